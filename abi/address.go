@@ -0,0 +1,17 @@
+package abi
+
+import (
+	"github.com/algorand/avm-abi/address"
+)
+
+// AddressValue returns the Go value of the address type for addr, which `Encode`,
+// `MarshalToJSON`, and `MarshalToMsgpack` accept for address values and address arguments.
+func AddressValue(addr address.Address) interface{} {
+	return [address.BytesSize]byte(addr)
+}
+
+// DecodeAddress returns the address held by an encoded address value, or by the result of decoding
+// one with `Decode`, which are both its 32 bytes.
+func DecodeAddress(encoded []byte) (address.Address, error) {
+	return address.FromBytes(encoded)
+}
@@ -0,0 +1,45 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/algorand/avm-abi/address"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressValue(t *testing.T) {
+	t.Parallel()
+
+	addr := address.ForApplication(1)
+	value := AddressValue(addr)
+
+	encoded, err := addressType.Encode(value)
+	require.NoError(t, err)
+	require.Equal(t, addr[:], encoded)
+
+	decoded, err := addressType.Decode(encoded)
+	require.NoError(t, err)
+	fromDecoded, err := DecodeAddress(decoded.([]byte))
+	require.NoError(t, err)
+	require.Equal(t, addr, fromDecoded)
+
+	jsonEncoded, err := addressType.MarshalToJSON(value)
+	require.NoError(t, err)
+	require.Equal(t, `"`+addr.String()+`"`, string(jsonEncoded))
+
+	msgpackEncoded, err := addressType.MarshalToMsgpack(value)
+	require.NoError(t, err)
+	msgpackDecoded, err := addressType.UnmarshalFromMsgpack(msgpackEncoded)
+	require.NoError(t, err)
+	require.Equal(t, addr[:], msgpackDecoded)
+
+	tupleType, err := TypeOf("(address,uint64)")
+	require.NoError(t, err)
+	_, err = tupleType.Encode([]interface{}{value, uint64(5)})
+	require.NoError(t, err)
+
+	_, err = DecodeAddress(encoded[:31])
+	require.ErrorIs(t, err, address.ErrWrongLength)
+	_, err = DecodeAddress(nil)
+	require.ErrorIs(t, err, address.ErrWrongLength)
+}
@@ -0,0 +1,64 @@
+package abi
+
+// Arg is a parsed method argument type, which is either an ABI type, a reference type such as
+// "account", or a transaction type such as "pay".
+type Arg struct {
+	kind ArgKind
+	// abiType is the type of value arguments
+	abiType Type
+	// typeStr is the type string of reference and transaction arguments
+	typeStr string
+}
+
+// ParseArg parses a method argument type string.
+func ParseArg(argType string) (Arg, error) {
+	kind := ArgKindOf(argType)
+	if kind != ValueArg {
+		return Arg{kind: kind, typeStr: argType}, nil
+	}
+	abiType, err := TypeOf(argType)
+	if err != nil {
+		return Arg{}, err
+	}
+	return Arg{kind: ValueArg, abiType: abiType}, nil
+}
+
+// Kind returns the kind of the argument.
+func (a Arg) Kind() ArgKind {
+	return a.kind
+}
+
+// Type returns the ABI type of a value argument. The second result is false for reference and
+// transaction arguments, which do not have an ABI type.
+func (a Arg) Type() (Type, bool) {
+	return a.abiType, a.kind == ValueArg
+}
+
+// IsEncoded reports whether the argument is encoded in the application arguments of a method call.
+// Value and reference arguments are encoded, while transaction arguments are passed as preceding
+// transactions in the group.
+func (a Arg) IsEncoded() bool {
+	return a.kind != TransactionArg
+}
+
+// EncodedType returns the type the argument is encoded as in the application arguments of a method
+// call: its ABI type for value arguments, or uint8 for reference arguments, whose values are
+// foreign array indexes. The second result is false for transaction arguments.
+func (a Arg) EncodedType() (Type, bool) {
+	switch a.kind {
+	case ValueArg:
+		return a.abiType, true
+	case ReferenceArg:
+		return uint8Type, true
+	default:
+		return Type{}, false
+	}
+}
+
+// String returns the canonical type string of the argument.
+func (a Arg) String() string {
+	if a.kind == ValueArg {
+		return a.abiType.String()
+	}
+	return a.typeStr
+}
@@ -0,0 +1,112 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArg(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		argType     string
+		kind        ArgKind
+		str         string
+		encoded     bool
+		encodedType string
+	}{
+		{argType: "uint064", kind: ValueArg, str: "uint64", encoded: true, encodedType: "uint64"},
+		{argType: "(bool,string[])", kind: ValueArg, str: "(bool,string[])", encoded: true, encodedType: "(bool,string[])"},
+		{argType: "account", kind: ReferenceArg, str: "account", encoded: true, encodedType: "uint8"},
+		{argType: "application", kind: ReferenceArg, str: "application", encoded: true, encodedType: "uint8"},
+		{argType: "txn", kind: TransactionArg, str: "txn", encoded: false},
+		{argType: "appl", kind: TransactionArg, str: "appl", encoded: false},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.argType, func(t *testing.T) {
+			t.Parallel()
+			arg, err := ParseArg(testCase.argType)
+			require.NoError(t, err)
+			require.Equal(t, testCase.kind, arg.Kind())
+			require.Equal(t, testCase.str, arg.String())
+			require.Equal(t, testCase.encoded, arg.IsEncoded())
+
+			abiType, ok := arg.Type()
+			require.Equal(t, testCase.kind == ValueArg, ok)
+			if ok {
+				require.Equal(t, testCase.str, abiType.String())
+			}
+			encodedType, ok := arg.EncodedType()
+			require.Equal(t, testCase.encoded, ok)
+			if ok {
+				require.Equal(t, testCase.encodedType, encodedType.String())
+			}
+		})
+	}
+
+	_, err := ParseArg("uint7")
+	require.Error(t, err)
+}
+
+func TestMethodParsedArgs(t *testing.T) {
+	t.Parallel()
+
+	method := Method{Name: "f", Args: []MethodArg{{Type: "pay"}, {Type: "asset"}, {Type: "byte[4]"}}}
+	args, err := method.ParsedArgs()
+	require.NoError(t, err)
+	require.Len(t, args, 3)
+	require.Equal(t, []ArgKind{TransactionArg, ReferenceArg, ValueArg}, []ArgKind{args[0].Kind(), args[1].Kind(), args[2].Kind()})
+	require.Equal(t, "byte[4]", args[2].String())
+
+	arg, err := method.Args[1].Parse()
+	require.NoError(t, err)
+	require.Equal(t, args[1], arg)
+
+	method.Args = append(method.Args, MethodArg{Type: "uint7"})
+	_, err = method.ParsedArgs()
+	require.ErrorContains(t, err, "Error parsing argument type at index 3 of method f")
+}
+
+func TestMethodStructTypes(t *testing.T) {
+	t.Parallel()
+
+	uintType, err := TypeOf("uint64")
+	require.NoError(t, err)
+	point, err := MakeNamedTupleType([]Type{uintType, uintType}, []string{"x", "y"})
+	require.NoError(t, err)
+
+	method := Method{
+		Name:    "move",
+		Args:    []MethodArg{{Type: "(uint64,uint64)", StructType: &point}, {Type: "uint64"}},
+		Returns: MethodReturn{Type: "(uint64,uint64)", StructType: &point},
+	}
+	require.Equal(t, "move((uint64,uint64),uint64)(uint64,uint64)", method.GetSignature())
+
+	args, err := method.ParsedArgs()
+	require.NoError(t, err)
+	argType, ok := args[0].Type()
+	require.True(t, ok)
+	require.Equal(t, []string{"x", "y"}, argType.FieldNames())
+	argType, ok = args[1].Type()
+	require.True(t, ok)
+	require.Nil(t, argType.FieldNames())
+
+	returnType, err := method.Returns.Parse()
+	require.NoError(t, err)
+	require.Equal(t, point, returnType)
+	encoded, err := returnType.MarshalToJSON([]interface{}{1, 2})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"x": 1, "y": 2}`, string(encoded))
+
+	eventArg := EventArg{Type: "(uint64,uint64)", StructType: &point}
+	eventArgType, err := eventArg.Parse()
+	require.NoError(t, err)
+	require.Equal(t, point, eventArgType)
+
+	_, err = MethodArg{Type: "(uint64,uint32)", StructType: &point}.Parse()
+	require.EqualError(t, err, "struct type (uint64,uint64) does not match type (uint64,uint32)")
+	_, err = MethodReturn{Type: VoidReturnType}.Parse()
+	require.EqualError(t, err, "void return value has no ABI type")
+}
@@ -0,0 +1,206 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ContractBuilder builds a contract description from method and event signatures, so contracts
+// can be defined in Go and published as ARC-4 JSON with `json.Marshal`. Errors are collected and
+// returned by Build, so definitions can be chained without checking each step.
+type ContractBuilder struct {
+	contract Contract
+	// emits holds the names of the events emitted by each method, by method index
+	emits [][]string
+	errs  []error
+}
+
+// MethodBuilder adds details to a method of a ContractBuilder.
+type MethodBuilder struct {
+	builder *ContractBuilder
+	// index is the position of the method in the contract, or -1 if its signature is invalid
+	index int
+}
+
+// EventBuilder adds details to an event of a ContractBuilder.
+type EventBuilder struct {
+	builder *ContractBuilder
+	// index is the position of the event in the contract, or -1 if its signature is invalid
+	index int
+}
+
+// NewContractBuilder creates a builder for a contract named name.
+func NewContractBuilder(name string) *ContractBuilder {
+	return &ContractBuilder{contract: Contract{Name: name, Methods: []Method{}}}
+}
+
+// Desc sets the description of the contract.
+func (b *ContractBuilder) Desc(desc string) *ContractBuilder {
+	b.contract.Desc = desc
+	return b
+}
+
+// Network records that the contract is deployed as the application appID on the network with the
+// given base64 encoded genesis hash.
+func (b *ContractBuilder) Network(genesisHash string, appID uint64) *ContractBuilder {
+	if err := b.contract.SetAppIDForNetwork(genesisHash, appID); err != nil {
+		b.errs = append(b.errs, err)
+	}
+	return b
+}
+
+// Method adds the method with the given signature, such as "add(uint64,uint64)uint128", to the
+// contract, and returns a builder to describe it.
+func (b *ContractBuilder) Method(signature string) *MethodBuilder {
+	method, err := MethodFromSignature(signature)
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("invalid method signature %s: %w", signature, err))
+		return &MethodBuilder{builder: b, index: -1}
+	}
+	b.contract.Methods = append(b.contract.Methods, method)
+	b.emits = append(b.emits, nil)
+	return &MethodBuilder{builder: b, index: len(b.contract.Methods) - 1}
+}
+
+// Event adds the ARC-28 event with the given signature, such as "Transfer(address,uint64)", to the
+// contract, and returns a builder to describe it.
+func (b *ContractBuilder) Event(signature string) *EventBuilder {
+	event, err := EventFromSignature(signature)
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("invalid event signature %s: %w", signature, err))
+		return &EventBuilder{builder: b, index: -1}
+	}
+	b.contract.Events = append(b.contract.Events, event)
+	return &EventBuilder{builder: b, index: len(b.contract.Events) - 1}
+}
+
+// Build returns the contract, or an error joining every problem found while building it and by
+// `Contract.Validate`.
+func (b *ContractBuilder) Build() (Contract, error) {
+	errs := append([]error{}, b.errs...)
+	// the built contract does not share slices with the builder, which may still be modified
+	contract := b.contract
+	contract.Methods = make([]Method, len(b.contract.Methods))
+	for i, method := range b.contract.Methods {
+		method.Args = append([]MethodArg{}, method.Args...)
+		contract.Methods[i] = method
+	}
+	contract.Events = nil
+	for _, event := range b.contract.Events {
+		event.Args = append([]EventArg{}, event.Args...)
+		contract.Events = append(contract.Events, event)
+	}
+	if b.contract.Networks != nil {
+		contract.Networks = make(map[string]ContractNetworkInfo, len(b.contract.Networks))
+		for genesisHash, info := range b.contract.Networks {
+			contract.Networks[genesisHash] = info
+		}
+	}
+	for i, eventNames := range b.emits {
+		method := &contract.Methods[i]
+		for _, eventName := range eventNames {
+			event, ok := eventByName(contract.Events, eventName)
+			if !ok {
+				errs = append(errs, fmt.Errorf(`method %s emits unknown event "%s"`, method.GetSignature(), eventName))
+				continue
+			}
+			method.Events = append(method.Events, event)
+		}
+	}
+	if err := contract.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return Contract{}, err
+	}
+	contract.selectors = &selectorIndex{}
+	return contract, nil
+}
+
+func eventByName(events []Event, name string) (Event, bool) {
+	for _, event := range events {
+		if event.Name == name {
+			return event, true
+		}
+	}
+	return Event{}, false
+}
+
+// method returns the method being built, or nil if its signature is invalid.
+func (m *MethodBuilder) method() *Method {
+	if m.index < 0 {
+		return nil
+	}
+	return &m.builder.contract.Methods[m.index]
+}
+
+// Desc sets the description of the method.
+func (m *MethodBuilder) Desc(desc string) *MethodBuilder {
+	if method := m.method(); method != nil {
+		method.Desc = desc
+	}
+	return m
+}
+
+// Arg sets the name and description of the argument at index.
+func (m *MethodBuilder) Arg(index int, name, desc string) *MethodBuilder {
+	method := m.method()
+	if method == nil {
+		return m
+	}
+	if index < 0 || index >= len(method.Args) {
+		m.builder.errs = append(m.builder.errs, fmt.Errorf("method %s has no argument at index %d", method.GetSignature(), index))
+		return m
+	}
+	method.Args[index].Name = name
+	method.Args[index].Desc = desc
+	return m
+}
+
+// Returns sets the description of the return value of the method.
+func (m *MethodBuilder) Returns(desc string) *MethodBuilder {
+	if method := m.method(); method != nil {
+		method.Returns.Desc = desc
+	}
+	return m
+}
+
+// ReadOnly sets the ARC-22 read-only flag of the method.
+func (m *MethodBuilder) ReadOnly() *MethodBuilder {
+	if method := m.method(); method != nil {
+		method.ReadOnly = true
+	}
+	return m
+}
+
+// Emits records that the method may emit the events of the contract with the given names. Events
+// are looked up when the contract is built, so they may be added after the method.
+func (m *MethodBuilder) Emits(eventNames ...string) *MethodBuilder {
+	if m.index >= 0 {
+		m.builder.emits[m.index] = append(m.builder.emits[m.index], eventNames...)
+	}
+	return m
+}
+
+// Desc sets the description of the event.
+func (e *EventBuilder) Desc(desc string) *EventBuilder {
+	if e.index >= 0 {
+		e.builder.contract.Events[e.index].Desc = desc
+	}
+	return e
+}
+
+// Arg sets the name and description of the argument at index.
+func (e *EventBuilder) Arg(index int, name, desc string) *EventBuilder {
+	if e.index < 0 {
+		return e
+	}
+	event := &e.builder.contract.Events[e.index]
+	if index < 0 || index >= len(event.Args) {
+		e.builder.errs = append(e.builder.errs, fmt.Errorf("event %s has no argument at index %d", event.GetSignature(), index))
+		return e
+	}
+	event.Args[index].Name = name
+	event.Args[index].Desc = desc
+	return e
+}
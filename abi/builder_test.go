@@ -0,0 +1,90 @@
+package abi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractBuilder(t *testing.T) {
+	t.Parallel()
+
+	const mainNet = "wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8="
+
+	builder := NewContractBuilder("Counter").Desc("Counts things").Network(mainNet, 1234)
+	builder.Method("increment(uint064)void").
+		Desc("Increments the counter").
+		Arg(0, "amount", "The amount to add").
+		Emits("Incremented")
+	builder.Method("get()uint64").Returns("The counter").ReadOnly()
+	eventBuilder := builder.Event("Incremented(uint64, uint64)").Desc("The counter was incremented").Arg(1, "total", "")
+
+	contract, err := builder.Build()
+	require.NoError(t, err)
+
+	incremented := Event{
+		Name: "Incremented",
+		Desc: "The counter was incremented",
+		Args: []EventArg{{Type: "uint64"}, {Name: "total", Type: "uint64"}},
+	}
+	require.Equal(t, "Counter", contract.Name)
+	require.Equal(t, "Counts things", contract.Desc)
+	require.Equal(t, map[string]ContractNetworkInfo{mainNet: {AppID: 1234}}, contract.Networks)
+	require.Equal(t, []Method{
+		{
+			Name:    "increment",
+			Desc:    "Increments the counter",
+			Args:    []MethodArg{{Name: "amount", Type: "uint64", Desc: "The amount to add"}},
+			Returns: MethodReturn{Type: "void"},
+			Events:  []Event{incremented},
+		},
+		{Name: "get", Args: []MethodArg{}, Returns: MethodReturn{Type: "uint64", Desc: "The counter"}, ReadOnly: true},
+	}, contract.Methods)
+	require.Equal(t, []Event{incremented}, contract.Events)
+
+	method, err := contract.MethodBySelector(contract.Methods[1].GetSelector())
+	require.NoError(t, err)
+	require.Equal(t, "get", method.Name)
+
+	// the built contract round-trips through JSON
+	encoded, err := json.Marshal(contract)
+	require.NoError(t, err)
+	var decoded Contract
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.ElementsMatch(t, contract.SelectorTable(), decoded.SelectorTable())
+
+	// modifying the builder does not modify built contracts
+	builder.Method("reset()void")
+	eventBuilder.Arg(0, "changed", "")
+	require.Len(t, contract.Methods, 2)
+	require.Equal(t, "", contract.Events[0].Args[0].Name)
+}
+
+func TestContractBuilderErrors(t *testing.T) {
+	t.Parallel()
+
+	builder := NewContractBuilder("").Network("AAAA", 1)
+	builder.Method("add(uint7)void").Desc("ignored").Arg(0, "a", "").Emits("Added")
+	builder.Method("get()uint64").Arg(1, "missing", "").Emits("Missing")
+	builder.Method("get()uint64")
+	builder.Event("Added(pay)").Desc("ignored")
+	builder.Event("Added()uint64")
+	builder.Event("Set(uint64)").Arg(-1, "missing", "")
+
+	_, err := builder.Build()
+	require.Error(t, err)
+	for _, message := range []string{
+		`network key "AAAA" is not a base64 encoded genesis hash`,
+		"invalid method signature add(uint7)void",
+		"method get()uint64 has no argument at index 1",
+		`invalid event signature Added(pay): Error parsing argument type at index 0 of event Added`,
+		`invalid event signature Added()uint64: Event signature has a return type: "Added()uint64"`,
+		"event Set(uint64) has no argument at index -1",
+		`method get()uint64 emits unknown event "Missing"`,
+		"contract has no name",
+		"methods at index 0 and 1 have the same signature get()uint64",
+	} {
+		require.ErrorContains(t, err, message)
+	}
+}
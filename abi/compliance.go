@@ -0,0 +1,96 @@
+package abi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MethodMismatch describes a method of an interface which a contract does not implement, but which
+// the contract has methods resembling.
+type MethodMismatch struct {
+	// Expected is the method of the interface.
+	Expected Method
+	// Actual are the methods of the contract which have the same name or the same selector as the
+	// expected method, but a different signature.
+	Actual []Method
+}
+
+// ComplianceReport describes how a contract conforms to an interface, as returned by
+// `CheckCompliance`. Each method of the interface is in exactly one of Implemented, Missing, and
+// Mismatched, in the order of the interface.
+type ComplianceReport struct {
+	// Contract is the name of the contract.
+	Contract string
+	// Interface is the name of the interface.
+	Interface string
+	// Implemented are the methods of the interface which the contract has with the same signature.
+	Implemented []Method
+	// Missing are the methods of the interface which the contract has nothing resembling.
+	Missing []Method
+	// Mismatched are the methods of the interface which the contract only has with a different
+	// signature, such as a different return type, or whose selector belongs to another method.
+	Mismatched []MethodMismatch
+}
+
+// CheckCompliance reports which methods of the interface the contract implements, which are
+// missing, and which the contract has with a mismatched signature or selector. Methods are
+// implemented when the contract has a method with the same signature.
+func CheckCompliance(c Contract, i Interface) ComplianceReport {
+	report := ComplianceReport{Contract: c.Name, Interface: i.Name}
+
+	signatures := make(map[string]bool, len(c.Methods))
+	byName := make(map[string][]int)
+	bySelector := make(map[Selector][]int)
+	for index, method := range c.Methods {
+		signatures[method.GetSignature()] = true
+		byName[method.Name] = append(byName[method.Name], index)
+		selector := method.GetSelector()
+		bySelector[selector] = append(bySelector[selector], index)
+	}
+
+	for _, method := range i.Methods {
+		if signatures[method.GetSignature()] {
+			report.Implemented = append(report.Implemented, method)
+			continue
+		}
+		var actual []Method
+		seen := make(map[int]bool)
+		for _, index := range append(byName[method.Name], bySelector[method.GetSelector()]...) {
+			if !seen[index] {
+				seen[index] = true
+				actual = append(actual, c.Methods[index])
+			}
+		}
+		if len(actual) == 0 {
+			report.Missing = append(report.Missing, method)
+		} else {
+			report.Mismatched = append(report.Mismatched, MethodMismatch{Expected: method, Actual: actual})
+		}
+	}
+	return report
+}
+
+// Compliant reports whether the contract implements every method of the interface.
+func (r ComplianceReport) Compliant() bool {
+	return len(r.Missing) == 0 && len(r.Mismatched) == 0
+}
+
+// String renders the report with a summary line followed by one line per method which is not
+// implemented, prefixed with "-" for missing methods and "~" for mismatched ones, suitable for CI
+// output.
+func (r ComplianceReport) String() string {
+	var b strings.Builder
+	total := len(r.Implemented) + len(r.Missing) + len(r.Mismatched)
+	fmt.Fprintf(&b, "contract %s implements %d of %d methods of interface %s\n", r.Contract, len(r.Implemented), total, r.Interface)
+	for _, method := range r.Missing {
+		b.WriteString("- " + method.GetSignature() + "\n")
+	}
+	for _, mismatch := range r.Mismatched {
+		actual := make([]string, len(mismatch.Actual))
+		for index, method := range mismatch.Actual {
+			actual[index] = method.GetSignature()
+		}
+		b.WriteString("~ " + mismatch.Expected.GetSignature() + ": found " + strings.Join(actual, ", ") + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCompliance(t *testing.T) {
+	t.Parallel()
+
+	increment := Method{Name: "increment", Args: []MethodArg{{Type: "uint64"}}, Returns: MethodReturn{Type: "void"}}
+	get := Method{Name: "get", Returns: MethodReturn{Type: "uint64"}}
+	reset := Method{Name: "reset", Returns: MethodReturn{Type: "void"}}
+	iface := Interface{Name: "Counter", Methods: []Method{increment, get, reset}}
+
+	full := Contract{Name: "Full", Methods: []Method{{Name: "extra", Returns: MethodReturn{Type: "void"}}, increment, get, reset}}
+	report := CheckCompliance(full, iface)
+	require.True(t, report.Compliant())
+	require.Equal(t, []Method{increment, get, reset}, report.Implemented)
+	require.Empty(t, report.Missing)
+	require.Empty(t, report.Mismatched)
+	require.Equal(t, "contract Full implements 3 of 3 methods of interface Counter\n", report.String())
+
+	getUint32 := Method{Name: "get", Returns: MethodReturn{Type: "uint32"}}
+	getString := Method{Name: "get", Args: []MethodArg{{Type: "string"}}, Returns: MethodReturn{Type: "uint64"}}
+	partial := Contract{
+		Name: "Partial",
+		Methods: []Method{
+			{Name: "increment", Args: []MethodArg{{Type: "uint64", Name: "amount"}}, Returns: MethodReturn{Type: "void"}},
+			getUint32,
+			getString,
+		},
+	}
+	report = CheckCompliance(partial, iface)
+	require.False(t, report.Compliant())
+	require.Equal(t, ComplianceReport{
+		Contract:    "Partial",
+		Interface:   "Counter",
+		Implemented: []Method{increment},
+		Missing:     []Method{reset},
+		Mismatched:  []MethodMismatch{{Expected: get, Actual: []Method{getUint32, getString}}},
+	}, report)
+	require.Equal(t, `contract Partial implements 1 of 3 methods of interface Counter
+- reset()void
+~ get()uint64: found get()uint32, get(string)uint64
+`, report.String())
+
+	report = CheckCompliance(partial, Interface{Name: "Empty"})
+	require.True(t, report.Compliant())
+}
@@ -0,0 +1,281 @@
+package abi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ContractNetworkInfo describes a deployment of a contract on a network.
+type ContractNetworkInfo struct {
+	// AppID is the ID of the application implementing the contract on the network.
+	AppID uint64 `json:"appID"`
+}
+
+// Contract is an ARC-4 contract description, which is usually loaded from a contract.json file.
+type Contract struct {
+	// Name is the name of the contract.
+	Name string `json:"name"`
+	// Desc is an optional description of the contract.
+	Desc string `json:"desc,omitempty"`
+	// Networks maps the base64 encoded genesis hash of each network the contract is deployed on to
+	// information about the deployment.
+	Networks map[string]ContractNetworkInfo `json:"networks,omitempty"`
+	// Methods are the methods of the contract.
+	Methods []Method `json:"methods"`
+	// Events are the ARC-28 events the contract may emit.
+	Events []Event `json:"events,omitempty"`
+
+	// selectors indexes Methods by selector for MethodBySelector. It is nil for contracts which
+	// were not created by this package, such as struct literals.
+	selectors *selectorIndex
+}
+
+// UnmarshalJSON parses an ARC-4 contract description, and verifies that the contract has a name
+// and that the argument and return types of its methods, and the argument types of its events, are
+// valid. Fields which are not part of the ARC-4 or ARC-28 contract description are ignored.
+func (c *Contract) UnmarshalJSON(data []byte) error {
+	// contractJSON has the same fields as Contract, without its UnmarshalJSON method
+	type contractJSON Contract
+	var parsed contractJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+	if parsed.Name == "" {
+		return fmt.Errorf("contract has no name")
+	}
+	for i, method := range parsed.Methods {
+		if err := method.verifyTypes(); err != nil {
+			return fmt.Errorf("invalid method at index %d of contract %s: %w", i, parsed.Name, err)
+		}
+	}
+	for i, event := range parsed.Events {
+		if err := event.verifyTypes(); err != nil {
+			return fmt.Errorf("invalid event at index %d of contract %s: %w", i, parsed.Name, err)
+		}
+	}
+	*c = Contract(parsed)
+	c.selectors = &selectorIndex{}
+	return nil
+}
+
+// MarshalJSON encodes the contract as an ARC-4 contract description. Fields are in a fixed order,
+// types are rendered in their canonical form, and networks are sorted by genesis hash, so
+// equivalent contracts are encoded identically. Methods and events keep the order they were
+// declared in, which is the order of `SelectorTable`, so a description which is unmarshaled and
+// marshaled again is not reordered.
+func (c Contract) MarshalJSON() ([]byte, error) {
+	// contractJSON has the same fields as Contract, without its MarshalJSON method
+	type contractJSON Contract
+	return json.Marshal(contractJSON(c))
+}
+
+// MethodBySelector returns the first method of the contract with the given selector, which is
+// usually the first application argument of a method call.
+//
+// Contracts parsed by UnmarshalJSON or created by `ContractBuilder` and `ComposeContract` index
+// their methods by selector on the first lookup, so later lookups take constant time and do not
+// compute selectors. The index is shared by copies of the contract, and is rebuilt when the name or
+// types of a method change. Other contracts, such as struct literals, compute the selector of each
+// method in order; use `Contract.NewMethodIndex` to look up many calls of such contracts.
+func (c Contract) MethodBySelector(selector Selector) (Method, error) {
+	if c.selectors != nil {
+		position, found, valid := c.selectors.lookup(c.Methods, selector)
+		if !valid {
+			c.selectors.rebuild(c.Methods)
+			position, found, _ = c.selectors.lookup(c.Methods, selector)
+		}
+		if found {
+			return c.Methods[position], nil
+		}
+		return Method{}, fmt.Errorf("contract %s has no method with selector %s", c.Name, selector)
+	}
+	for _, method := range c.Methods {
+		if method.GetSelector() == selector {
+			return method, nil
+		}
+	}
+	return Method{}, fmt.Errorf("contract %s has no method with selector %s", c.Name, selector)
+}
+
+// MethodByName returns the method of the contract with the given name. If several methods share
+// the name, argCount must give the number of arguments of the wanted method; an error listing the
+// signatures of the candidates is returned if the method is still ambiguous.
+func (c Contract) MethodByName(name string, argCount ...int) (Method, error) {
+	if len(argCount) > 1 {
+		return Method{}, fmt.Errorf("at most one argument count can be given, got %d", len(argCount))
+	}
+
+	var matches []Method
+	for _, method := range c.Methods {
+		if method.Name != name {
+			continue
+		}
+		if len(argCount) == 1 && len(method.Args) != argCount[0] {
+			continue
+		}
+		matches = append(matches, method)
+	}
+
+	var withArgCount string
+	if len(argCount) == 1 {
+		withArgCount = fmt.Sprintf(" with %d arguments", argCount[0])
+	}
+	switch len(matches) {
+	case 0:
+		return Method{}, fmt.Errorf(`contract %s has no method named "%s"%s`, c.Name, name, withArgCount)
+	case 1:
+		return matches[0], nil
+	default:
+		signatures := make([]string, len(matches))
+		for i, method := range matches {
+			signatures[i] = method.GetSignature()
+		}
+		return Method{}, fmt.Errorf(`contract %s has %d methods named "%s"%s, which is ambiguous: %s`,
+			c.Name, len(matches), name, withArgCount, strings.Join(signatures, ", "))
+	}
+}
+
+// genesisHashSize is the size in bytes of a network's genesis hash.
+const genesisHashSize = 32
+
+// isGenesisHash reports whether genesisHash is a base64 encoded genesis hash.
+func isGenesisHash(genesisHash string) bool {
+	decoded, err := base64.StdEncoding.DecodeString(genesisHash)
+	return err == nil && len(decoded) == genesisHashSize
+}
+
+// AppIDForNetwork returns the ID of the application implementing the contract on the network with
+// the given base64 encoded genesis hash, and whether the contract is deployed on that network.
+func (c Contract) AppIDForNetwork(genesisHash string) (uint64, bool) {
+	info, ok := c.Networks[genesisHash]
+	if !ok || info.AppID == 0 {
+		return 0, false
+	}
+	return info.AppID, true
+}
+
+// SetAppIDForNetwork records that the contract is deployed as the application appID on the network
+// with the given base64 encoded genesis hash, replacing any previous deployment on that network.
+func (c *Contract) SetAppIDForNetwork(genesisHash string, appID uint64) error {
+	if !isGenesisHash(genesisHash) {
+		return fmt.Errorf(`network key "%s" is not a base64 encoded genesis hash`, genesisHash)
+	}
+	if appID == 0 {
+		return fmt.Errorf(`cannot set app ID 0 for network "%s"`, genesisHash)
+	}
+	if c.Networks == nil {
+		c.Networks = make(map[string]ContractNetworkInfo)
+	}
+	info := c.Networks[genesisHash]
+	info.AppID = appID
+	c.Networks[genesisHash] = info
+	return nil
+}
+
+// RemoveNetwork removes the deployment of the contract on the network with the given base64
+// encoded genesis hash, if there is one.
+func (c *Contract) RemoveNetwork(genesisHash string) {
+	delete(c.Networks, genesisHash)
+}
+
+// Validate checks the whole contract description and returns an error joining every problem found,
+// or nil if there is none. The joined errors can be listed with `Unwrap() []error`. It checks that:
+//
+//   - the contract and all its methods and events have names
+//   - every argument type is an ABI type, a reference type, or a transaction type, and every
+//     return type is an ABI type or void
+//   - every event argument type is an ABI type
+//   - no two methods have the same signature or selector
+//   - no two events have the same name or selector
+//   - every network key is a base64 encoded 32 byte genesis hash, and every app ID is not zero
+//   - every name and description is valid UTF-8, so the contract round-trips through JSON
+func (c Contract) Validate() error {
+	var errs []error
+	checkText := func(text, context string) {
+		if !utf8.ValidString(text) {
+			errs = append(errs, fmt.Errorf("%s is not valid UTF-8", context))
+		}
+	}
+	checkEventText := func(event Event) {
+		checkText(event.Name, "event name")
+		checkText(event.Desc, fmt.Sprintf("description of event %s", event.Name))
+		for j, arg := range event.Args {
+			checkText(arg.Name, fmt.Sprintf("name of argument %d of event %s", j, event.Name))
+			checkText(arg.Desc, fmt.Sprintf("description of argument %d of event %s", j, event.Name))
+		}
+	}
+
+	if c.Name == "" {
+		errs = append(errs, fmt.Errorf("contract has no name"))
+	}
+	checkText(c.Name, "contract name")
+	checkText(c.Desc, "contract description")
+
+	signatures := make(map[string]int, len(c.Methods))
+	selectors := make(map[Selector]int, len(c.Methods))
+	for i, method := range c.Methods {
+		if err := method.verifyTypes(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid method at index %d: %w", i, err))
+		}
+		signature := method.GetSignature()
+		selector := method.GetSelector()
+		if first, ok := signatures[signature]; ok {
+			errs = append(errs, fmt.Errorf("methods at index %d and %d have the same signature %s", first, i, signature))
+		} else if first, ok := selectors[selector]; ok {
+			errs = append(errs, fmt.Errorf("methods at index %d and %d have the same selector %s", first, i, selector))
+		} else {
+			signatures[signature] = i
+			selectors[selector] = i
+		}
+		checkText(method.Name, fmt.Sprintf("name of method at index %d", i))
+		checkText(method.Desc, fmt.Sprintf("description of method %s", method.Name))
+		for j, arg := range method.Args {
+			checkText(arg.Name, fmt.Sprintf("name of argument %d of method %s", j, method.Name))
+			checkText(arg.Desc, fmt.Sprintf("description of argument %d of method %s", j, method.Name))
+		}
+		checkText(method.Returns.Desc, fmt.Sprintf("description of return value of method %s", method.Name))
+		for _, event := range method.Events {
+			checkEventText(event)
+		}
+	}
+
+	eventNames := make(map[string]int, len(c.Events))
+	eventSelectors := make(map[Selector]int, len(c.Events))
+	for i, event := range c.Events {
+		if err := event.verifyTypes(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid event at index %d: %w", i, err))
+		}
+		selector := event.GetSelector()
+		if first, ok := eventNames[event.Name]; ok {
+			errs = append(errs, fmt.Errorf("events at index %d and %d have the same name %s", first, i, event.Name))
+		} else if first, ok := eventSelectors[selector]; ok {
+			errs = append(errs, fmt.Errorf("events at index %d and %d have the same selector %s", first, i, selector))
+		} else {
+			eventNames[event.Name] = i
+			eventSelectors[selector] = i
+		}
+		checkEventText(event)
+	}
+
+	genesisHashes := make([]string, 0, len(c.Networks))
+	for genesisHash := range c.Networks {
+		genesisHashes = append(genesisHashes, genesisHash)
+	}
+	sort.Strings(genesisHashes)
+	for _, genesisHash := range genesisHashes {
+		info := c.Networks[genesisHash]
+		if !isGenesisHash(genesisHash) {
+			errs = append(errs, fmt.Errorf(`network key "%s" is not a base64 encoded genesis hash`, genesisHash))
+		}
+		if info.AppID == 0 {
+			errs = append(errs, fmt.Errorf(`network "%s" has app ID 0`, genesisHash))
+		}
+	}
+
+	return errors.Join(errs...)
+}
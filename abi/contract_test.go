@@ -0,0 +1,358 @@
+package abi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const exampleContractJSON = `{
+	"name": "Calculator",
+	"desc": "Performs arithmetic",
+	"networks": {
+		"wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8=": {"appID": 1234},
+		"SGO1GKSzyE7IEPItTxCByw9x8FmnrCDexi9/cOUJOiI=": {"appID": 5678}
+	},
+	"methods": [
+		{
+			"name": "add",
+			"desc": "Add two numbers",
+			"args": [
+				{"type": "uint64", "name": "a", "desc": "The first number"},
+				{"type": "uint64", "name": "b"}
+			],
+			"returns": {"type": "uint128", "desc": "The sum"}
+		},
+		{
+			"name": "deposit",
+			"args": [{"type": "pay"}, {"type": "account"}],
+			"returns": {"type": "void"},
+			"readonly": false
+		}
+	]
+}`
+
+func TestContractUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var contract Contract
+	require.NoError(t, json.Unmarshal([]byte(exampleContractJSON), &contract))
+
+	expected := Contract{
+		Name: "Calculator",
+		Desc: "Performs arithmetic",
+		Networks: map[string]ContractNetworkInfo{
+			"wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8=": {AppID: 1234},
+			"SGO1GKSzyE7IEPItTxCByw9x8FmnrCDexi9/cOUJOiI=": {AppID: 5678},
+		},
+		Methods: []Method{
+			{
+				Name: "add",
+				Desc: "Add two numbers",
+				Args: []MethodArg{
+					{Type: "uint64", Name: "a", Desc: "The first number"},
+					{Type: "uint64", Name: "b"},
+				},
+				Returns: MethodReturn{Type: "uint128", Desc: "The sum"},
+			},
+			{
+				Name:    "deposit",
+				Args:    []MethodArg{{Type: "pay"}, {Type: "account"}},
+				Returns: MethodReturn{Type: "void"},
+			},
+		},
+		selectors: &selectorIndex{},
+	}
+	require.Equal(t, expected, contract)
+
+	selector := contract.Methods[0].GetSelector()
+	require.Equal(t, Selector{0x8a, 0xa3, 0xb6, 0x1f}, selector)
+
+	// the parsed contract round-trips through JSON
+	encoded, err := json.Marshal(contract)
+	require.NoError(t, err)
+	var decoded Contract
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, contract, decoded)
+
+	errorCases := []struct {
+		input string
+		err   string
+	}{
+		{input: `{"methods": []}`, err: "contract has no name"},
+		{input: `{"name": "c", "methods": [{"args": [], "returns": {"type": "void"}}]}`, err: "invalid method at index 0 of contract c: method has no name"},
+		{input: `{"name": "c", "methods": [{"name": "m", "args": [{"type": "uint7"}], "returns": {"type": "void"}}]}`, err: "Error parsing argument type at index 0 of method m"},
+		{input: `{"name": "c", "methods": [{"name": "m", "args": [], "returns": {"type": "pay"}}]}`, err: "Error parsing return type of method m"},
+		{input: `{"name": "c", "methods": [{"name": "m", "args": []}]}`, err: "Error parsing return type of method m"},
+		{input: `{"name": 1}`, err: "cannot unmarshal number"},
+	}
+	for _, errorCase := range errorCases {
+		var contract Contract
+		err := json.Unmarshal([]byte(errorCase.input), &contract)
+		require.ErrorContains(t, err, errorCase.err)
+	}
+}
+
+func TestContractMethodBySelector(t *testing.T) {
+	t.Parallel()
+
+	var contract Contract
+	require.NoError(t, json.Unmarshal([]byte(exampleContractJSON), &contract))
+
+	addSelector := [4]byte{0x8a, 0xa3, 0xb6, 0x1f}
+	method, err := contract.MethodBySelector(addSelector)
+	require.NoError(t, err)
+	require.Equal(t, "add", method.Name)
+
+	method, err = contract.MethodBySelector(contract.Methods[1].GetSelector())
+	require.NoError(t, err)
+	require.Equal(t, "deposit", method.Name)
+
+	_, err = contract.MethodBySelector([4]byte{1, 2, 3, 4})
+	require.EqualError(t, err, "contract Calculator has no method with selector 01020304")
+
+	// a method modified in place is found by its new selector
+	contract.Methods[0].Returns.Type = "uint64"
+	_, err = contract.MethodBySelector(addSelector)
+	require.Error(t, err)
+	method, err = contract.MethodBySelector(contract.Methods[0].GetSelector())
+	require.NoError(t, err)
+	require.Equal(t, contract.Methods[0], method)
+
+	built := Contract{
+		Name: "Built",
+		Methods: []Method{
+			{Name: "add", Args: []MethodArg{{Type: "uint64"}, {Type: "uint64"}}, Returns: MethodReturn{Type: "uint128"}},
+		},
+	}
+	method, err = built.MethodBySelector(addSelector)
+	require.NoError(t, err)
+	require.Equal(t, "add", method.Name)
+
+	contract.Methods = append(contract.Methods, built.Methods[0])
+	method, err = contract.MethodBySelector(addSelector)
+	require.NoError(t, err)
+	require.Equal(t, built.Methods[0], method)
+
+	_, err = Contract{Name: "Empty"}.MethodBySelector(addSelector)
+	require.Error(t, err)
+}
+
+func TestContractMethodBySelectorIndex(t *testing.T) {
+	t.Parallel()
+
+	var contract Contract
+	require.NoError(t, json.Unmarshal([]byte(exampleContractJSON), &contract))
+	depositSelector := contract.Methods[1].GetSelector()
+
+	// the index is built by the first lookup, and shared by copies of the contract
+	copied := contract
+	_, err := copied.MethodBySelector(depositSelector)
+	require.NoError(t, err)
+	index := contract.selectors.bySelector
+	require.Len(t, index, 2)
+
+	// later lookups trust the index instead of computing selectors, so a selector added to it
+	// is found
+	fake := Selector{1, 2, 3, 4}
+	contract.selectors.bySelector[fake] = 1
+	method, err := contract.MethodBySelector(fake)
+	require.NoError(t, err)
+	require.Equal(t, "deposit", method.Name)
+	method, err = contract.MethodBySelector(depositSelector)
+	require.NoError(t, err)
+	require.Equal(t, "deposit", method.Name)
+	_, err = contract.MethodBySelector(Selector{5, 6, 7, 8})
+	require.Error(t, err)
+	require.Equal(t, reflect.ValueOf(index).Pointer(), reflect.ValueOf(contract.selectors.bySelector).Pointer())
+
+	// changing the types of a method rebuilds the index
+	contract.Methods[1].Args[1].Type = "application"
+	_, err = contract.MethodBySelector(fake)
+	require.Error(t, err)
+	method, err = contract.MethodBySelector(contract.Methods[1].GetSelector())
+	require.NoError(t, err)
+	require.Equal(t, contract.Methods[1], method)
+	_, err = contract.MethodBySelector(depositSelector)
+	require.Error(t, err)
+}
+
+func TestContractMethodByName(t *testing.T) {
+	t.Parallel()
+
+	contract := Contract{
+		Name: "Overloads",
+		Methods: []Method{
+			{Name: "add", Args: []MethodArg{{Type: "uint64"}, {Type: "uint64"}}, Returns: MethodReturn{Type: "uint64"}},
+			{Name: "add", Args: []MethodArg{{Type: "uint64"}, {Type: "uint64"}, {Type: "uint64"}}, Returns: MethodReturn{Type: "uint64"}},
+			{Name: "set", Args: []MethodArg{{Type: "uint64"}}, Returns: MethodReturn{Type: "void"}},
+			{Name: "set", Args: []MethodArg{{Type: "string"}}, Returns: MethodReturn{Type: "void"}},
+			{Name: "get", Returns: MethodReturn{Type: "uint64"}},
+		},
+	}
+
+	method, err := contract.MethodByName("get")
+	require.NoError(t, err)
+	require.Equal(t, contract.Methods[4], method)
+
+	method, err = contract.MethodByName("get", 0)
+	require.NoError(t, err)
+	require.Equal(t, contract.Methods[4], method)
+
+	method, err = contract.MethodByName("add", 3)
+	require.NoError(t, err)
+	require.Equal(t, contract.Methods[1], method)
+
+	_, err = contract.MethodByName("add")
+	require.EqualError(t, err, `contract Overloads has 2 methods named "add", which is ambiguous: add(uint64,uint64)uint64, add(uint64,uint64,uint64)uint64`)
+
+	_, err = contract.MethodByName("set", 1)
+	require.EqualError(t, err, `contract Overloads has 2 methods named "set" with 1 arguments, which is ambiguous: set(uint64)void, set(string)void`)
+
+	_, err = contract.MethodByName("add", 1)
+	require.EqualError(t, err, `contract Overloads has no method named "add" with 1 arguments`)
+
+	_, err = contract.MethodByName("missing")
+	require.EqualError(t, err, `contract Overloads has no method named "missing"`)
+
+	_, err = contract.MethodByName("add", 2, 3)
+	require.EqualError(t, err, "at most one argument count can be given, got 2")
+}
+
+func TestContractValidate(t *testing.T) {
+	t.Parallel()
+
+	var contract Contract
+	require.NoError(t, json.Unmarshal([]byte(exampleContractJSON), &contract))
+	require.NoError(t, contract.Validate())
+
+	invalid := Contract{
+		Desc: "bad \xff",
+		Networks: map[string]ContractNetworkInfo{
+			"wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8=": {AppID: 0},
+			"not-base64": {AppID: 1},
+			"AAAA":       {AppID: 1},
+		},
+		Methods: []Method{
+			{Name: "add", Args: []MethodArg{{Type: "uint64"}}, Returns: MethodReturn{Type: "void"}},
+			{Name: "add", Args: []MethodArg{{Type: "uint64", Desc: "\xfe"}}, Returns: MethodReturn{Type: "void"}},
+			{Args: []MethodArg{{Type: "uint7"}}, Returns: MethodReturn{Type: "void"}},
+			{Name: "ret", Returns: MethodReturn{Type: "account"}},
+			// e4105()void and e24435()void have the same selector
+			{Name: "e4105", Returns: MethodReturn{Type: "void"}},
+			{Name: "e24435", Returns: MethodReturn{Type: "void"}},
+		},
+		Events: []Event{
+			{Name: "e", Args: []EventArg{{Type: "pay"}}},
+			{Name: "e", Args: []EventArg{{Type: "uint64"}}},
+			// e79828() and e85378() have the same selector
+			{Name: "e79828"},
+			{Name: "e85378"},
+		},
+	}
+	err := invalid.Validate()
+	require.Error(t, err)
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	require.True(t, ok)
+	messages := make([]string, len(joined.Unwrap()))
+	for i, err := range joined.Unwrap() {
+		messages[i] = err.Error()
+	}
+	require.Equal(t, []string{
+		"contract has no name",
+		"contract description is not valid UTF-8",
+		"methods at index 0 and 1 have the same signature add(uint64)void",
+		"description of argument 0 of method add is not valid UTF-8",
+		"invalid method at index 2: method has no name",
+		"invalid method at index 3: Error parsing return type of method ret: cannot convert the string \"account\" to an ABI type",
+		"methods at index 4 and 5 have the same selector 5e45115c",
+		"invalid event at index 0: Error parsing argument type at index 0 of event e: cannot convert the string \"pay\" to an ABI type",
+		"events at index 0 and 1 have the same name e",
+		"events at index 2 and 3 have the same selector 16d7ebbd",
+		`network key "AAAA" is not a base64 encoded genesis hash`,
+		`network key "not-base64" is not a base64 encoded genesis hash`,
+		`network "wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8=" has app ID 0`,
+	}, messages)
+}
+
+func TestContractNetworks(t *testing.T) {
+	t.Parallel()
+
+	const mainNet = "wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8="
+	const testNet = "SGO1GKSzyE7IEPItTxCByw9x8FmnrCDexi9/cOUJOiI="
+
+	contract := Contract{Name: "c"}
+	_, ok := contract.AppIDForNetwork(mainNet)
+	require.False(t, ok)
+
+	require.NoError(t, contract.SetAppIDForNetwork(mainNet, 1))
+	require.NoError(t, contract.SetAppIDForNetwork(testNet, 2))
+	require.NoError(t, contract.SetAppIDForNetwork(mainNet, 3))
+	appID, ok := contract.AppIDForNetwork(mainNet)
+	require.True(t, ok)
+	require.Equal(t, uint64(3), appID)
+	require.Equal(t, map[string]ContractNetworkInfo{mainNet: {AppID: 3}, testNet: {AppID: 2}}, contract.Networks)
+	require.NoError(t, contract.Validate())
+
+	require.EqualError(t, contract.SetAppIDForNetwork("AAAA", 1), `network key "AAAA" is not a base64 encoded genesis hash`)
+	require.EqualError(t, contract.SetAppIDForNetwork(testNet, 0), `cannot set app ID 0 for network "`+testNet+`"`)
+
+	contract.RemoveNetwork(testNet)
+	_, ok = contract.AppIDForNetwork(testNet)
+	require.False(t, ok)
+	require.Len(t, contract.Networks, 1)
+
+	contract.Networks[testNet] = ContractNetworkInfo{}
+	_, ok = contract.AppIDForNetwork(testNet)
+	require.False(t, ok)
+}
+
+func TestContractMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	get := Method{Name: "get", Args: []MethodArg{{Type: "uint064", Name: "key"}}, Returns: MethodReturn{Type: "byte[]"}, ReadOnly: true}
+	set := Method{
+		Name:    "set",
+		Returns: MethodReturn{Type: "void"},
+		Events:  []Event{{Name: "Set", Args: []EventArg{{Type: "uint064"}}}, {Name: "Changed", Args: []EventArg{}}},
+	}
+	reset := Method{Name: "reset", Args: []MethodArg{}, Returns: MethodReturn{Type: "void"}}
+
+	contract := Contract{
+		Name: "Store",
+		Networks: map[string]ContractNetworkInfo{
+			"wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8=": {AppID: 2},
+			"SGO1GKSzyE7IEPItTxCByw9x8FmnrCDexi9/cOUJOiI=": {AppID: 1},
+		},
+		Methods: []Method{set, get, reset},
+		Events:  []Event{{Name: "Set", Args: []EventArg{{Type: "uint64"}}}, {Name: "Changed", Args: []EventArg{}}},
+	}
+	encoded, err := json.Marshal(contract)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"Store",`+
+		`"networks":{"SGO1GKSzyE7IEPItTxCByw9x8FmnrCDexi9/cOUJOiI=":{"appID":1},"wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8=":{"appID":2}},`+
+		`"methods":[`+
+		`{"name":"set","args":[],"returns":{"type":"void"},"events":[{"name":"Set","args":[{"type":"uint64"}]},{"name":"Changed","args":[]}]},`+
+		`{"name":"get","args":[{"name":"key","type":"uint64"}],"returns":{"type":"byte[]"},"readonly":true},`+
+		`{"name":"reset","args":[],"returns":{"type":"void"}}],`+
+		`"events":[{"name":"Set","args":[{"type":"uint64"}]},{"name":"Changed","args":[]}]}`, string(encoded))
+	require.Equal(t, "uint064", contract.Methods[1].Args[0].Type)
+
+	// the encoding round-trips to an equivalent contract, keeping the declared order
+	var decoded Contract
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	reencoded, err := json.Marshal(decoded)
+	require.NoError(t, err)
+	require.Equal(t, string(encoded), string(reencoded))
+	require.Equal(t, contract.SelectorTable(), decoded.SelectorTable())
+
+	iface := Interface{Name: "Store", Methods: []Method{set, get}}
+	encoded, err = json.Marshal(iface)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"Store","methods":[`+
+		`{"name":"set","args":[],"returns":{"type":"void"},"events":[{"name":"Set","args":[{"type":"uint64"}]},{"name":"Changed","args":[]}]},`+
+		`{"name":"get","args":[{"name":"key","type":"uint64"}],"returns":{"type":"byte[]"},"readonly":true}]}`, string(encoded))
+}
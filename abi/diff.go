@@ -0,0 +1,276 @@
+package abi
+
+import (
+	"slices"
+	"strings"
+)
+
+// MethodChange describes a method which is in both versions of a contract but differs between them.
+type MethodChange struct {
+	// Old is the method in the old contract.
+	Old Method
+	// New is the method in the new contract.
+	New Method
+	// SignatureChanged reports whether the signature of the method changed. Methods with different
+	// signatures are only paired when their name identifies them unambiguously.
+	SignatureChanged bool
+	// DescriptionChanged reports whether the description of the method, of its return value, or the
+	// name or description of one of its arguments changed.
+	DescriptionChanged bool
+	// ReadOnlyChanged reports whether the ARC-22 read-only flag of the method changed.
+	ReadOnlyChanged bool
+	// EventsChanged reports whether the signatures of the events the method may emit changed.
+	EventsChanged bool
+}
+
+// EventChange describes an event which is in both versions of a contract but differs between them.
+type EventChange struct {
+	// Old is the event in the old contract.
+	Old Event
+	// New is the event in the new contract.
+	New Event
+	// SignatureChanged reports whether the signature of the event changed. Events with different
+	// signatures are only paired when their name identifies them unambiguously.
+	SignatureChanged bool
+	// DescriptionChanged reports whether the description of the event, or the name or description
+	// of one of its arguments changed.
+	DescriptionChanged bool
+}
+
+// ContractDiff describes the differences between two versions of a contract, as returned by
+// `DiffContracts`.
+type ContractDiff struct {
+	// DescriptionChanged reports whether the description of the contract changed.
+	DescriptionChanged bool
+	// AddedMethods are the methods of the new contract which are not in the old one.
+	AddedMethods []Method
+	// RemovedMethods are the methods of the old contract which are not in the new one.
+	RemovedMethods []Method
+	// ChangedMethods are the methods which are in both contracts but differ between them.
+	ChangedMethods []MethodChange
+	// AddedEvents are the events of the new contract which are not in the old one.
+	AddedEvents []Event
+	// RemovedEvents are the events of the old contract which are not in the new one.
+	RemovedEvents []Event
+	// ChangedEvents are the events which are in both contracts but differ between them.
+	ChangedEvents []EventChange
+}
+
+// DiffContracts compares two versions of a contract. Methods and events are paired by signature,
+// and the remaining ones by name when exactly one old and one new method or event have that name,
+// which reports them as changed signatures. Unpaired methods and events are reported as removed or
+// added. Removed items are listed in the order of the old contract, and other items in the order
+// of the new contract.
+func DiffContracts(oldContract, newContract Contract) ContractDiff {
+	diff := ContractDiff{DescriptionChanged: oldContract.Desc != newContract.Desc}
+
+	oldSignatures, oldNames := methodKeys(oldContract.Methods)
+	newSignatures, newNames := methodKeys(newContract.Methods)
+	pairs, removed, added := pairItems(oldSignatures, oldNames, newSignatures, newNames)
+	for _, pair := range pairs {
+		oldMethod, newMethod := oldContract.Methods[pair[0]], newContract.Methods[pair[1]]
+		change := MethodChange{
+			Old:              oldMethod,
+			New:              newMethod,
+			SignatureChanged: oldSignatures[pair[0]] != newSignatures[pair[1]],
+			DescriptionChanged: oldMethod.Desc != newMethod.Desc ||
+				oldMethod.Returns.Desc != newMethod.Returns.Desc ||
+				!slices.Equal(methodArgDocs(oldMethod), methodArgDocs(newMethod)),
+			ReadOnlyChanged: oldMethod.ReadOnly != newMethod.ReadOnly,
+			EventsChanged:   !slices.Equal(eventSignatures(oldMethod.Events), eventSignatures(newMethod.Events)),
+		}
+		if change.SignatureChanged || change.DescriptionChanged || change.ReadOnlyChanged || change.EventsChanged {
+			diff.ChangedMethods = append(diff.ChangedMethods, change)
+		}
+	}
+	for _, i := range removed {
+		diff.RemovedMethods = append(diff.RemovedMethods, oldContract.Methods[i])
+	}
+	for _, i := range added {
+		diff.AddedMethods = append(diff.AddedMethods, newContract.Methods[i])
+	}
+
+	oldSignatures, oldNames = eventKeys(oldContract.Events)
+	newSignatures, newNames = eventKeys(newContract.Events)
+	pairs, removed, added = pairItems(oldSignatures, oldNames, newSignatures, newNames)
+	for _, pair := range pairs {
+		oldEvent, newEvent := oldContract.Events[pair[0]], newContract.Events[pair[1]]
+		change := EventChange{
+			Old:              oldEvent,
+			New:              newEvent,
+			SignatureChanged: oldSignatures[pair[0]] != newSignatures[pair[1]],
+			DescriptionChanged: oldEvent.Desc != newEvent.Desc ||
+				!slices.Equal(eventArgDocs(oldEvent), eventArgDocs(newEvent)),
+		}
+		if change.SignatureChanged || change.DescriptionChanged {
+			diff.ChangedEvents = append(diff.ChangedEvents, change)
+		}
+	}
+	for _, i := range removed {
+		diff.RemovedEvents = append(diff.RemovedEvents, oldContract.Events[i])
+	}
+	for _, i := range added {
+		diff.AddedEvents = append(diff.AddedEvents, newContract.Events[i])
+	}
+
+	return diff
+}
+
+// IsEmpty reports whether the diff has no differences.
+func (d ContractDiff) IsEmpty() bool {
+	return !d.DescriptionChanged &&
+		len(d.AddedMethods) == 0 && len(d.RemovedMethods) == 0 && len(d.ChangedMethods) == 0 &&
+		len(d.AddedEvents) == 0 && len(d.RemovedEvents) == 0 && len(d.ChangedEvents) == 0
+}
+
+// String renders the diff with one line per difference, prefixed with "+" for additions, "-" for
+// removals, and "~" for changes, suitable for review comments and changelogs.
+func (d ContractDiff) String() string {
+	var b strings.Builder
+	if d.DescriptionChanged {
+		b.WriteString("~ contract: description\n")
+	}
+	for _, method := range d.RemovedMethods {
+		b.WriteString("- method " + method.GetSignature() + "\n")
+	}
+	for _, method := range d.AddedMethods {
+		b.WriteString("+ method " + method.GetSignature() + "\n")
+	}
+	for _, change := range d.ChangedMethods {
+		var changes []string
+		if change.SignatureChanged {
+			changes = append(changes, "signature")
+		}
+		if change.DescriptionChanged {
+			changes = append(changes, "description")
+		}
+		if change.ReadOnlyChanged {
+			changes = append(changes, "readonly")
+		}
+		if change.EventsChanged {
+			changes = append(changes, "events")
+		}
+		b.WriteString("~ method " + changeTitle(change.Old.GetSignature(), change.New.GetSignature()) + ": " + strings.Join(changes, ", ") + "\n")
+	}
+	for _, event := range d.RemovedEvents {
+		b.WriteString("- event " + event.GetSignature() + "\n")
+	}
+	for _, event := range d.AddedEvents {
+		b.WriteString("+ event " + event.GetSignature() + "\n")
+	}
+	for _, change := range d.ChangedEvents {
+		var changes []string
+		if change.SignatureChanged {
+			changes = append(changes, "signature")
+		}
+		if change.DescriptionChanged {
+			changes = append(changes, "description")
+		}
+		b.WriteString("~ event " + changeTitle(change.Old.GetSignature(), change.New.GetSignature()) + ": " + strings.Join(changes, ", ") + "\n")
+	}
+	return b.String()
+}
+
+func changeTitle(oldSignature, newSignature string) string {
+	if oldSignature == newSignature {
+		return newSignature
+	}
+	return oldSignature + " -> " + newSignature
+}
+
+// pairItems pairs old and new items, first by equal signatures in order of occurrence, then by
+// names which only one unpaired old item and one unpaired new item have. It returns the pairs of
+// old and new indexes in the order of the new items, and the indexes of unpaired old and new items.
+func pairItems(oldSignatures, oldNames, newSignatures, newNames []string) (pairs [][2]int, removed, added []int) {
+	pairedOld := make([]int, len(newSignatures))
+	oldBySignature := make(map[string][]int)
+	for i, signature := range oldSignatures {
+		oldBySignature[signature] = append(oldBySignature[signature], i)
+	}
+	oldPaired := make([]bool, len(oldSignatures))
+	for j, signature := range newSignatures {
+		pairedOld[j] = -1
+		if candidates := oldBySignature[signature]; len(candidates) > 0 {
+			pairedOld[j] = candidates[0]
+			oldPaired[candidates[0]] = true
+			oldBySignature[signature] = candidates[1:]
+		}
+	}
+
+	oldByName := make(map[string][]int)
+	for i, name := range oldNames {
+		if !oldPaired[i] {
+			oldByName[name] = append(oldByName[name], i)
+		}
+	}
+	newByName := make(map[string][]int)
+	for j, name := range newNames {
+		if pairedOld[j] == -1 {
+			newByName[name] = append(newByName[name], j)
+		}
+	}
+	for name, newIndexes := range newByName {
+		if oldIndexes := oldByName[name]; len(oldIndexes) == 1 && len(newIndexes) == 1 {
+			pairedOld[newIndexes[0]] = oldIndexes[0]
+			oldPaired[oldIndexes[0]] = true
+		}
+	}
+
+	for j, i := range pairedOld {
+		if i == -1 {
+			added = append(added, j)
+		} else {
+			pairs = append(pairs, [2]int{i, j})
+		}
+	}
+	for i, paired := range oldPaired {
+		if !paired {
+			removed = append(removed, i)
+		}
+	}
+	return pairs, removed, added
+}
+
+func methodKeys(methods []Method) (signatures, names []string) {
+	signatures = make([]string, len(methods))
+	names = make([]string, len(methods))
+	for i, method := range methods {
+		signatures[i] = method.GetSignature()
+		names[i] = method.Name
+	}
+	return signatures, names
+}
+
+func eventKeys(events []Event) (signatures, names []string) {
+	signatures = make([]string, len(events))
+	names = make([]string, len(events))
+	for i, event := range events {
+		signatures[i] = event.GetSignature()
+		names[i] = event.Name
+	}
+	return signatures, names
+}
+
+func eventSignatures(events []Event) []string {
+	signatures, _ := eventKeys(events)
+	slices.Sort(signatures)
+	return signatures
+}
+
+// methodArgDocs returns the names and descriptions of the arguments of a method.
+func methodArgDocs(method Method) []string {
+	docs := make([]string, 0, 2*len(method.Args))
+	for _, arg := range method.Args {
+		docs = append(docs, arg.Name, arg.Desc)
+	}
+	return docs
+}
+
+// eventArgDocs returns the names and descriptions of the arguments of an event.
+func eventArgDocs(event Event) []string {
+	docs := make([]string, 0, 2*len(event.Args))
+	for _, arg := range event.Args {
+		docs = append(docs, arg.Name, arg.Desc)
+	}
+	return docs
+}
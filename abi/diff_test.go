@@ -0,0 +1,82 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffContracts(t *testing.T) {
+	t.Parallel()
+
+	add := Method{Name: "add", Args: []MethodArg{{Name: "a", Type: "uint64"}, {Name: "b", Type: "uint64"}}, Returns: MethodReturn{Type: "uint128"}}
+	sub := Method{Name: "sub", Args: []MethodArg{{Type: "uint64"}, {Type: "uint64"}}, Returns: MethodReturn{Type: "uint64"}}
+	get := Method{Name: "get", Returns: MethodReturn{Type: "uint64"}}
+	overloaded1 := Method{Name: "set", Args: []MethodArg{{Type: "uint64"}}, Returns: MethodReturn{Type: "void"}}
+	overloaded2 := Method{Name: "set", Args: []MethodArg{{Type: "string"}}, Returns: MethodReturn{Type: "void"}}
+	reset := Event{Name: "Reset", Args: []EventArg{}}
+	transfer := Event{Name: "Transfer", Args: []EventArg{{Type: "uint64"}}}
+
+	oldContract := Contract{
+		Name:    "Calculator",
+		Desc:    "Version 1",
+		Methods: []Method{add, sub, get, overloaded1, overloaded2},
+		Events:  []Event{reset, transfer},
+	}
+
+	require.True(t, DiffContracts(oldContract, oldContract).IsEmpty())
+	require.Empty(t, DiffContracts(oldContract, oldContract).String())
+
+	// add is documented, get returns a different type and becomes read-only, sub is removed, and
+	// both set overloads change, so they cannot be paired by name
+	newAdd := add
+	newAdd.Args = []MethodArg{{Name: "a", Type: "uint64", Desc: "First"}, {Name: "b", Type: "uint64"}}
+	newGet := Method{Name: "get", Returns: MethodReturn{Type: "uint128"}, ReadOnly: true}
+	newSet1 := Method{Name: "set", Args: []MethodArg{{Type: "uint32"}}, Returns: MethodReturn{Type: "void"}}
+	newSet2 := Method{Name: "set", Args: []MethodArg{{Type: "byte[]"}}, Returns: MethodReturn{Type: "void"}, Events: []Event{reset}}
+	mul := Method{Name: "mul", Args: []MethodArg{{Type: "uint64"}, {Type: "uint64"}}, Returns: MethodReturn{Type: "uint128"}}
+	newTransfer := Event{Name: "Transfer", Desc: "Tokens moved", Args: []EventArg{{Type: "uint64"}, {Type: "address"}}}
+	minted := Event{Name: "Minted", Args: []EventArg{{Type: "uint64"}}}
+	newContract := Contract{
+		Name:    "Calculator",
+		Desc:    "Version 2",
+		Methods: []Method{mul, newGet, newAdd, newSet1, newSet2},
+		Events:  []Event{newTransfer, minted},
+	}
+
+	diff := DiffContracts(oldContract, newContract)
+	require.Equal(t, ContractDiff{
+		DescriptionChanged: true,
+		AddedMethods:       []Method{mul, newSet1, newSet2},
+		RemovedMethods:     []Method{sub, overloaded1, overloaded2},
+		ChangedMethods: []MethodChange{
+			{Old: get, New: newGet, SignatureChanged: true, ReadOnlyChanged: true},
+			{Old: add, New: newAdd, DescriptionChanged: true},
+		},
+		AddedEvents:   []Event{minted},
+		RemovedEvents: []Event{reset},
+		ChangedEvents: []EventChange{
+			{Old: transfer, New: newTransfer, SignatureChanged: true, DescriptionChanged: true},
+		},
+	}, diff)
+	require.False(t, diff.IsEmpty())
+	require.Equal(t, "~ contract: description\n"+
+		"- method sub(uint64,uint64)uint64\n"+
+		"- method set(uint64)void\n"+
+		"- method set(string)void\n"+
+		"+ method mul(uint64,uint64)uint128\n"+
+		"+ method set(uint32)void\n"+
+		"+ method set(byte[])void\n"+
+		"~ method get()uint64 -> get()uint128: signature, readonly\n"+
+		"~ method add(uint64,uint64)uint128: description\n"+
+		"- event Reset()\n"+
+		"+ event Minted(uint64)\n"+
+		"~ event Transfer(uint64) -> Transfer(uint64,address): signature, description\n", diff.String())
+
+	// events emitted by a method are compared by signature
+	withEvents := overloaded1
+	withEvents.Events = []Event{transfer}
+	diff = DiffContracts(Contract{Methods: []Method{overloaded1}}, Contract{Methods: []Method{withEvents}})
+	require.Equal(t, []MethodChange{{Old: overloaded1, New: withEvents, EventsChanged: true}}, diff.ChangedMethods)
+	require.Equal(t, "~ method set(uint64)void: events\n", diff.String())
+}
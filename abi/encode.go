@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"reflect"
 	"strings"
+	"unicode"
 
 	"github.com/algorand/avm-abi/address"
 )
@@ -71,6 +72,13 @@ func (t Type) typeCastToTuple(tupLen ...int) (Type, error) {
 // and arrays of interfaces or specific types that are compatible with the
 // contents of the ABI type's contained types. For example, the `address` type
 // accepts Go types []interface{}, [32]interface{}, []byte, and [32]byte.
+//
+// Tuple types additionally accept Go structs, whose exported fields are used as
+// the tuple elements in declaration order. A field tagged with `abi:"-"` is
+// skipped. For named tuple types, struct fields are instead matched to tuple
+// elements by name, using the field's `abi` tag if present or its Go name
+// otherwise, and a map[string]interface{} keyed by element name is accepted
+// too.
 func (t Type) Encode(value interface{}) ([]byte, error) {
 	switch t.kind {
 	case Uint, Ufixed:
@@ -132,7 +140,11 @@ func (t Type) Encode(value interface{}) ([]byte, error) {
 		encoded = append(lengthEncode, encoded...)
 		return encoded, nil
 	case Tuple:
-		return encodeTuple(value, t.childTypes)
+		values, err := t.inferTupleValues(value)
+		if err != nil {
+			return nil, err
+		}
+		return encodeTuple(values, t.childTypes)
 	default:
 		return nil, fmt.Errorf("cannot infer type for encoding")
 	}
@@ -198,6 +210,41 @@ func inferToSlice(value interface{}) ([]interface{}, error) {
 	return values, nil
 }
 
+// derefStruct returns the struct held by reflectVal, looking through a single non-nil pointer.
+func derefStruct(reflectVal reflect.Value) (reflect.Value, bool) {
+	if reflectVal.Kind() == reflect.Ptr && !reflectVal.IsNil() {
+		reflectVal = reflectVal.Elem()
+	}
+	return reflectVal, reflectVal.Kind() == reflect.Struct
+}
+
+// structFieldValues returns the names and values of the exported fields of a struct, in
+// declaration order. A field's name is taken from its `abi` struct tag, or is the Go field name if
+// the tag is absent. Fields tagged with `abi:"-"` are skipped.
+func structFieldValues(structVal reflect.Value) ([]string, []interface{}) {
+	structType := structVal.Type()
+	names := make([]string, 0, structType.NumField())
+	values := make([]interface{}, 0, structType.NumField())
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("abi"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		names = append(names, name)
+		values = append(values, structVal.Field(i).Interface())
+	}
+	return names, values
+}
+
 // encodeTuple encodes slice-of-interface of golang values to bytes, following ABI encoding rules
 func encodeTuple(value interface{}, childT []Type) ([]byte, error) {
 	if len(childT) >= abiEncodingLengthLimit {
@@ -406,6 +453,41 @@ func (t Type) Decode(encoded []byte) (interface{}, error) {
 	}
 }
 
+// zeroValue returns the value that decoding the zero value of ABI type t produces, i.e. zero for
+// numeric types, false for bool, the empty string, an all-zero address, an empty dynamic array, and
+// static arrays and tuples of zero values.
+func (t Type) zeroValue() interface{} {
+	switch t.kind {
+	case Uint, Ufixed:
+		value, _ := decodeUint(make([]byte, t.bitSize/8), t.bitSize)
+		return value
+	case Bool:
+		return false
+	case Byte:
+		return byte(0)
+	case Address:
+		return make([]byte, address.BytesSize)
+	case String:
+		return ""
+	case ArrayDynamic:
+		return []interface{}{}
+	case ArrayStatic:
+		values := make([]interface{}, t.staticLength)
+		for i := range values {
+			values[i] = t.childTypes[0].zeroValue()
+		}
+		return values
+	case Tuple:
+		values := make([]interface{}, len(t.childTypes))
+		for i, childT := range t.childTypes {
+			values[i] = childT.zeroValue()
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
 // decodeTuple decodes byte slice with ABI type slice, outputting a slice of golang interface values
 // following ABI encoding rules
 func decodeTuple(encoded []byte, childT []Type) ([]interface{}, error) {
@@ -428,6 +510,9 @@ func decodeTuple(encoded []byte, childT []Type) ([]interface{}, error) {
 			// search after bool
 			after := findBoolLR(childT, i, 1)
 			if before%8 == 0 {
+				if iterIndex >= len(encoded) {
+					return nil, fmt.Errorf("input byte not enough to decode")
+				}
 				if after > 7 {
 					after = 7
 				}
@@ -451,6 +536,9 @@ func decodeTuple(encoded []byte, childT []Type) ([]interface{}, error) {
 			if err != nil {
 				return nil, err
 			}
+			if iterIndex+currLen > len(encoded) {
+				return nil, fmt.Errorf("input byte not enough to decode")
+			}
 			valuePartition = append(valuePartition, encoded[iterIndex:iterIndex+currLen])
 			iterIndex += currLen
 		}
@@ -491,13 +579,49 @@ func decodeTuple(encoded []byte, childT []Type) ([]interface{}, error) {
 	return values, nil
 }
 
+// isSignatureDelimiter reports whether r separates the tokens of a method signature.
+func isSignatureDelimiter(r rune) bool {
+	switch r {
+	case '(', ')', ',', '[', ']':
+		return true
+	default:
+		return false
+	}
+}
+
+// stripSignatureWhitespace removes insignificant whitespace from a method signature, which is
+// whitespace at either end of the signature or next to a delimiter. Whitespace within a name or
+// type, as in "uint 64", is kept so that it is reported as an error.
+func stripSignatureWhitespace(methodSig string) string {
+	runes := []rune(strings.TrimSpace(methodSig))
+	var builder strings.Builder
+	for i := 0; i < len(runes); i++ {
+		if !unicode.IsSpace(runes[i]) {
+			builder.WriteRune(runes[i])
+			continue
+		}
+		end := i
+		for end < len(runes) && unicode.IsSpace(runes[end]) {
+			end++
+		}
+		if !isSignatureDelimiter(runes[i-1]) && !isSignatureDelimiter(runes[end]) {
+			builder.WriteString(string(runes[i:end]))
+		}
+		i = end - 1
+	}
+	return builder.String()
+}
+
 // ParseMethodSignature parses a method of format `method(argType1,argType2,...)retType`
 // into `method` {`argType1`,`argType2`,...} and `retType`
 //
+// Insignificant whitespace, such as in `add(uint64, uint64) uint64`, is ignored.
+//
 // NOTE: This function **DOES NOT** verify that the argument or return type strings represent valid
 // ABI types. Consider using `VerifyMethodSignature` prior to calling this function if you wish to
 // verify those types.
 func ParseMethodSignature(methodSig string) (name string, argTypes []string, returnType string, err error) {
+	methodSig = stripSignatureWhitespace(methodSig)
 	argsStart := strings.Index(methodSig, "(")
 	if argsStart == -1 {
 		err = fmt.Errorf(`No parenthesis in method signature: "%s"`, methodSig)
@@ -538,30 +662,127 @@ func ParseMethodSignature(methodSig string) (name string, argTypes []string, ret
 	return
 }
 
-// VerifyMethodSignature checks if a method signature and its referenced types can be parsed properly
-func VerifyMethodSignature(methodSig string) error {
-	_, argTypes, retType, err := ParseMethodSignature(methodSig)
+// NormalizeMethodSignature returns the canonical form of a method signature, which is the form used
+// to compute its selector. Insignificant whitespace is removed and ABI types are rendered in their
+// canonical form as returned by `Type.String`. An error is returned if the signature is not valid.
+func NormalizeMethodSignature(methodSig string) (string, error) {
+	method, err := MethodFromSignature(methodSig)
+	if err != nil {
+		return "", err
+	}
+	return method.GetSignature(), nil
+}
+
+// ArgTypeError is an invalid argument type of a method signature.
+type ArgTypeError struct {
+	// Index is the position of the argument.
+	Index int
+	// Type is the invalid argument type string.
+	Type string
+	// Err is the error parsing the type.
+	Err error
+}
+
+// Error describes the invalid argument type.
+func (e ArgTypeError) Error() string {
+	return fmt.Sprintf("Error parsing argument type at index %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error parsing the type.
+func (e ArgTypeError) Unwrap() error {
+	return e.Err
+}
+
+// MethodSignatureError lists every invalid type of a method signature.
+type MethodSignatureError struct {
+	// ArgErrors are the invalid argument types, in argument order.
+	ArgErrors []ArgTypeError
+	// ReturnErr is the error parsing the return type, or nil if it is valid.
+	ReturnErr error
+}
+
+// Error describes every invalid type, separated by semicolons.
+func (e *MethodSignatureError) Error() string {
+	messages := make([]string, 0, len(e.ArgErrors)+1)
+	for _, argErr := range e.ArgErrors {
+		messages = append(messages, argErr.Error())
+	}
+	if e.ReturnErr != nil {
+		messages = append(messages, fmt.Sprintf("Error parsing return type: %v", e.ReturnErr))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns every error in e.
+func (e *MethodSignatureError) Unwrap() []error {
+	errs := make([]error, 0, len(e.ArgErrors)+1)
+	for _, argErr := range e.ArgErrors {
+		errs = append(errs, argErr)
+	}
+	if e.ReturnErr != nil {
+		errs = append(errs, e.ReturnErr)
+	}
+	return errs
+}
+
+// MethodSignatureInfo is a method signature parsed by `AnalyzeMethodSignature`.
+type MethodSignatureInfo struct {
+	// Name is the name of the method.
+	Name string
+	// ArgTypes are the argument type strings, in order.
+	ArgTypes []string
+	// ArgKinds are the kinds of the arguments, in order.
+	ArgKinds []ArgKind
+	// ReturnType is the return type string.
+	ReturnType string
+}
+
+// AnalyzeMethodSignature parses a method signature, classifies its arguments, and checks all of its
+// argument and return types. If the signature can be parsed but some of its types are invalid, the
+// parsed signature is returned together with a *MethodSignatureError listing every invalid type, so
+// that all problems can be reported at once.
+func AnalyzeMethodSignature(methodSig string) (MethodSignatureInfo, error) {
+	name, argTypes, retType, err := ParseMethodSignature(methodSig)
 	if err != nil {
-		return err
+		return MethodSignatureInfo{}, err
 	}
 
+	info := MethodSignatureInfo{
+		Name:       name,
+		ArgTypes:   argTypes,
+		ArgKinds:   make([]ArgKind, len(argTypes)),
+		ReturnType: retType,
+	}
+	var sigErr MethodSignatureError
 	for i, argType := range argTypes {
-		if IsReferenceType(argType) || IsTransactionType(argType) {
+		info.ArgKinds[i] = ArgKindOf(argType)
+		if info.ArgKinds[i] != ValueArg {
 			continue
 		}
 
 		_, err = TypeOf(argType)
 		if err != nil {
-			return fmt.Errorf("Error parsing argument type at index %d: %w", i, err)
+			sigErr.ArgErrors = append(sigErr.ArgErrors, ArgTypeError{Index: i, Type: argType, Err: err})
 		}
 	}
 
 	if retType != VoidReturnType {
 		_, err = TypeOf(retType)
 		if err != nil {
-			return fmt.Errorf("Error parsing return type: %w", err)
+			sigErr.ReturnErr = err
 		}
 	}
 
-	return nil
+	if len(sigErr.ArgErrors) > 0 || sigErr.ReturnErr != nil {
+		return info, &sigErr
+	}
+	return info, nil
+}
+
+// VerifyMethodSignature checks if a method signature and its referenced types can be parsed properly.
+// If the signature can be parsed, invalid types are reported by a *MethodSignatureError listing all
+// of them.
+func VerifyMethodSignature(methodSig string) error {
+	_, err := AnalyzeMethodSignature(methodSig)
+	return err
 }
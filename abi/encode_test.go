@@ -3,6 +3,7 @@ package abi
 import (
 	"crypto/rand"
 	"encoding/binary"
+	"errors"
 	"math/big"
 	"testing"
 
@@ -849,6 +850,20 @@ func TestDecodeInvalid(t *testing.T) {
 		_, err = tupleT.Decode(encodedInput)
 		require.Error(t, err, "decode corrupted empty tuple should return error")
 	})
+
+	// decoding test for *truncated* static tuple
+	// expected 8 bytes for uint64 in (uint8,uint64,bool)
+	// encoded bytes end partway through the uint64
+	// should return error
+	t.Run("truncated static tuple decoding", func(t *testing.T) {
+		t.Parallel()
+		tupleT, err := TypeOf("(uint8,uint64,bool)")
+		require.NoError(t, err, "make tuple type failure")
+		_, err = tupleT.Decode([]byte{0x01, 0x00, 0x00})
+		require.Error(t, err, "decode truncated static tuple should return error")
+		_, err = tupleT.Decode([]byte{0x01, 0, 0, 0, 0, 0, 0, 0, 0x02})
+		require.Error(t, err, "decode static tuple missing bool byte should return error")
+	})
 }
 
 type testUnit struct {
@@ -1083,6 +1098,18 @@ func TestParseMethodSignature(t *testing.T) {
 			argTypes:   []string{"(uint8,uint128)", "account", "(string,(bool,bool))"},
 			returnType: "(bool,bool,bool)",
 		},
+		{
+			signature:  " add(uint64, uint64) uint64\n",
+			name:       "add",
+			argTypes:   []string{"uint64", "uint64"},
+			returnType: "uint64",
+		},
+		{
+			signature:  "spaced ( ( uint8 , byte [ 4 ] ) ,\tpay )\t( bool [] , string )",
+			name:       "spaced",
+			argTypes:   []string{"(uint8,byte[4])", "pay"},
+			returnType: "(bool[],string)",
+		},
 	}
 
 	for _, test := range tests {
@@ -1147,6 +1174,75 @@ func TestVerifyMethodSignature(t *testing.T) {
 	}
 }
 
+func TestAnalyzeMethodSignature(t *testing.T) {
+	t.Parallel()
+
+	info, err := AnalyzeMethodSignature("deposit(pay,uint64,account,(bool,string))void")
+	require.NoError(t, err)
+	require.Equal(t, MethodSignatureInfo{
+		Name:       "deposit",
+		ArgTypes:   []string{"pay", "uint64", "account", "(bool,string)"},
+		ArgKinds:   []ArgKind{TransactionArg, ValueArg, ReferenceArg, ValueArg},
+		ReturnType: "void",
+	}, info)
+
+	info, err = AnalyzeMethodSignature("bad(uint7,pay,string[x],asset)uint9")
+	require.Equal(t, MethodSignatureInfo{
+		Name:       "bad",
+		ArgTypes:   []string{"uint7", "pay", "string[x]", "asset"},
+		ArgKinds:   []ArgKind{ValueArg, TransactionArg, ValueArg, ReferenceArg},
+		ReturnType: "uint9",
+	}, info)
+
+	var sigErr *MethodSignatureError
+	require.ErrorAs(t, err, &sigErr)
+	require.Len(t, sigErr.ArgErrors, 2)
+	require.Equal(t, 0, sigErr.ArgErrors[0].Index)
+	require.Equal(t, "uint7", sigErr.ArgErrors[0].Type)
+	require.Equal(t, 2, sigErr.ArgErrors[1].Index)
+	require.Equal(t, "string[x]", sigErr.ArgErrors[1].Type)
+	require.Error(t, sigErr.ReturnErr)
+	require.Len(t, sigErr.Unwrap(), 3)
+	require.Equal(t, sigErr.ArgErrors[0].Error()+"; "+sigErr.ArgErrors[1].Error()+"; Error parsing return type: "+sigErr.ReturnErr.Error(), err.Error())
+	require.ErrorIs(t, err, sigErr.ReturnErr)
+
+	// VerifyMethodSignature reports the same errors
+	require.Equal(t, err, VerifyMethodSignature("bad(uint7,pay,string[x],asset)uint9"))
+
+	_, err = AnalyzeMethodSignature("missing")
+	require.ErrorContains(t, err, "No parenthesis in method signature")
+	require.False(t, errors.As(err, &sigErr))
+
+	require.Equal(t, "value", ValueArg.String())
+	require.Equal(t, "reference", ReferenceArg.String())
+	require.Equal(t, "transaction", TransactionArg.String())
+	require.Equal(t, "ArgKind(7)", ArgKind(7).String())
+}
+
+func TestNormalizeMethodSignature(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		signature string
+		expected  string
+	}{
+		{signature: "add(uint64,uint64)uint64", expected: "add(uint64,uint64)uint64"},
+		{signature: "add(uint64, uint64) uint64", expected: "add(uint64,uint64)uint64"},
+		{signature: "  deposit( pay , account )void ", expected: "deposit(pay,account)void"},
+		{signature: "nested((uint8, (bool, string[ ])), byte[32])(uint8, bool)", expected: "nested((uint8,(bool,string[])),byte[32])(uint8,bool)"},
+	}
+
+	for _, test := range tests {
+		normalized, err := NormalizeMethodSignature(test.signature)
+		require.NoError(t, err)
+		require.Equal(t, test.expected, normalized)
+	}
+
+	for _, signature := range []string{"add(uint 64)void", "add(uint64)", "a dd(uint64)void", "add(uint64,)void"} {
+		_, err := NormalizeMethodSignature(signature)
+		require.Error(t, err, signature)
+	}
+}
+
 func TestInferToSlice(t *testing.T) {
 	t.Parallel()
 	var emptySlice []int
@@ -0,0 +1,203 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// EventArg is an argument of an ARC-28 event.
+type EventArg struct {
+	// Name is the name of the argument, which is optional.
+	Name string `json:"name,omitempty"`
+	// Type is the ABI type of the argument.
+	Type string `json:"type"`
+	// Desc is an optional description of the argument.
+	Desc string `json:"desc,omitempty"`
+	// StructType is an optional named tuple type of the argument, as in `MethodArg`.
+	StructType *Type `json:"-"`
+}
+
+// Parse parses the argument's type. If StructType is set, it is the returned type.
+func (a EventArg) Parse() (Type, error) {
+	return resolveStructType(a.Type, a.StructType)
+}
+
+// Event is an ARC-28 event. An application emits an event by logging the event selector followed
+// by the ABI encoding of a tuple of the event arguments.
+type Event struct {
+	// Name is the name of the event.
+	Name string `json:"name"`
+	// Desc is an optional description of the event.
+	Desc string `json:"desc,omitempty"`
+	// Args are the arguments of the event, in order.
+	Args []EventArg `json:"args"`
+}
+
+// EventFromSignature parses an event signature, such as "Transfer(address,address,uint64)", into
+// an Event without names or descriptions. Argument types are checked and stored in their canonical
+// form.
+func EventFromSignature(eventSig string) (Event, error) {
+	name, argTypes, returnType, err := ParseMethodSignature(eventSig)
+	if err != nil {
+		return Event{}, err
+	}
+	if returnType != "" {
+		return Event{}, fmt.Errorf(`Event signature has a return type: "%s"`, eventSig)
+	}
+	event := Event{Name: name, Args: make([]EventArg, len(argTypes))}
+	for i, argType := range argTypes {
+		event.Args[i] = EventArg{Type: canonicalArgType(argType)}
+	}
+	if err := event.verifyTypes(); err != nil {
+		return Event{}, err
+	}
+	return event, nil
+}
+
+// GetSignature returns the canonical signature of the event, of format
+// `name(argType1,argType2,...)`. ABI types are rendered in their canonical form as returned by
+// `Type.String`.
+func (e Event) GetSignature() string {
+	argTypes := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		argTypes[i] = canonicalArgType(arg.Type)
+	}
+	return e.Name + "(" + strings.Join(argTypes, ",") + ")"
+}
+
+// GetSelector returns the selector of the event, which is the first 4 bytes of the SHA-512/256
+// hash of the event signature returned by `GetSignature`. Logs of the event start with the
+// selector.
+func (e Event) GetSelector() Selector {
+	return computeSelector(e.GetSignature())
+}
+
+// MarshalJSON encodes the event as an ARC-28 event description, with argument types in their
+// canonical form.
+func (e Event) MarshalJSON() ([]byte, error) {
+	// eventJSON has the same fields as Event, without its MarshalJSON method
+	type eventJSON Event
+	canonical := eventJSON(e)
+	canonical.Args = make([]EventArg, len(e.Args))
+	for i, arg := range e.Args {
+		canonical.Args[i] = arg
+		canonical.Args[i].Type = canonicalArgType(arg.Type)
+	}
+	return json.Marshal(canonical)
+}
+
+// argsType returns the tuple type of the event arguments.
+func (e Event) argsType() (Type, error) {
+	argTypes := make([]Type, len(e.Args))
+	for i, arg := range e.Args {
+		argType, err := arg.Parse()
+		if err != nil {
+			return Type{}, fmt.Errorf("Error parsing argument type at index %d of event %s: %w", i, e.Name, err)
+		}
+		argTypes[i] = argType
+	}
+	return MakeTupleType(argTypes)
+}
+
+// verifyTypes checks that the event has a name and that its argument types are valid.
+func (e Event) verifyTypes() error {
+	if e.Name == "" {
+		return fmt.Errorf("event has no name")
+	}
+	_, err := e.argsType()
+	return err
+}
+
+// DecodedEvent is an event decoded from a log.
+type DecodedEvent struct {
+	// Event is the event which was emitted.
+	Event Event
+	// Args are the decoded argument values, in the form returned by `Decode`.
+	Args []interface{}
+	// LogIndex is the index of the log the event was decoded from, as set by `DecodeEvents`.
+	LogIndex int
+}
+
+// DecodeEventLog decodes log as an emission of one of events. The event is identified by the
+// selector at the start of the log, and the rest of the log is decoded as a tuple of the event
+// arguments. An error is returned if no event matches the selector, or if the arguments cannot be
+// decoded.
+func DecodeEventLog(events []Event, log []byte) (DecodedEvent, error) {
+	if len(log) < MethodSelectorLength {
+		return DecodedEvent{}, fmt.Errorf("log of length %d is too short to contain an event selector", len(log))
+	}
+	for _, event := range events {
+		selector := event.GetSelector()
+		if bytes.Equal(selector[:], log[:MethodSelectorLength]) {
+			return decodeEvent(event, log)
+		}
+	}
+	return DecodedEvent{}, fmt.Errorf("no event matches selector %x", log[:MethodSelectorLength])
+}
+
+// decodeEvent decodes the arguments of event from log, which starts with the event selector.
+func decodeEvent(event Event, log []byte) (DecodedEvent, error) {
+	argsType, err := event.argsType()
+	if err != nil {
+		return DecodedEvent{}, err
+	}
+	decoded, err := argsType.Decode(log[MethodSelectorLength:])
+	if err != nil {
+		return DecodedEvent{}, fmt.Errorf("cannot decode arguments of event %s: %w", event.Name, err)
+	}
+	return DecodedEvent{Event: event, Args: decoded.([]interface{})}, nil
+}
+
+// LogError is an error decoding one log of a batch, reported by `DecodeEvents`.
+type LogError struct {
+	// LogIndex is the index of the log which could not be decoded.
+	LogIndex int
+	// Err is the decoding error.
+	Err error
+}
+
+// Error describes the log decoding error.
+func (e LogError) Error() string {
+	return fmt.Sprintf("log %d: %v", e.LogIndex, e.Err)
+}
+
+func (e LogError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeEvents decodes every log which starts with the selector of one of events, such as all the
+// logs of a transaction or group, and returns the decoded events in log order with their LogIndex
+// set. Logs which match no event, such as method return values, are skipped.
+//
+// A log which matches an event but cannot be decoded does not stop the batch: the other logs are
+// still decoded, and the returned error joins a `LogError` for each failed log.
+func DecodeEvents(events []Event, logs [][]byte) ([]DecodedEvent, error) {
+	// if several events share a selector, the first one is used, as in DecodeEventLog
+	bySelector := make(map[Selector]int, len(events))
+	for i := len(events) - 1; i >= 0; i-- {
+		bySelector[events[i].GetSelector()] = i
+	}
+
+	var decoded []DecodedEvent
+	var errs []error
+	for i, log := range logs {
+		if len(log) < MethodSelectorLength {
+			continue
+		}
+		index, ok := bySelector[Selector(log[:MethodSelectorLength])]
+		if !ok {
+			continue
+		}
+		event, err := decodeEvent(events[index], log)
+		if err != nil {
+			errs = append(errs, LogError{LogIndex: i, Err: err})
+			continue
+		}
+		event.LogIndex = i
+		decoded = append(decoded, event)
+	}
+	return decoded, errors.Join(errs...)
+}
@@ -0,0 +1,155 @@
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSelector(t *testing.T) {
+	t.Parallel()
+
+	swapped := Event{
+		Name: "Swapped",
+		Args: []EventArg{{Name: "a", Type: "uint64"}, {Name: "b", Type: "uint64"}},
+	}
+	require.Equal(t, "Swapped(uint64,uint64)", swapped.GetSignature())
+	selector := swapped.GetSelector()
+	require.Equal(t, "1ccbd925", hex.EncodeToString(selector[:]))
+
+	empty := Event{Name: "Reset"}
+	require.Equal(t, "Reset()", empty.GetSignature())
+}
+
+func TestDecodeEventLog(t *testing.T) {
+	t.Parallel()
+
+	events := []Event{
+		{Name: "Swapped", Args: []EventArg{{Type: "uint64"}, {Type: "uint64"}}},
+		{Name: "Named", Args: []EventArg{{Name: "name", Type: "string"}, {Name: "owner", Type: "address"}}},
+	}
+
+	swappedLog, err := hex.DecodeString("1ccbd925" + "0000000000000001" + "0000000000000002")
+	require.NoError(t, err)
+	decoded, err := DecodeEventLog(events, swappedLog)
+	require.NoError(t, err)
+	require.Equal(t, DecodedEvent{Event: events[0], Args: []interface{}{uint64(1), uint64(2)}}, decoded)
+
+	namedArgs, err := mustTypeOf(t, "(string,address)").Encode([]interface{}{"hi", make([]byte, 32)})
+	require.NoError(t, err)
+	namedLog := append([]byte{0x21, 0x71, 0x7b, 0x58}, namedArgs...)
+	decoded, err = DecodeEventLog(events, namedLog)
+	require.NoError(t, err)
+	require.Equal(t, "Named", decoded.Event.Name)
+	require.Equal(t, []interface{}{"hi", make([]byte, 32)}, decoded.Args)
+
+	_, err = DecodeEventLog(events, []byte{0x1c, 0xcb})
+	require.EqualError(t, err, "log of length 2 is too short to contain an event selector")
+
+	_, err = DecodeEventLog(events, []byte{1, 2, 3, 4})
+	require.EqualError(t, err, "no event matches selector 01020304")
+
+	_, err = DecodeEventLog(events, swappedLog[:10])
+	require.ErrorContains(t, err, "cannot decode arguments of event Swapped")
+
+	invalid := []Event{{Name: "Bad", Args: []EventArg{{Type: "pay"}}}}
+	badSelector := invalid[0].GetSelector()
+	_, err = DecodeEventLog(invalid, badSelector[:])
+	require.ErrorContains(t, err, "Error parsing argument type at index 0 of event Bad")
+}
+
+func TestContractEvents(t *testing.T) {
+	t.Parallel()
+
+	var contract Contract
+	err := json.Unmarshal([]byte(`{
+		"name": "Exchange",
+		"methods": [
+			{
+				"name": "swap",
+				"args": [],
+				"returns": {"type": "void"},
+				"events": [{"name": "Swapped", "args": [{"type": "uint64"}, {"type": "uint64"}]}]
+			}
+		],
+		"events": [{"name": "Swapped", "args": [{"type": "uint64"}, {"type": "uint64"}]}]
+	}`), &contract)
+	require.NoError(t, err)
+	swapped := Event{Name: "Swapped", Args: []EventArg{{Type: "uint64"}, {Type: "uint64"}}}
+	require.Equal(t, []Event{swapped}, contract.Events)
+	require.Equal(t, []Event{swapped}, contract.Methods[0].Events)
+
+	err = json.Unmarshal([]byte(`{"name": "c", "methods": [], "events": [{"name": "e", "args": [{"type": "account"}]}]}`), &contract)
+	require.ErrorContains(t, err, "invalid event at index 0 of contract c")
+
+	err = json.Unmarshal([]byte(`{"name": "c", "methods": [{"name": "m", "args": [], "returns": {"type": "void"}, "events": [{"args": []}]}]}`), &contract)
+	require.ErrorContains(t, err, "invalid event at index 0 of method m: event has no name")
+}
+
+func TestDecodeEvents(t *testing.T) {
+	t.Parallel()
+
+	events := []Event{
+		{Name: "Swapped", Args: []EventArg{{Type: "uint64"}, {Type: "uint64"}}},
+		{Name: "Reset"},
+	}
+	reset := events[1].GetSelector()
+
+	swappedLog, err := hex.DecodeString("1ccbd925" + "0000000000000001" + "0000000000000002")
+	require.NoError(t, err)
+	returnLog := append(MethodReturnPrefix(), 0x01)
+	logs := [][]byte{
+		[]byte("hi"),
+		swappedLog,
+		swappedLog[:10],
+		returnLog,
+		reset[:],
+		append(reset[:], 0x00),
+	}
+
+	decoded, err := DecodeEvents(events, logs)
+	require.Equal(t, []DecodedEvent{
+		{Event: events[0], Args: []interface{}{uint64(1), uint64(2)}, LogIndex: 1},
+		{Event: events[1], Args: []interface{}{}, LogIndex: 4},
+	}, decoded)
+
+	var logErr LogError
+	require.ErrorAs(t, err, &logErr)
+	require.Equal(t, 2, logErr.LogIndex)
+	joined, ok := err.(interface{ Unwrap() []error })
+	require.True(t, ok)
+	require.Len(t, joined.Unwrap(), 2)
+	require.True(t, errors.As(joined.Unwrap()[1], &logErr))
+	require.Equal(t, 5, logErr.LogIndex)
+	require.ErrorContains(t, err, "log 2: cannot decode arguments of event Swapped")
+
+	decoded, err = DecodeEvents(events, [][]byte{returnLog})
+	require.NoError(t, err)
+	require.Empty(t, decoded)
+}
+
+func TestEventFromSignature(t *testing.T) {
+	t.Parallel()
+
+	event, err := EventFromSignature("Transfer(address, address, uint064)")
+	require.NoError(t, err)
+	require.Equal(t, Event{
+		Name: "Transfer",
+		Args: []EventArg{{Type: "address"}, {Type: "address"}, {Type: "uint64"}},
+	}, event)
+	require.Equal(t, "Transfer(address,address,uint64)", event.GetSignature())
+
+	event, err = EventFromSignature("Reset()")
+	require.NoError(t, err)
+	require.Equal(t, Event{Name: "Reset", Args: []EventArg{}}, event)
+
+	_, err = EventFromSignature("Transfer(address)void")
+	require.EqualError(t, err, `Event signature has a return type: "Transfer(address)void"`)
+	_, err = EventFromSignature("Transfer(pay)")
+	require.ErrorContains(t, err, "Error parsing argument type at index 0 of event Transfer")
+	_, err = EventFromSignature("Transfer")
+	require.ErrorContains(t, err, "No parenthesis in method signature")
+}
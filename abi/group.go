@@ -0,0 +1,70 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxGroupSize is the maximum number of transactions in an atomic transaction group.
+const MaxGroupSize = 16
+
+// TxnPlacementError is a transaction argument of a method call which does not have a matching
+// transaction at its position in the group, reported by `Method.ValidateGroup`.
+type TxnPlacementError struct {
+	// ArgIndex is the position of the transaction argument in the method arguments.
+	ArgIndex int
+	// ArgType is the transaction type of the argument, such as "pay" or "txn".
+	ArgType string
+	// GroupIndex is the position in the group the transaction should be at. It is negative if too
+	// few transactions precede the application call.
+	GroupIndex int
+	// Actual is the type of the transaction at GroupIndex, or empty if GroupIndex is negative.
+	Actual string
+}
+
+// Error describes where the transaction argument should be.
+func (e TxnPlacementError) Error() string {
+	if e.GroupIndex < 0 {
+		return fmt.Sprintf("transaction argument %d (%s) should be at group index %d, before the start of the group", e.ArgIndex, e.ArgType, e.GroupIndex)
+	}
+	return fmt.Sprintf("transaction argument %d (%s) should be at group index %d, but the transaction there is %s", e.ArgIndex, e.ArgType, e.GroupIndex, e.Actual)
+}
+
+// ValidateGroup checks that a group of transactions, given by the transaction type of each, such
+// as "pay" or "appl", can make a call to the method with the application call at appCallIndex.
+// The transaction arguments of the method must immediately precede the application call, in the
+// order of the arguments, and have the type of their argument unless it is "txn". The returned
+// error joins a *TxnPlacementError for each misplaced transaction argument, and any problem with
+// the group itself.
+func (m Method) ValidateGroup(group []string, appCallIndex int) error {
+	var errs []error
+	if len(group) > MaxGroupSize {
+		errs = append(errs, fmt.Errorf("group has %d transactions, more than the maximum of %d", len(group), MaxGroupSize))
+	}
+	for i, txnType := range group {
+		if !IsTransactionType(txnType) || txnType == AnyTransactionType {
+			errs = append(errs, fmt.Errorf(`unknown transaction type "%s" at group index %d`, txnType, i))
+		}
+	}
+	if appCallIndex < 0 || appCallIndex >= len(group) {
+		errs = append(errs, fmt.Errorf("application call index %d is outside of the group of %d transactions", appCallIndex, len(group)))
+		return errors.Join(errs...)
+	}
+	if group[appCallIndex] != ApplicationCallTransactionType {
+		errs = append(errs, fmt.Errorf("transaction at application call index %d is %s, not %s", appCallIndex, group[appCallIndex], ApplicationCallTransactionType))
+	}
+
+	groupIndex := appCallIndex - m.TxnArgCount()
+	for i, arg := range m.Args {
+		if arg.Kind() != TransactionArg {
+			continue
+		}
+		if groupIndex < 0 {
+			errs = append(errs, &TxnPlacementError{ArgIndex: i, ArgType: arg.Type, GroupIndex: groupIndex})
+		} else if actual := group[groupIndex]; arg.Type != AnyTransactionType && actual != arg.Type {
+			errs = append(errs, &TxnPlacementError{ArgIndex: i, ArgType: arg.Type, GroupIndex: groupIndex, Actual: actual})
+		}
+		groupIndex++
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,53 @@
+package abi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodValidateGroup(t *testing.T) {
+	t.Parallel()
+
+	method := Method{
+		Name:    "swap",
+		Args:    []MethodArg{{Type: "pay"}, {Type: "uint64"}, {Type: "axfer"}, {Type: "asset"}, {Type: "txn"}},
+		Returns: MethodReturn{Type: "void"},
+	}
+
+	require.NoError(t, method.ValidateGroup([]string{"pay", "axfer", "appl", "appl"}, 3))
+	require.NoError(t, method.ValidateGroup([]string{"keyreg", "pay", "axfer", "acfg", "appl", "pay"}, 4))
+	require.NoError(t, Method{Name: "noop", Returns: MethodReturn{Type: "void"}}.ValidateGroup([]string{"appl"}, 0))
+
+	err := method.ValidateGroup([]string{"axfer", "pay", "afrz", "appl"}, 3)
+	require.EqualError(t, err, "transaction argument 0 (pay) should be at group index 0, but the transaction there is axfer\n"+
+		"transaction argument 2 (axfer) should be at group index 1, but the transaction there is pay")
+	var placementErr *TxnPlacementError
+	require.True(t, errors.As(err, &placementErr))
+	require.Equal(t, TxnPlacementError{ArgIndex: 0, ArgType: "pay", GroupIndex: 0, Actual: "axfer"}, *placementErr)
+
+	err = method.ValidateGroup([]string{"axfer", "pay", "appl"}, 2)
+	require.EqualError(t, err, "transaction argument 0 (pay) should be at group index -1, before the start of the group")
+
+	errorCases := []struct {
+		group        []string
+		appCallIndex int
+		err          string
+	}{
+		{group: []string{"pay", "axfer", "pay", "appl"}, appCallIndex: 4, err: "application call index 4 is outside of the group of 4 transactions"},
+		{group: []string{"pay", "axfer", "pay", "appl"}, appCallIndex: -1, err: "application call index -1 is outside of the group of 4 transactions"},
+		{group: []string{"pay", "axfer", "pay", "pay"}, appCallIndex: 3, err: "transaction at application call index 3 is pay, not appl"},
+		{group: []string{"pay", "axfer", "txn", "appl"}, appCallIndex: 3, err: `unknown transaction type "txn" at group index 2`},
+		{group: []string{"pay", "axfer", "foo", "appl"}, appCallIndex: 3, err: `unknown transaction type "foo" at group index 2`},
+		{
+			group:        []string{"pay", "pay", "pay", "pay", "pay", "pay", "pay", "pay", "pay", "pay", "pay", "pay", "pay", "pay", "axfer", "pay", "appl"},
+			appCallIndex: 16,
+			err:          "group has 17 transactions, more than the maximum of 16",
+		},
+	}
+	for _, errorCase := range errorCases {
+		err := method.ValidateGroup(errorCase.group, errorCase.appCallIndex)
+		require.EqualError(t, err, errorCase.err, "group %v", errorCase.group)
+	}
+}
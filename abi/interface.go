@@ -0,0 +1,129 @@
+package abi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Interface is an ARC-4 interface description, a named set of methods which contracts can
+// implement, such as the methods of a standard like ARC-20.
+type Interface struct {
+	// Name is the name of the interface.
+	Name string `json:"name"`
+	// Desc is an optional description of the interface.
+	Desc string `json:"desc,omitempty"`
+	// Methods are the methods of the interface.
+	Methods []Method `json:"methods"`
+}
+
+// UnmarshalJSON parses an ARC-4 interface description, and verifies that the interface has a name
+// and that the argument and return types of its methods are valid. Fields which are not part of
+// the ARC-4 interface description are ignored.
+func (i *Interface) UnmarshalJSON(data []byte) error {
+	// interfaceJSON has the same fields as Interface, without its UnmarshalJSON method
+	type interfaceJSON Interface
+	var parsed interfaceJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+	if parsed.Name == "" {
+		return fmt.Errorf("interface has no name")
+	}
+	for index, method := range parsed.Methods {
+		if err := method.verifyTypes(); err != nil {
+			return fmt.Errorf("invalid method at index %d of interface %s: %w", index, parsed.Name, err)
+		}
+	}
+	*i = Interface(parsed)
+	return nil
+}
+
+// MarshalJSON encodes the interface as an ARC-4 interface description. Fields are in a fixed order
+// and types are rendered in their canonical form, so equivalent interfaces are encoded
+// identically. Methods keep the order they were declared in.
+func (i Interface) MarshalJSON() ([]byte, error) {
+	// interfaceJSON has the same fields as Interface, without its MarshalJSON method
+	type interfaceJSON Interface
+	return json.Marshal(interfaceJSON(i))
+}
+
+// GetSelector returns the selector of the interface, which is the XOR of the selectors of all its
+// methods, as defined by ARC-73.
+func (i Interface) GetSelector() Selector {
+	var selector Selector
+	for _, method := range i.Methods {
+		methodSelector := method.GetSelector()
+		for j := range selector {
+			selector[j] ^= methodSelector[j]
+		}
+	}
+	return selector
+}
+
+// Implements checks that the contract has every method of the interface, comparing methods by
+// signature. The returned error lists the signatures of all missing methods.
+func (c Contract) Implements(iface Interface) error {
+	signatures := make(map[string]bool, len(c.Methods))
+	for _, method := range c.Methods {
+		signatures[method.GetSignature()] = true
+	}
+	var missing []string
+	for _, method := range iface.Methods {
+		signature := method.GetSignature()
+		if !signatures[signature] {
+			missing = append(missing, signature)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("contract %s does not implement interface %s: missing methods %s", c.Name, iface.Name, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// ComposeContract creates a contract named name from the methods of the given interfaces followed
+// by the extra methods, in order. Methods with the same signature are merged, keeping the first
+// one, so interfaces may share methods. It is an error for two methods to differ only in their
+// return type, or for methods with different signatures to have the same selector.
+func ComposeContract(name string, interfaces []Interface, methods ...Method) (Contract, error) {
+	contract := Contract{Name: name, selectors: &selectorIndex{}}
+	signatures := make(map[string]bool)
+	returnTypes := make(map[string]string)
+	selectors := make(map[Selector]string)
+
+	add := func(method Method, source string) error {
+		signature := method.GetSignature()
+		if signatures[signature] {
+			return nil
+		}
+		returnType := canonicalArgType(method.Returns.Type)
+		prefix := signature[:len(signature)-len(returnType)]
+		if existing, ok := returnTypes[prefix]; ok {
+			return fmt.Errorf("method %s from %s conflicts with method %s%s, which has a different return type", signature, source, prefix, existing)
+		}
+		selector := method.GetSelector()
+		if existing, ok := selectors[selector]; ok {
+			return fmt.Errorf("method %s from %s has the same selector %s as method %s", signature, source, selector, existing)
+		}
+		signatures[signature] = true
+		returnTypes[prefix] = returnType
+		selectors[selector] = signature
+		contract.Methods = append(contract.Methods, method)
+		return nil
+	}
+
+	for _, iface := range interfaces {
+		for _, method := range iface.Methods {
+			if err := add(method, "interface "+iface.Name); err != nil {
+				return Contract{}, err
+			}
+		}
+	}
+	for _, method := range methods {
+		if err := add(method, "contract "+name); err != nil {
+			return Contract{}, err
+		}
+	}
+
+	return contract, nil
+}
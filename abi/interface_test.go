@@ -0,0 +1,134 @@
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterfaceUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	const input = `{
+		"name": "Calculator",
+		"methods": [
+			{"name": "add", "args": [{"type": "uint64"}, {"type": "uint64"}], "returns": {"type": "uint128"}},
+			{"name": "optIn", "args": [{"type": "pay"}, {"type": "account"}], "returns": {"type": "void"}}
+		]
+	}`
+
+	var iface Interface
+	require.NoError(t, json.Unmarshal([]byte(input), &iface))
+	require.Equal(t, Interface{
+		Name: "Calculator",
+		Methods: []Method{
+			{Name: "add", Args: []MethodArg{{Type: "uint64"}, {Type: "uint64"}}, Returns: MethodReturn{Type: "uint128"}},
+			{Name: "optIn", Args: []MethodArg{{Type: "pay"}, {Type: "account"}}, Returns: MethodReturn{Type: "void"}},
+		},
+	}, iface)
+
+	errorCases := []struct {
+		input string
+		err   string
+	}{
+		{input: `{"methods": []}`, err: "interface has no name"},
+		{input: `{"name": "i", "methods": [{"name": "m", "args": [{"type": "string[x]"}], "returns": {"type": "void"}}]}`, err: "invalid method at index 0 of interface i"},
+	}
+	for _, errorCase := range errorCases {
+		var iface Interface
+		err := json.Unmarshal([]byte(errorCase.input), &iface)
+		require.ErrorContains(t, err, errorCase.err)
+	}
+}
+
+func TestInterfaceGetSelector(t *testing.T) {
+	t.Parallel()
+
+	// ARC-73 gives the selector of its own interface, which has a single method
+	arc73 := Interface{
+		Name: "ARC73",
+		Methods: []Method{
+			{Name: "supportsInterface", Args: []MethodArg{{Type: "byte[4]"}}, Returns: MethodReturn{Type: "bool"}},
+		},
+	}
+	selector := arc73.GetSelector()
+	require.Equal(t, "4e22a3ba", hex.EncodeToString(selector[:]))
+
+	calculator := Interface{
+		Name: "Calculator",
+		Methods: []Method{
+			{Name: "add", Args: []MethodArg{{Type: "uint64"}, {Type: "uint64"}}, Returns: MethodReturn{Type: "uint128"}},
+			{Name: "optIn", Args: []MethodArg{{Type: "pay"}, {Type: "account"}}, Returns: MethodReturn{Type: "void"}},
+		},
+	}
+	selector = calculator.GetSelector()
+	require.Equal(t, "95321bc7", hex.EncodeToString(selector[:]))
+
+	require.Equal(t, Selector{}, Interface{Name: "Empty"}.GetSelector())
+}
+
+func TestContractImplements(t *testing.T) {
+	t.Parallel()
+
+	iface := Interface{
+		Name: "Counter",
+		Methods: []Method{
+			{Name: "increment", Args: []MethodArg{{Type: "uint64"}}, Returns: MethodReturn{Type: "void"}},
+			{Name: "get", Returns: MethodReturn{Type: "uint64"}},
+			{Name: "reset", Returns: MethodReturn{Type: "void"}},
+		},
+	}
+
+	contract := Contract{Name: "Full", Methods: append([]Method{{Name: "extra", Returns: MethodReturn{Type: "void"}}}, iface.Methods...)}
+	require.NoError(t, contract.Implements(iface))
+
+	partial := Contract{
+		Name: "Partial",
+		Methods: []Method{
+			{Name: "increment", Args: []MethodArg{{Type: "uint64", Name: "amount"}}, Returns: MethodReturn{Type: "void"}},
+			{Name: "get", Returns: MethodReturn{Type: "uint32"}},
+		},
+	}
+	require.EqualError(t, partial.Implements(iface), "contract Partial does not implement interface Counter: missing methods get()uint64, reset()void")
+
+	require.NoError(t, partial.Implements(Interface{Name: "Empty"}))
+}
+
+func TestComposeContract(t *testing.T) {
+	t.Parallel()
+
+	counter := Interface{
+		Name: "Counter",
+		Methods: []Method{
+			{Name: "increment", Args: []MethodArg{{Type: "uint64"}}, Returns: MethodReturn{Type: "void"}},
+			{Name: "get", Returns: MethodReturn{Type: "uint64"}, ReadOnly: true},
+		},
+	}
+	getter := Interface{
+		Name: "Getter",
+		Methods: []Method{
+			{Name: "get", Desc: "shared with Counter", Returns: MethodReturn{Type: "uint64"}},
+			{Name: "get", Args: []MethodArg{{Type: "string"}}, Returns: MethodReturn{Type: "byte[]"}},
+		},
+	}
+	extra := Method{Name: "reset", Returns: MethodReturn{Type: "void"}}
+
+	contract, err := ComposeContract("Composed", []Interface{counter, getter}, extra)
+	require.NoError(t, err)
+	require.Equal(t, "Composed", contract.Name)
+	require.Equal(t, []Method{counter.Methods[0], counter.Methods[1], getter.Methods[1], extra}, contract.Methods)
+	require.NoError(t, contract.Implements(counter))
+	require.NoError(t, contract.Implements(getter))
+
+	method, err := contract.MethodBySelector(extra.GetSelector())
+	require.NoError(t, err)
+	require.Equal(t, extra, method)
+
+	_, err = ComposeContract("Conflict", []Interface{counter}, Method{Name: "get", Returns: MethodReturn{Type: "uint32"}})
+	require.EqualError(t, err, "method get()uint32 from contract Conflict conflicts with method get()uint64, which has a different return type")
+
+	_, err = ComposeContract("Conflict", []Interface{counter, {Name: "Other", Methods: []Method{{Name: "increment", Args: []MethodArg{{Type: "uint64"}}, Returns: MethodReturn{Type: "(uint64,bool)"}}}}})
+	require.EqualError(t, err, "method increment(uint64)(uint64,bool) from interface Other conflicts with method increment(uint64)void, which has a different return type")
+}
@@ -3,10 +3,11 @@ package abi
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
-
-	"github.com/algorand/avm-abi/address"
+	"reflect"
 )
 
 func castBigIntToNearestPrimitive(num *big.Int, bitSize uint16) (interface{}, error) {
@@ -30,214 +31,245 @@ func castBigIntToNearestPrimitive(num *big.Int, bitSize uint16) (interface{}, er
 	}
 }
 
+// JSONOptions controls how ABI values are converted to and from JSON. The zero value produces the
+// same behavior as `MarshalToJSON` and `UnmarshalFromJSON`.
+type JSONOptions struct {
+	// UfixedAsString renders ufixed values as quoted decimal strings, e.g. "12.340" instead of
+	// 12.340, so JSON consumers which parse numbers as float64 do not silently lose precision. When
+	// unmarshaling, quoted ufixed values are accepted in addition to bare numbers.
+	UfixedAsString bool
+
+	// UintAsString renders uint values as quoted decimal strings. When unmarshaling, quoted uint
+	// values are accepted in addition to bare numbers.
+	UintAsString bool
+
+	// JSSafeIntegers renders uint values greater than 2^53 - 1 (JavaScript's
+	// Number.MAX_SAFE_INTEGER) as quoted decimal strings, while smaller values remain JSON numbers.
+	// This keeps the output exact for consumers using JSON.parse. When unmarshaling, quoted uint
+	// values are accepted in addition to bare numbers.
+	JSSafeIntegers bool
+
+	// UintFromString accepts quoted strings of decimal digits, such as "123456789012345678901234",
+	// as uint values when unmarshaling, without changing how uint values are marshaled. Large values
+	// often arrive quoted from other SDKs and databases.
+	UintFromString bool
+
+	// StringFromByteArray accepts a JSON array of byte values, such as [65,66,67], as the value of
+	// an ABI string when unmarshaling. Without this option such input is rejected, since it usually
+	// indicates that a byte[] type was intended.
+	StringFromByteArray bool
+
+	// ZeroFillMissing fills tuple elements which are missing from the end of a JSON array, and
+	// named tuple fields which are missing from a JSON object, with the zero value of their type
+	// when unmarshaling. Without this option missing elements are an error.
+	ZeroFillMissing bool
+
+	// ByteArraysAsNumbers renders static and dynamic byte arrays as JSON arrays of numbers, e.g.
+	// [1,2,3], instead of base64 strings, for consumers which cannot handle the base64 form. Both
+	// forms are accepted when unmarshaling regardless of this option. Byte32AsAddress takes
+	// precedence for byte[32] values.
+	ByteArraysAsNumbers bool
+
+	// Byte32AsAddress renders byte[32] values as checksummed base32 Algorand address strings, the
+	// same way as the ABI `address` type, since contracts often declare addresses as byte[32]. When
+	// unmarshaling, byte[32] values are accepted as address strings in addition to the usual forms.
+	Byte32AsAddress bool
+
+	// MaxArrayLength, if positive, is the maximum number of elements of any array when
+	// unmarshaling, including byte arrays given as base64 strings. Longer arrays fail with an error
+	// wrapping ErrArrayTooLong, as soon as the element past the limit, or a base64 string too long
+	// to be within the limit, is read.
+	MaxArrayLength int
+
+	// MaxStringLength, if positive, is the maximum length in bytes of any string value when
+	// unmarshaling. Longer strings fail with an error wrapping ErrStringTooLong, as soon as enough
+	// of the string is read to exceed the limit.
+	MaxStringLength int
+
+	// MaxDepth, if positive, is the maximum nesting depth of JSON arrays and objects when
+	// unmarshaling, where the outermost array or object has depth 1. Deeper values fail with an
+	// error wrapping ErrTooDeep. Arrays and objects given in place of other values are rejected as
+	// soon as they are opened.
+	MaxDepth int
+
+	// MaxInputSize, if positive, is the maximum number of bytes of JSON input read when
+	// unmarshaling, including whitespace. Larger inputs fail with an error wrapping
+	// ErrInputTooLarge as soon as the limit is crossed, without reading the rest of the input.
+	MaxInputSize int64
+
+	// Indent, if not empty, is used to indent each nesting level of the marshaled JSON, as in
+	// json.MarshalIndent. Otherwise the output is compact.
+	Indent string
+}
+
+// ErrArrayTooLong is returned, wrapped, when unmarshaling an array longer than
+// JSONOptions.MaxArrayLength.
+var ErrArrayTooLong = errors.New("array exceeds maximum length")
+
+// ErrStringTooLong is returned, wrapped, when unmarshaling a string longer than
+// JSONOptions.MaxStringLength.
+var ErrStringTooLong = errors.New("string exceeds maximum length")
+
+// ErrInputTooLarge is returned, wrapped, when unmarshaling more than JSONOptions.MaxInputSize bytes
+// of JSON.
+var ErrInputTooLarge = errors.New("input exceeds maximum size")
+
+// ErrTooDeep is returned, wrapped, when unmarshaling a value nested deeper than
+// JSONOptions.MaxDepth.
+var ErrTooDeep = errors.New("value exceeds maximum nesting depth")
+
+// maxJSSafeInteger is the largest integer which can be exactly represented by a JavaScript number.
+var maxJSSafeInteger = big.NewInt(1<<53 - 1)
+
+// quoteJSON wraps already encoded JSON text in quotes, producing a JSON string.
+func quoteJSON(encoded []byte) []byte {
+	quoted := make([]byte, 0, len(encoded)+2)
+	quoted = append(quoted, '"')
+	quoted = append(quoted, encoded...)
+	return append(quoted, '"')
+}
+
+// unquoteJSON removes the quotes around a JSON string holding a number, if allowed is true and the
+// input is quoted. Otherwise the input is returned unchanged.
+func unquoteJSON(jsonEncoded []byte, allowed bool) []byte {
+	if allowed && len(jsonEncoded) >= 2 && jsonEncoded[0] == '"' && jsonEncoded[len(jsonEncoded)-1] == '"' {
+		return jsonEncoded[1 : len(jsonEncoded)-1]
+	}
+	return jsonEncoded
+}
+
 // MarshalToJSON convert golang value to JSON format from ABI type
 func (t Type) MarshalToJSON(value interface{}) ([]byte, error) {
-	switch t.kind {
-	case Uint:
-		bytesUint, err := encodeInt(value, t.bitSize)
-		if err != nil {
-			return nil, err
-		}
-		return new(big.Int).SetBytes(bytesUint).MarshalJSON()
-	case Ufixed:
-		denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(t.precision)), nil)
-		encodedUint, err := encodeInt(value, t.bitSize)
-		if err != nil {
-			return nil, err
-		}
-		return []byte(new(big.Rat).SetFrac(new(big.Int).SetBytes(encodedUint), denom).FloatString(int(t.precision))), nil
-	case Bool:
-		boolValue, ok := value.(bool)
-		if !ok {
-			return nil, fmt.Errorf("cannot infer to bool for marshal to JSON")
-		}
-		return json.Marshal(boolValue)
-	case Byte:
-		byteValue, ok := value.(byte)
-		if !ok {
-			return nil, fmt.Errorf("cannot infer to byte for marshal to JSON")
-		}
-		return json.Marshal(byteValue)
-	case Address:
-		var addressBytes [address.BytesSize]byte
-		switch valueCasted := value.(type) {
-		case []byte:
-			if len(valueCasted) != address.BytesSize {
-				return nil, fmt.Errorf("address byte slice length not equal to 32 byte")
-			}
-			copy(addressBytes[:], valueCasted[:])
-		case [address.BytesSize]byte:
-			copy(addressBytes[:], valueCasted[:])
-		default:
-			return nil, fmt.Errorf("cannot infer to byte slice/array for marshal to JSON")
-		}
-		return json.Marshal(address.ToString(addressBytes))
-	case ArrayStatic, ArrayDynamic:
-		values, err := inferToSlice(value)
-		if err != nil {
-			return nil, err
-		}
-		if t.kind == ArrayStatic && int(t.staticLength) != len(values) {
-			return nil, fmt.Errorf("length of slice %d != type specific length %d", len(values), t.staticLength)
-		}
-		if t.childTypes[0].kind == Byte {
-			byteArr := make([]byte, len(values))
-			for i := 0; i < len(values); i++ {
-				tempByte, ok := values[i].(byte)
-				if !ok {
-					return nil, fmt.Errorf("cannot infer byte element from slice")
-				}
-				byteArr[i] = tempByte
-			}
-			return json.Marshal(byteArr)
-		}
-		rawMsgSlice := make([]json.RawMessage, len(values))
-		for i := 0; i < len(values); i++ {
-			rawMsgSlice[i], err = t.childTypes[0].MarshalToJSON(values[i])
-			if err != nil {
-				return nil, err
-			}
-		}
-		return json.Marshal(rawMsgSlice)
-	case String:
-		stringVal, ok := value.(string)
-		if !ok {
-			return nil, fmt.Errorf("cannot infer to string for marshal to JSON")
-		}
-		return json.Marshal(stringVal)
-	case Tuple:
-		values, err := inferToSlice(value)
-		if err != nil {
-			return nil, err
-		}
-		if len(values) != int(t.staticLength) {
-			return nil, fmt.Errorf("tuple element number != value slice length")
-		}
-		rawMsgSlice := make([]json.RawMessage, len(values))
-		for i := 0; i < len(values); i++ {
-			rawMsgSlice[i], err = t.childTypes[i].MarshalToJSON(values[i])
-			if err != nil {
-				return nil, err
-			}
-		}
-		return json.Marshal(rawMsgSlice)
-	default:
-		return nil, fmt.Errorf("cannot infer ABI type for marshalling value to JSON")
+	return t.MarshalToJSONWithOptions(value, JSONOptions{})
+}
+
+// MarshalToJSONWithOptions convert golang value to JSON format from ABI type, following the
+// behavior selected by opts
+//
+// The output is deterministic: array and tuple elements are emitted in order, and the fields of
+// named tuples are emitted in the order they are declared by the type, so equal values always
+// produce identical JSON.
+func (t Type) MarshalToJSONWithOptions(value interface{}, opts JSONOptions) ([]byte, error) {
+	return t.NewJSONCodec(opts).Marshal(value)
+}
+
+// CanonicalJSON converts golang value to the canonical JSON form of ABI type t. Each ABI value has
+// exactly one canonical form, which is suitable for signing and content addressing:
+//
+//   - the output is compact, with no insignificant whitespace
+//   - uint values are JSON numbers in decimal notation
+//   - ufixed<N>x<M> values are JSON numbers with exactly M fractional digits
+//   - string values are JSON strings escaped as by encoding/json, which includes escaping <, >, and &
+//   - address values are checksummed base32 strings
+//   - static and dynamic byte arrays are standard base64 strings
+//   - named tuples are objects with fields in declaration order, and other tuples and arrays are
+//     JSON arrays
+//
+// The value is normalized by encoding and decoding it first, so any value accepted by `Encode` may be
+// given. `UnmarshalFromJSON` of the result is guaranteed to equal `Decode(Encode(value))`; if that
+// cannot hold, for example because a string is not valid UTF-8, an error is returned.
+func (t Type) CanonicalJSON(value interface{}) ([]byte, error) {
+	encoded, err := t.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := t.Decode(encoded)
+	if err != nil {
+		return nil, err
+	}
+	codec := t.NewJSONCodec(JSONOptions{})
+	canonical, err := codec.Marshal(normalized)
+	if err != nil {
+		return nil, err
+	}
+	roundTrip, err := codec.Unmarshal(canonical)
+	if err != nil {
+		return nil, fmt.Errorf("cannot produce canonical JSON for %s value: %w", t.String(), err)
 	}
+	roundTripEncoded, err := t.Encode(roundTrip)
+	if err != nil || !bytes.Equal(encoded, roundTripEncoded) {
+		return nil, fmt.Errorf("cannot produce canonical JSON for %s value: value does not round-trip through JSON", t.String())
+	}
+	return canonical, nil
 }
 
-// UnmarshalFromJSON convert bytes to golang value following ABI type and encoding rules
+// UnmarshalFromJSON convert bytes to golang value following ABI type and encoding rules. As with
+// json.Unmarshal into a slice, a JSON null is read as an empty array or tuple.
 func (t Type) UnmarshalFromJSON(jsonEncoded []byte) (interface{}, error) {
-	switch t.kind {
-	case Uint:
-		num := new(big.Int)
-		if err := num.UnmarshalJSON(jsonEncoded); err != nil {
-			return nil, fmt.Errorf("cannot cast JSON encoded (%s) to uint: %w", string(jsonEncoded), err)
-		}
-		return castBigIntToNearestPrimitive(num, t.bitSize)
-	case Ufixed:
-		floatTemp := new(big.Rat)
-		if err := floatTemp.UnmarshalText(jsonEncoded); err != nil {
-			return nil, fmt.Errorf("cannot cast JSON encoded (%s) to ufixed: %w", string(jsonEncoded), err)
-		}
-		denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(t.precision)), nil)
-		denomRat := new(big.Rat).SetInt(denom)
-		numeratorRat := new(big.Rat).Mul(denomRat, floatTemp)
-		if !numeratorRat.IsInt() {
-			return nil, fmt.Errorf("cannot cast JSON encoded (%s) to ufixed: precision out of range", string(jsonEncoded))
-		}
-		return castBigIntToNearestPrimitive(numeratorRat.Num(), t.bitSize)
-	case Bool:
-		var elem bool
-		if err := json.Unmarshal(jsonEncoded, &elem); err != nil {
-			return nil, fmt.Errorf("cannot cast JSON encoded (%s) to bool: %w", string(jsonEncoded), err)
-		}
-		return elem, nil
-	case Byte:
-		var elem byte
-		if err := json.Unmarshal(jsonEncoded, &elem); err != nil {
-			return nil, fmt.Errorf("cannot cast JSON encoded to byte: %w", err)
-		}
-		return elem, nil
-	case Address:
-		var addrStr string
-		if err := json.Unmarshal(jsonEncoded, &addrStr); err != nil {
-			return nil, fmt.Errorf("cannot cast JSON encoded (%s) to address string: %w", string(jsonEncoded), err)
-		}
+	return t.UnmarshalFromJSONWithOptions(jsonEncoded, JSONOptions{})
+}
 
-		addrBytes, err := address.FromString(addrStr)
-		if err != nil {
-			return nil, err
-		}
+// UnmarshalFromJSONWithOptions convert bytes to golang value following ABI type and encoding rules,
+// and the behavior selected by opts
+func (t Type) UnmarshalFromJSONWithOptions(jsonEncoded []byte, opts JSONOptions) (interface{}, error) {
+	return t.NewJSONCodec(opts).Unmarshal(jsonEncoded)
+}
 
-		return addrBytes[:], nil
-	case ArrayStatic, ArrayDynamic:
-		if t.childTypes[0].kind == Byte && bytes.HasPrefix(jsonEncoded, []byte{'"'}) {
-			var byteArr []byte
-			err := json.Unmarshal(jsonEncoded, &byteArr)
-			if err != nil {
-				return nil, fmt.Errorf("cannot cast JSON encoded (%s) to bytes: %w", string(jsonEncoded), err)
-			}
-			if t.kind == ArrayStatic && len(byteArr) != int(t.staticLength) {
-				return nil, fmt.Errorf("length of slice %d != type specific length %d", len(byteArr), t.staticLength)
-			}
-			outInterface := make([]interface{}, len(byteArr))
-			for i := 0; i < len(byteArr); i++ {
-				outInterface[i] = byteArr[i]
-			}
-			return outInterface, nil
-		}
-		var elems []json.RawMessage
-		if err := json.Unmarshal(jsonEncoded, &elems); err != nil {
-			return nil, fmt.Errorf("cannot cast JSON encoded (%s) to array: %w", string(jsonEncoded), err)
+// UnmarshalFromJSONReader reads a single JSON encoded value from r and converts it to golang value
+// following ABI type and encoding rules, and the behavior selected by opts.
+//
+// Arrays and tuples are consumed one element at a time with a json.Decoder, so large arrays are not
+// buffered in their JSON form before being converted.
+func (t Type) UnmarshalFromJSONReader(r io.Reader, opts JSONOptions) (interface{}, error) {
+	return t.NewJSONCodec(opts).UnmarshalReader(r)
+}
+
+// inferTupleValues infers the element values of a tuple. In addition to the values accepted by
+// inferToSlice, a struct (or non-nil pointer to a struct) is accepted, whose fields are the tuple
+// elements as returned by structFieldValues. Named tuples match struct fields to their elements by
+// name instead of position, and also accept a map[string]interface{} keyed by field name.
+func (t Type) inferTupleValues(value interface{}) ([]interface{}, error) {
+	structVal, isStruct := derefStruct(reflect.ValueOf(value))
+	if len(t.fieldNames) == 0 {
+		if isStruct {
+			_, values := structFieldValues(structVal)
+			return values, nil
 		}
-		if t.kind == ArrayStatic && len(elems) != int(t.staticLength) {
-			return nil, fmt.Errorf("JSON array element number != ABI array elem number")
+		return inferToSlice(value)
+	}
+	valueMap, ok := value.(map[string]interface{})
+	if !ok {
+		if !isStruct {
+			return inferToSlice(value)
 		}
-		values := make([]interface{}, len(elems))
-		for i := 0; i < len(elems); i++ {
-			tempValue, err := t.childTypes[0].UnmarshalFromJSON(elems[i])
-			if err != nil {
-				return nil, err
-			}
-			values[i] = tempValue
+		names, values := structFieldValues(structVal)
+		valueMap = make(map[string]interface{}, len(names))
+		for i, name := range names {
+			valueMap[name] = values[i]
 		}
-		return values, nil
-	case String:
-		stringEncoded := string(jsonEncoded)
-		if bytes.HasPrefix(jsonEncoded, []byte{'"'}) {
-			var stringVar string
-			if err := json.Unmarshal(jsonEncoded, &stringVar); err != nil {
-				return nil, fmt.Errorf("cannot cast JSON encoded (%s) to string: %w", stringEncoded, err)
-			}
-			return stringVar, nil
-		} else if bytes.HasPrefix(jsonEncoded, []byte{'['}) {
-			var elems []byte
-			if err := json.Unmarshal(jsonEncoded, &elems); err != nil {
-				return nil, fmt.Errorf("cannot cast JSON encoded (%s) to string: %w", stringEncoded, err)
-			}
-			return string(elems), nil
-		} else {
-			return nil, fmt.Errorf("cannot cast JSON encoded (%s) to string", stringEncoded)
+	}
+	for name := range valueMap {
+		if t.fieldIndex(name) == -1 {
+			return nil, fmt.Errorf(`unknown field "%s" for named tuple %s`, name, t.String())
 		}
-	case Tuple:
-		var elems []json.RawMessage
-		if err := json.Unmarshal(jsonEncoded, &elems); err != nil {
-			return nil, fmt.Errorf("cannot cast JSON encoded (%s) to array for tuple: %w", string(jsonEncoded), err)
+	}
+	values := make([]interface{}, len(t.fieldNames))
+	for i, name := range t.fieldNames {
+		fieldValue, ok := valueMap[name]
+		if !ok {
+			return nil, fmt.Errorf(`missing field "%s" for named tuple %s`, name, t.String())
 		}
-		if len(elems) != int(t.staticLength) {
-			return nil, fmt.Errorf("JSON array element number != ABI tuple elem number")
+		values[i] = fieldValue
+	}
+	return values, nil
+}
+
+// marshalJSONObject renders a JSON object whose keys appear in the order given by names.
+func marshalJSONObject(names []string, rawValues []json.RawMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			buf.WriteByte(',')
 		}
-		values := make([]interface{}, len(elems))
-		for i := 0; i < len(elems); i++ {
-			tempValue, err := t.childTypes[i].UnmarshalFromJSON(elems[i])
-			if err != nil {
-				return nil, err
-			}
-			values[i] = tempValue
+		encodedName, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
 		}
-		return values, nil
-	default:
-		return nil, fmt.Errorf("cannot cast JSON encoded %s to ABI encoding stuff", string(jsonEncoded))
+		buf.Write(encodedName)
+		buf.WriteByte(':')
+		buf.Write(rawValues[i])
 	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
 }
@@ -0,0 +1,694 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"strconv"
+
+	"github.com/algorand/avm-abi/address"
+)
+
+// JSONCodec converts values of a single ABI type to and from JSON, following fixed JSONOptions.
+//
+// The decisions which only depend on the type, such as the child codecs of arrays and tuples, the
+// denominator of ufixed values, and the field names of named tuples, are made once when the codec
+// is created. Services which convert many values of the same type should create one codec and
+// reuse it. A JSONCodec is safe for concurrent use.
+type JSONCodec struct {
+	abiType Type
+	opts    JSONOptions
+
+	// codecs of the element type for arrays, or of each element for tuples
+	children []*JSONCodec
+	// only for `ufixed`, the denominator 10^<M>
+	denom *big.Int
+	// only for arrays, whether the element type is `byte`
+	byteArray bool
+	// only for `byte[32]`, whether values are represented as address strings
+	addressArray bool
+	// only for named tuples, the position of each field name
+	fieldIndexes map[string]int
+	// the JSON nesting depth of arrays and objects read by this codec, starting at 1
+	depth int
+}
+
+// NewJSONCodec creates a JSONCodec for values of ABI type t, following the behavior selected by
+// opts.
+func (t Type) NewJSONCodec(opts JSONOptions) *JSONCodec {
+	return t.newJSONCodec(opts, 1)
+}
+
+func (t Type) newJSONCodec(opts JSONOptions, depth int) *JSONCodec {
+	codec := &JSONCodec{abiType: t, opts: opts, depth: depth}
+	switch t.kind {
+	case Ufixed:
+		codec.denom = new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(t.precision)), nil)
+	case ArrayStatic, ArrayDynamic:
+		codec.children = []*JSONCodec{t.childTypes[0].newJSONCodec(opts, depth+1)}
+		codec.byteArray = t.childTypes[0].kind == Byte
+		codec.addressArray = opts.Byte32AsAddress && codec.byteArray &&
+			t.kind == ArrayStatic && t.staticLength == address.BytesSize
+	case Tuple:
+		codec.children = make([]*JSONCodec, len(t.childTypes))
+		for i, childT := range t.childTypes {
+			codec.children[i] = childT.newJSONCodec(opts, depth+1)
+		}
+		if len(t.fieldNames) > 0 {
+			codec.fieldIndexes = make(map[string]int, len(t.fieldNames))
+			for i, name := range t.fieldNames {
+				codec.fieldIndexes[name] = i
+			}
+		}
+	}
+	return codec
+}
+
+// Type returns the ABI type converted by the codec.
+func (c *JSONCodec) Type() Type {
+	return c.abiType
+}
+
+// Marshal converts golang value to JSON format, as described by `MarshalToJSONWithOptions`.
+func (c *JSONCodec) Marshal(value interface{}) ([]byte, error) {
+	encoded, err := c.marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return indentJSON(encoded, c.opts.Indent)
+}
+
+// indentJSON indents encoded JSON with indent as in json.MarshalIndent, or returns it unchanged if
+// indent is empty.
+func indentJSON(encoded []byte, indent string) ([]byte, error) {
+	if indent == "" {
+		return encoded, nil
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, encoded, "", indent); err != nil {
+		return nil, err
+	}
+	return indented.Bytes(), nil
+}
+
+// Unmarshal converts JSON encoded bytes to golang value, as described by
+// `UnmarshalFromJSONWithOptions`.
+func (c *JSONCodec) Unmarshal(jsonEncoded []byte) (interface{}, error) {
+	return c.UnmarshalReader(bytes.NewReader(jsonEncoded))
+}
+
+// UnmarshalReader reads a single JSON encoded value from r and converts it to golang value, as
+// described by `UnmarshalFromJSONReader`.
+func (c *JSONCodec) UnmarshalReader(r io.Reader) (interface{}, error) {
+	reader := newJSONReader(r, c.opts)
+	value, err := c.unmarshalValue(reader)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := reader.dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after JSON encoded %s value", c.abiType.String())
+	}
+	return value, nil
+}
+
+// marshal converts golang value to compact JSON.
+func (c *JSONCodec) marshal(value interface{}) ([]byte, error) {
+	t := c.abiType
+	switch t.kind {
+	case Uint:
+		bytesUint, err := encodeInt(value, t.bitSize)
+		if err != nil {
+			return nil, err
+		}
+		num := new(big.Int).SetBytes(bytesUint)
+		encoded, err := num.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		if c.opts.UintAsString || (c.opts.JSSafeIntegers && num.Cmp(maxJSSafeInteger) > 0) {
+			return quoteJSON(encoded), nil
+		}
+		return encoded, nil
+	case Ufixed:
+		encodedUint, err := encodeInt(value, t.bitSize)
+		if err != nil {
+			return nil, err
+		}
+		encoded := []byte(new(big.Rat).SetFrac(new(big.Int).SetBytes(encodedUint), c.denom).FloatString(int(t.precision)))
+		if c.opts.UfixedAsString {
+			return quoteJSON(encoded), nil
+		}
+		return encoded, nil
+	case Bool:
+		boolValue, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("cannot infer to bool for marshal to JSON")
+		}
+		return json.Marshal(boolValue)
+	case Byte:
+		byteValue, ok := value.(byte)
+		if !ok {
+			return nil, fmt.Errorf("cannot infer to byte for marshal to JSON")
+		}
+		return json.Marshal(byteValue)
+	case Address:
+		var addressBytes [address.BytesSize]byte
+		switch valueCasted := value.(type) {
+		case []byte:
+			addr, err := address.FromBytes(valueCasted)
+			if err != nil {
+				return nil, fmt.Errorf("address byte slice length not equal to 32 byte")
+			}
+			addressBytes = addr
+		case [address.BytesSize]byte:
+			addressBytes = valueCasted
+		default:
+			return nil, fmt.Errorf("cannot infer to byte slice/array for marshal to JSON")
+		}
+		return json.Marshal(address.ToString(addressBytes))
+	case ArrayStatic, ArrayDynamic:
+		values, err := inferToSlice(value)
+		if err != nil {
+			return nil, err
+		}
+		if t.kind == ArrayStatic && int(t.staticLength) != len(values) {
+			return nil, fmt.Errorf("length of slice %d != type specific length %d", len(values), t.staticLength)
+		}
+		if c.byteArray && (c.addressArray || !c.opts.ByteArraysAsNumbers) {
+			byteArr := make([]byte, len(values))
+			for i := 0; i < len(values); i++ {
+				tempByte, ok := values[i].(byte)
+				if !ok {
+					return nil, fmt.Errorf("cannot infer byte element from slice")
+				}
+				byteArr[i] = tempByte
+			}
+			if c.addressArray {
+				addr, err := address.FromBytes(byteArr)
+				if err != nil {
+					return nil, err
+				}
+				return json.Marshal(addr.String())
+			}
+			return json.Marshal(byteArr)
+		}
+		rawMsgSlice := make([]json.RawMessage, len(values))
+		for i := 0; i < len(values); i++ {
+			rawMsgSlice[i], err = c.children[0].marshal(values[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+		return json.Marshal(rawMsgSlice)
+	case String:
+		stringVal, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot infer to string for marshal to JSON")
+		}
+		return json.Marshal(stringVal)
+	case Tuple:
+		values, err := t.inferTupleValues(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != int(t.staticLength) {
+			return nil, fmt.Errorf("tuple element number != value slice length")
+		}
+		rawMsgSlice := make([]json.RawMessage, len(values))
+		for i := 0; i < len(values); i++ {
+			rawMsgSlice[i], err = c.children[i].marshal(values[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+		if c.fieldIndexes != nil {
+			return marshalJSONObject(t.fieldNames, rawMsgSlice)
+		}
+		return json.Marshal(rawMsgSlice)
+	default:
+		return nil, fmt.Errorf("cannot infer ABI type for marshalling value to JSON")
+	}
+}
+
+// jsonReader reads the JSON input of a single unmarshal call, enforcing the size limits of
+// JSONOptions while the input is read, rather than once each value has been read as a whole.
+type jsonReader struct {
+	dec *json.Decoder
+	src *limitedReader
+}
+
+// newJSONReader creates a jsonReader of r, limited to opts.MaxInputSize bytes. Numbers are read as
+// json.Number, so their JSON text is kept.
+func newJSONReader(r io.Reader, opts JSONOptions) *jsonReader {
+	src := &limitedReader{r: r, limit: opts.MaxInputSize}
+	dec := json.NewDecoder(src)
+	dec.UseNumber()
+	return &jsonReader{dec: dec, src: src}
+}
+
+// maxTokenPadding is the number of bytes of whitespace and separators which may precede a token
+// read by limitedToken.
+const maxTokenPadding = 1024
+
+// limitedToken reads the next token, failing with the error returned by limitErr as soon as it is too long to be a JSON
+// string of at most maxLength bytes. The decoder reads each string as a whole, so the limit must be
+// enforced on the input it reads. A JSON string takes at most 6 bytes per byte of its value, for
+// escapes such as \u0000, and the token may be preceded by up to maxTokenPadding bytes. The token is
+// not limited if maxLength is not positive.
+func (r *jsonReader) limitedToken(maxLength int, limitErr func() error) (json.Token, error) {
+	if maxLength > 0 {
+		r.src.valueEnd = r.dec.InputOffset() + maxTokenPadding + 2 + 6*int64(maxLength)
+		r.src.valueErr = limitErr
+		defer func() { r.src.valueErr = nil }()
+	}
+	return r.dec.Token()
+}
+
+// limitedReader reads from r, failing once more than limit bytes are read if limit is positive, and
+// once the reader asks for input past valueEnd while valueErr is set. Unlike io.LimitReader, it
+// fails rather than reporting the end of the input, so oversized input is not mistaken for
+// truncated JSON.
+type limitedReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+	// valueEnd is the input offset which the token being read must end by, and valueErr returns
+	// the error if it does not
+	valueEnd int64
+	valueErr func() error
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.limit > 0 && l.read > l.limit {
+		return 0, l.inputTooLarge()
+	}
+	if l.valueErr != nil {
+		if l.read >= l.valueEnd {
+			return 0, l.valueErr()
+		}
+		p = truncateBuffer(p, l.valueEnd-l.read)
+	}
+	if l.limit > 0 {
+		// one more byte is read to tell inputs of exactly limit bytes from larger ones
+		p = truncateBuffer(p, l.limit+1-l.read)
+	}
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.limit > 0 && l.read > l.limit {
+		return 0, l.inputTooLarge()
+	}
+	return n, err
+}
+
+func (l *limitedReader) inputTooLarge() error {
+	return fmt.Errorf("%w: input has more than %d bytes", ErrInputTooLarge, l.limit)
+}
+
+// truncateBuffer returns the first size bytes of p, or p if it is shorter.
+func truncateBuffer(p []byte, size int64) []byte {
+	if int64(len(p)) > size {
+		return p[:size]
+	}
+	return p
+}
+
+// unmarshalValue reads the next JSON value from r.
+func (c *JSONCodec) unmarshalValue(r *jsonReader) (interface{}, error) {
+	switch c.abiType.kind {
+	case ArrayStatic, ArrayDynamic:
+		return c.unmarshalArray(r)
+	case Tuple:
+		return c.unmarshalTuple(r)
+	case String:
+		return c.unmarshalString(r)
+	default:
+		token, err := r.dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read JSON encoded %s value: %w", c.abiType.String(), err)
+		}
+		// arrays and objects are rejected before they are read, however deeply they are nested
+		if _, ok := token.(json.Delim); ok {
+			return nil, fmt.Errorf("cannot cast JSON encoded (%v) to %s: unexpected JSON array or object", token, c.abiType.String())
+		}
+		return c.unmarshalScalar(tokenJSON(token))
+	}
+}
+
+// tokenJSON returns the JSON encoding of a string, number, boolean, or null token.
+func tokenJSON(token json.Token) []byte {
+	switch value := token.(type) {
+	case json.Number:
+		return []byte(value)
+	case string:
+		encoded, _ := json.Marshal(value)
+		return encoded
+	case bool:
+		return strconv.AppendBool(nil, value)
+	default:
+		return []byte("null")
+	}
+}
+
+// unmarshalString reads a string value from r, given as a JSON string or, with
+// JSONOptions.StringFromByteArray, as a JSON array of bytes.
+func (c *JSONCodec) unmarshalString(r *jsonReader) (interface{}, error) {
+	maxLength := c.opts.MaxStringLength
+	token, err := r.limitedToken(maxLength, c.stringTooLong)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read JSON encoded string value: %w", err)
+	}
+	switch value := token.(type) {
+	case string:
+		if err := c.checkStringLength(len(value)); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case json.Delim:
+		if value != '[' {
+			return nil, fmt.Errorf("cannot cast JSON encoded (%v) to string", token)
+		}
+		if !c.opts.StringFromByteArray {
+			return nil, fmt.Errorf(
+				"cannot cast JSON encoded array to string: JSON arrays are only accepted as strings " +
+					"with JSONOptions.StringFromByteArray, consider the byte[] type instead")
+		}
+		if err := c.checkDepth(); err != nil {
+			return nil, err
+		}
+		var elems []byte
+		for r.dec.More() {
+			if maxLength > 0 && len(elems) == maxLength {
+				return nil, c.stringTooLong()
+			}
+			token, err := r.dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("cannot read JSON encoded string value: %w", err)
+			}
+			num, _ := token.(json.Number)
+			elem, err := strconv.ParseUint(string(num), 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("cannot cast JSON encoded (%v) to string: array elements must be bytes", token)
+			}
+			elems = append(elems, byte(elem))
+		}
+		if _, err := r.dec.Token(); err != nil {
+			return nil, fmt.Errorf("cannot read JSON encoded string value: %w", err)
+		}
+		return string(elems), nil
+	default:
+		return nil, fmt.Errorf("cannot cast JSON encoded (%v) to string", token)
+	}
+}
+
+// unmarshalScalar converts the JSON encoding of a non-array, non-tuple, non-string ABI value.
+func (c *JSONCodec) unmarshalScalar(jsonEncoded []byte) (interface{}, error) {
+	t := c.abiType
+	switch t.kind {
+	case Uint:
+		digits := unquoteJSON(jsonEncoded, c.opts.UintAsString || c.opts.JSSafeIntegers || c.opts.UintFromString)
+		// big.Int also accepts prefixed and underscored forms, such as 0x10 and 1_000
+		if !isDecimalDigits(digits) {
+			return nil, fmt.Errorf("cannot cast JSON encoded (%s) to uint: not a decimal integer", string(jsonEncoded))
+		}
+		num, _ := new(big.Int).SetString(string(digits), 10)
+		return castBigIntToNearestPrimitive(num, t.bitSize)
+	case Ufixed:
+		floatTemp, err := parseUfixedJSON(unquoteJSON(jsonEncoded, c.opts.UfixedAsString))
+		if err != nil {
+			return nil, fmt.Errorf("cannot cast JSON encoded (%s) to ufixed: %w", string(jsonEncoded), err)
+		}
+		denomRat := new(big.Rat).SetInt(c.denom)
+		numeratorRat := new(big.Rat).Mul(denomRat, floatTemp)
+		if !numeratorRat.IsInt() {
+			return nil, fmt.Errorf("cannot cast JSON encoded (%s) to ufixed: precision out of range", string(jsonEncoded))
+		}
+		return castBigIntToNearestPrimitive(numeratorRat.Num(), t.bitSize)
+	case Bool:
+		var elem bool
+		if err := json.Unmarshal(jsonEncoded, &elem); err != nil {
+			return nil, fmt.Errorf("cannot cast JSON encoded (%s) to bool: %w", string(jsonEncoded), err)
+		}
+		return elem, nil
+	case Byte:
+		var elem byte
+		if err := json.Unmarshal(jsonEncoded, &elem); err != nil {
+			return nil, fmt.Errorf("cannot cast JSON encoded to byte: %w", err)
+		}
+		return elem, nil
+	case Address:
+		var addrStr string
+		if err := json.Unmarshal(jsonEncoded, &addrStr); err != nil {
+			return nil, fmt.Errorf("cannot cast JSON encoded (%s) to address string: %w", string(jsonEncoded), err)
+		}
+
+		addrBytes, err := address.FromString(addrStr)
+		if err != nil {
+			return nil, err
+		}
+
+		return addrBytes[:], nil
+	default:
+		return nil, fmt.Errorf("cannot cast JSON encoded %s to ABI encoding stuff", string(jsonEncoded))
+	}
+}
+
+// isDecimalDigits reports whether text is a non-empty sequence of decimal digits.
+func isDecimalDigits(text []byte) bool {
+	if len(text) == 0 {
+		return false
+	}
+	for _, c := range text {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// checkDepth returns an error wrapping ErrTooDeep if the arrays or objects read by c exceed
+// JSONOptions.MaxDepth.
+func (c *JSONCodec) checkDepth() error {
+	if c.opts.MaxDepth > 0 && c.depth > c.opts.MaxDepth {
+		return fmt.Errorf("%w: %s value at depth %d, limit is %d", ErrTooDeep, c.abiType.String(), c.depth, c.opts.MaxDepth)
+	}
+	return nil
+}
+
+// stringTooLong returns an error wrapping ErrStringTooLong for a string longer than
+// JSONOptions.MaxStringLength.
+func (c *JSONCodec) stringTooLong() error {
+	return fmt.Errorf("%w: string value has more than %d bytes", ErrStringTooLong, c.opts.MaxStringLength)
+}
+
+// arrayTooLong returns an error wrapping ErrArrayTooLong for an array longer than
+// JSONOptions.MaxArrayLength.
+func (c *JSONCodec) arrayTooLong() error {
+	return fmt.Errorf("%w: %s value has more than %d elements", ErrArrayTooLong, c.abiType.String(), c.opts.MaxArrayLength)
+}
+
+// checkStringLength returns an error wrapping ErrStringTooLong if a string of length bytes exceeds
+// JSONOptions.MaxStringLength.
+func (c *JSONCodec) checkStringLength(length int) error {
+	if c.opts.MaxStringLength > 0 && length > c.opts.MaxStringLength {
+		return fmt.Errorf("%w: string value has %d bytes, limit is %d", ErrStringTooLong, length, c.opts.MaxStringLength)
+	}
+	return nil
+}
+
+// maxUfixedExponent bounds the magnitude of the exponent accepted in ufixed JSON values. The largest
+// ufixed type has 512 bits and a precision of 160, so larger exponents never describe a valid value,
+// and evaluating them exactly would be needlessly expensive.
+const maxUfixedExponent = 1000
+
+// ufixedJSONRegexp matches the decimal numbers accepted as ufixed values, whose exponent has at most
+// 19 digits, so it fits in an int64 before it is checked against maxUfixedExponent. It excludes the
+// other forms accepted by big.Rat, such as fractions, hexadecimal floats, and underscores.
+var ufixedJSONRegexp = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?([eE][+-]?[0-9]{1,19})?$`)
+
+// parseUfixedJSON parses a JSON number, optionally in scientific notation such as 1.5e3, as an exact
+// rational value.
+func parseUfixedJSON(text []byte) (*big.Rat, error) {
+	if !ufixedJSONRegexp.Match(text) {
+		return nil, fmt.Errorf("not a decimal number")
+	}
+	if expIndex := bytes.IndexAny(text, "eE"); expIndex != -1 {
+		exp, err := strconv.ParseInt(string(text[expIndex+1:]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %w", err)
+		}
+		if exp > maxUfixedExponent || exp < -maxUfixedExponent {
+			return nil, fmt.Errorf("exponent %d out of range", exp)
+		}
+	}
+	value := new(big.Rat)
+	if err := value.UnmarshalText(text); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// unmarshalArray reads a static or dynamic array from r. Byte arrays may also be given as a
+// base64 encoded JSON string.
+func (c *JSONCodec) unmarshalArray(r *jsonReader) (interface{}, error) {
+	t := c.abiType
+	maxLength := c.opts.MaxArrayLength
+	var token json.Token
+	var err error
+	if c.byteArray && maxLength > 0 {
+		token, err = r.limitedToken(base64.StdEncoding.EncodedLen(maxLength), c.arrayTooLong)
+	} else {
+		token, err = r.dec.Token()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read JSON encoded %s value: %w", t.String(), err)
+	}
+	if encodedBytes, ok := token.(string); ok && c.byteArray {
+		var byteArr []byte
+		if addressBytes, addrErr := address.FromString(encodedBytes); c.addressArray && addrErr == nil {
+			byteArr = addressBytes[:]
+		} else {
+			byteArr, err = base64.StdEncoding.DecodeString(encodedBytes)
+			if err != nil {
+				if c.addressArray {
+					return nil, fmt.Errorf("cannot cast JSON encoded (%q) to address or bytes: %w", encodedBytes, addrErr)
+				}
+				return nil, fmt.Errorf("cannot cast JSON encoded (%q) to bytes: %w", encodedBytes, err)
+			}
+		}
+		if maxLength > 0 && len(byteArr) > maxLength {
+			return nil, fmt.Errorf("%w: %s value has %d elements, limit is %d", ErrArrayTooLong, t.String(), len(byteArr), maxLength)
+		}
+		if t.kind == ArrayStatic && len(byteArr) != int(t.staticLength) {
+			return nil, fmt.Errorf("length of slice %d != type specific length %d", len(byteArr), t.staticLength)
+		}
+		outInterface := make([]interface{}, len(byteArr))
+		for i := 0; i < len(byteArr); i++ {
+			outInterface[i] = byteArr[i]
+		}
+		return outInterface, nil
+	}
+	values := make([]interface{}, 0, t.staticLength)
+	// null is read as an empty array, as by json.Unmarshal
+	if token != nil {
+		if token != json.Delim('[') {
+			return nil, fmt.Errorf("cannot cast JSON encoded (%v) to array: expected JSON array", token)
+		}
+		if err := c.checkDepth(); err != nil {
+			return nil, err
+		}
+		for r.dec.More() {
+			if t.kind == ArrayStatic && len(values) == int(t.staticLength) {
+				return nil, fmt.Errorf("JSON array element number != ABI array elem number")
+			}
+			if maxLength > 0 && len(values) == maxLength {
+				return nil, c.arrayTooLong()
+			}
+			tempValue, err := c.children[0].unmarshalValue(r)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, tempValue)
+		}
+		if _, err := r.dec.Token(); err != nil {
+			return nil, fmt.Errorf("cannot read JSON encoded %s value: %w", t.String(), err)
+		}
+	}
+	if t.kind == ArrayStatic && len(values) != int(t.staticLength) {
+		return nil, fmt.Errorf("JSON array element number != ABI array elem number")
+	}
+	return values, nil
+}
+
+// unmarshalTuple reads a tuple from r. Named tuples may also be given as a JSON object keyed by
+// field name.
+func (c *JSONCodec) unmarshalTuple(r *jsonReader) (interface{}, error) {
+	t := c.abiType
+	token, err := r.dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read JSON encoded %s value: %w", t.String(), err)
+	}
+	if c.fieldIndexes != nil && token == json.Delim('{') {
+		if err := c.checkDepth(); err != nil {
+			return nil, err
+		}
+		return c.unmarshalObject(r)
+	}
+	values := make([]interface{}, 0, len(c.children))
+	// null is read as an empty array, as by json.Unmarshal
+	if token != nil {
+		if token != json.Delim('[') {
+			return nil, fmt.Errorf("cannot cast JSON encoded (%v) to array for tuple: expected JSON array", token)
+		}
+		if err := c.checkDepth(); err != nil {
+			return nil, err
+		}
+		for r.dec.More() {
+			if len(values) == len(c.children) {
+				return nil, fmt.Errorf("JSON array element number != ABI tuple elem number")
+			}
+			tempValue, err := c.children[len(values)].unmarshalValue(r)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, tempValue)
+		}
+		if _, err := r.dec.Token(); err != nil {
+			return nil, fmt.Errorf("cannot read JSON encoded %s value: %w", t.String(), err)
+		}
+	}
+	if len(values) != len(c.children) {
+		if !c.opts.ZeroFillMissing {
+			return nil, fmt.Errorf("JSON array element number != ABI tuple elem number")
+		}
+		for _, child := range c.children[len(values):] {
+			values = append(values, child.abiType.zeroValue())
+		}
+	}
+	return values, nil
+}
+
+// unmarshalObject reads the remainder of a JSON object keyed by field name from r, after its
+// opening brace, into the element values of a named tuple. Every field must be present exactly once
+// and unknown fields are rejected.
+func (c *JSONCodec) unmarshalObject(r *jsonReader) (interface{}, error) {
+	t := c.abiType
+	values := make([]interface{}, len(t.fieldNames))
+	present := make([]bool, len(t.fieldNames))
+	for r.dec.More() {
+		token, err := r.dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read JSON encoded %s value: %w", t.String(), err)
+		}
+		name, _ := token.(string)
+		index, ok := c.fieldIndexes[name]
+		if !ok {
+			return nil, fmt.Errorf(`unknown field "%s" for named tuple %s`, name, t.String())
+		}
+		if present[index] {
+			return nil, fmt.Errorf(`duplicate field "%s" for named tuple %s`, name, t.String())
+		}
+		tempValue, err := c.children[index].unmarshalValue(r)
+		if err != nil {
+			return nil, err
+		}
+		values[index] = tempValue
+		present[index] = true
+	}
+	if _, err := r.dec.Token(); err != nil {
+		return nil, fmt.Errorf("cannot read JSON encoded %s value: %w", t.String(), err)
+	}
+	for i, name := range t.fieldNames {
+		if present[i] {
+			continue
+		}
+		if !c.opts.ZeroFillMissing {
+			return nil, fmt.Errorf(`missing field "%s" for named tuple %s`, name, t.String())
+		}
+		values[i] = t.childTypes[i].zeroValue()
+	}
+	return values, nil
+}
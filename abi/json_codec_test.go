@@ -0,0 +1,48 @@
+package abi
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONCodec(t *testing.T) {
+	t.Parallel()
+
+	abiT, err := TypeOf("(uint64,ufixed64x2,byte[],(bool,string)[])")
+	require.NoError(t, err)
+
+	codec := abiT.NewJSONCodec(JSONOptions{UfixedAsString: true})
+	require.True(t, abiT.Equal(codec.Type()))
+
+	// the subtests run in parallel, sharing the same codec
+	for i := 0; i < 16; i++ {
+		i := i
+		t.Run(fmt.Sprintf("i=%d", i), func(t *testing.T) {
+			t.Parallel()
+			value := []interface{}{
+				uint64(i),
+				uint64(i * 100),
+				[]interface{}{byte(i)},
+				[]interface{}{[]interface{}{i%2 == 0, fmt.Sprint(i)}},
+			}
+			expected := fmt.Sprintf(`[%d,"%d.00","%s",[[%t,"%d"]]]`,
+				i, i, base64.StdEncoding.EncodeToString([]byte{byte(i)}), i%2 == 0, i)
+
+			encoded, err := codec.Marshal(value)
+			require.NoError(t, err)
+			require.Equal(t, expected, string(encoded))
+
+			decoded, err := codec.Unmarshal(encoded)
+			require.NoError(t, err)
+			require.Equal(t, value, decoded)
+
+			// the codec must produce the same results as the equivalent Type methods
+			typeEncoded, err := abiT.MarshalToJSONWithOptions(value, JSONOptions{UfixedAsString: true})
+			require.NoError(t, err)
+			require.Equal(t, encoded, typeEncoded)
+		})
+	}
+}
@@ -2,7 +2,9 @@ package abi
 
 import (
 	"fmt"
+	"io"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -57,14 +59,24 @@ func TestUnmarshalFromJSON(t *testing.T) {
 			expected: []interface{}{},
 		},
 		{
-			input:    "[65, 66, 67]",
-			typeStr:  "string",
-			expected: "ABC",
+			input:    `null`,
+			typeStr:  `(uint64,bool[2],string)[]`,
+			expected: []interface{}{},
 		},
 		{
-			input:    "[]",
-			typeStr:  "string",
-			expected: "",
+			input:    `null`,
+			typeStr:  `byte[]`,
+			expected: []interface{}{},
+		},
+		{
+			input:    `null`,
+			typeStr:  `()`,
+			expected: []interface{}{},
+		},
+		{
+			input:    `[null,[]]`,
+			typeStr:  `(string[],uint64[0])`,
+			expected: []interface{}{[]interface{}{}, []interface{}{}},
 		},
 		{
 			input:    "123.456",
@@ -111,6 +123,37 @@ func TestUnmarshalFromJSON(t *testing.T) {
 	}
 }
 
+func TestUnmarshalNullArray(t *testing.T) {
+	t.Parallel()
+
+	// null is read as an empty array, so it is only accepted for arrays and tuples which may be empty
+	errorCases := []struct {
+		typeStr string
+		err     string
+	}{
+		{typeStr: "uint64[2]", err: "JSON array element number != ABI array elem number"},
+		{typeStr: "byte[3]", err: "JSON array element number != ABI array elem number"},
+		{typeStr: "(uint64,bool)", err: "JSON array element number != ABI tuple elem number"},
+		{typeStr: "string", err: "cannot cast JSON encoded (<nil>) to string"},
+	}
+	for _, errorCase := range errorCases {
+		errorCase := errorCase
+		t.Run(errorCase.typeStr, func(t *testing.T) {
+			t.Parallel()
+			abiType, err := TypeOf(errorCase.typeStr)
+			require.NoError(t, err)
+			_, err = abiType.UnmarshalFromJSON([]byte("null"))
+			require.ErrorContains(t, err, errorCase.err)
+		})
+	}
+
+	abiType, err := TypeOf("(uint64,bool)")
+	require.NoError(t, err)
+	value, err := abiType.UnmarshalFromJSONWithOptions([]byte("null"), JSONOptions{ZeroFillMissing: true})
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{uint64(0), false}, value)
+}
+
 func TestMarshalToJSON(t *testing.T) {
 	t.Parallel()
 	var testCases = []struct {
@@ -242,3 +285,743 @@ func TestMarshalToJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestNamedTupleJSON(t *testing.T) {
+	t.Parallel()
+
+	uint64Type, err := TypeOf("uint64")
+	require.NoError(t, err)
+	innerType, err := MakeNamedTupleType([]Type{boolType, stringType}, []string{"flag", "note"})
+	require.NoError(t, err)
+	namedType, err := MakeNamedTupleType(
+		[]Type{addressType, uint64Type, innerType},
+		[]string{"owner", "amount", "extra"},
+	)
+	require.NoError(t, err)
+
+	addr := []byte{16, 10, 81, 202, 158, 158, 46, 209, 139, 213, 244, 123, 112, 56, 225, 176, 71, 198, 31, 126, 155, 105, 97, 91, 131, 241, 213, 95, 145, 71, 126, 247}
+	value := []interface{}{addr, uint64(5), []interface{}{true, "hi"}}
+	expectedJSON := `{"owner":"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM","amount":5,"extra":{"flag":true,"note":"hi"}}`
+
+	t.Run("marshal", func(t *testing.T) {
+		t.Parallel()
+		actual, err := namedType.MarshalToJSON(value)
+		require.NoError(t, err)
+		require.Equal(t, expectedJSON, string(actual))
+
+		actual, err = namedType.MarshalToJSON(map[string]interface{}{
+			"amount": uint64(5),
+			"owner":  addr,
+			"extra":  map[string]interface{}{"note": "hi", "flag": true},
+		})
+		require.NoError(t, err)
+		require.Equal(t, expectedJSON, string(actual))
+
+		_, err = namedType.MarshalToJSON(map[string]interface{}{"owner": addr, "amount": uint64(5)})
+		require.ErrorContains(t, err, `missing field "extra"`)
+
+		_, err = namedType.MarshalToJSON(map[string]interface{}{
+			"owner": addr, "amount": uint64(5), "extra": []interface{}{true, "hi"}, "other": 1,
+		})
+		require.ErrorContains(t, err, `unknown field "other"`)
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		t.Parallel()
+		actual, err := namedType.UnmarshalFromJSON([]byte(expectedJSON))
+		require.NoError(t, err)
+		require.Equal(t, value, actual)
+
+		// positional arrays are still accepted for named tuples
+		actual, err = namedType.UnmarshalFromJSON([]byte(`["CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM",5,[true,"hi"]]`))
+		require.NoError(t, err)
+		require.Equal(t, value, actual)
+
+		_, err = namedType.UnmarshalFromJSON([]byte(`{"owner":"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM","amount":5}`))
+		require.ErrorContains(t, err, `missing field "extra"`)
+
+		_, err = namedType.UnmarshalFromJSON([]byte(`{"owner":"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM","amount":5,"extra":{"flag":true,"note":"hi"},"amnt":6}`))
+		require.ErrorContains(t, err, `unknown field "amnt"`)
+
+		_, err = namedType.UnmarshalFromJSON([]byte(`{"amount":5,"amount":6}`))
+		require.ErrorContains(t, err, `duplicate field "amount"`)
+
+		unnamedType, err := TypeOf("(address,uint64,(bool,string))")
+		require.NoError(t, err)
+		_, err = unnamedType.UnmarshalFromJSON([]byte(expectedJSON))
+		require.Error(t, err)
+	})
+}
+
+func TestJSONNumbersAsStrings(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		typeStr  string
+		value    interface{}
+		opts     JSONOptions
+		expected string
+	}{
+		{
+			typeStr:  "ufixed64x3",
+			value:    uint64(12340),
+			opts:     JSONOptions{UfixedAsString: true},
+			expected: `"12.340"`,
+		},
+		{
+			typeStr:  "ufixed64x3",
+			value:    uint64(12340),
+			opts:     JSONOptions{UintAsString: true},
+			expected: `12.340`,
+		},
+		{
+			typeStr:  "uint64",
+			value:    uint64(18446744073709551615),
+			opts:     JSONOptions{UintAsString: true},
+			expected: `"18446744073709551615"`,
+		},
+		{
+			typeStr:  "uint64",
+			value:    uint64(5),
+			opts:     JSONOptions{UfixedAsString: true},
+			expected: `5`,
+		},
+		{
+			typeStr:  "(uint8,ufixed128x10[])",
+			value:    []interface{}{uint8(1), []interface{}{big.NewInt(1), big.NewInt(20000000000)}},
+			opts:     JSONOptions{UfixedAsString: true, UintAsString: true},
+			expected: `["1",["0.0000000001","2.0000000000"]]`,
+		},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(fmt.Sprintf("i=%d", i), func(t *testing.T) {
+			abiT, err := TypeOf(testCase.typeStr)
+			require.NoError(t, err)
+
+			actualJSON, err := abiT.MarshalToJSONWithOptions(testCase.value, testCase.opts)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, string(actualJSON))
+
+			decoded, err := abiT.UnmarshalFromJSONWithOptions(actualJSON, testCase.opts)
+			require.NoError(t, err)
+			require.Equal(t, testCase.value, decoded)
+		})
+	}
+
+	ufixedType, err := TypeOf("ufixed64x3")
+	require.NoError(t, err)
+	_, err = ufixedType.UnmarshalFromJSON([]byte(`"12.340"`))
+	require.Error(t, err, "quoted ufixed values should only be accepted with UfixedAsString")
+}
+
+func TestUnmarshalUfixedScientificNotation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		typeStr  string
+		input    string
+		opts     JSONOptions
+		expected interface{}
+	}{
+		{typeStr: "ufixed64x2", input: `1.5e3`, expected: uint64(150000)},
+		{typeStr: "ufixed64x2", input: `1.5E+3`, expected: uint64(150000)},
+		{typeStr: "ufixed64x3", input: `125e-3`, expected: uint64(125)},
+		{typeStr: "ufixed64x3", input: `0.0125E1`, expected: uint64(125)},
+		{typeStr: "ufixed64x2", input: `"2.5e2"`, opts: JSONOptions{UfixedAsString: true}, expected: uint64(25000)},
+		{typeStr: "ufixed256x10", input: `1e60`, expected: new(big.Int).Exp(big.NewInt(10), big.NewInt(70), nil)},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(fmt.Sprintf("i=%d", i), func(t *testing.T) {
+			abiT, err := TypeOf(testCase.typeStr)
+			require.NoError(t, err)
+			decoded, err := abiT.UnmarshalFromJSONWithOptions([]byte(testCase.input), testCase.opts)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, decoded)
+		})
+	}
+
+	errorCases := []struct {
+		typeStr string
+		input   string
+		err     string
+	}{
+		{typeStr: "ufixed64x2", input: `1.2345e1`, err: "precision out of range"},
+		{typeStr: "ufixed64x2", input: `1e-3`, err: "precision out of range"},
+		{typeStr: "ufixed64x2", input: `1e30`, err: "cast big int to nearest primitive failure"},
+		{typeStr: "ufixed64x2", input: `1e1001`, err: "exponent 1001 out of range"},
+		{typeStr: "ufixed64x2", input: `1e-99999999`, err: "exponent -99999999 out of range"},
+		{typeStr: "ufixed64x2", input: `1e999999999999999999999`, err: "not a decimal number"},
+		{typeStr: "ufixed64x2", input: `1e9999999999999999999`, err: "invalid exponent"},
+		{typeStr: "ufixed64x2", input: `"1/2"`, err: "not a decimal number"},
+		{typeStr: "ufixed64x2", input: `"0x1p10000000"`, err: "not a decimal number"},
+		{typeStr: "ufixed64x2", input: `"0x1p-2"`, err: "not a decimal number"},
+		{typeStr: "ufixed64x2", input: `"0x10"`, err: "not a decimal number"},
+		{typeStr: "ufixed64x2", input: `"0b11"`, err: "not a decimal number"},
+		{typeStr: "ufixed64x2", input: `"0o17"`, err: "not a decimal number"},
+		{typeStr: "ufixed64x2", input: `"1p2"`, err: "not a decimal number"},
+		{typeStr: "ufixed64x2", input: `"1_000"`, err: "not a decimal number"},
+		{typeStr: "ufixed64x2", input: `".5"`, err: "not a decimal number"},
+		{typeStr: "ufixed64x2", input: `"1e"`, err: "not a decimal number"},
+	}
+
+	for i, errorCase := range errorCases {
+		t.Run(fmt.Sprintf("error i=%d", i), func(t *testing.T) {
+			abiT, err := TypeOf(errorCase.typeStr)
+			require.NoError(t, err)
+			_, err = abiT.UnmarshalFromJSONWithOptions([]byte(errorCase.input), JSONOptions{UfixedAsString: true})
+			require.ErrorContains(t, err, errorCase.err)
+		})
+	}
+}
+
+func TestJSSafeIntegers(t *testing.T) {
+	t.Parallel()
+
+	abiT, err := TypeOf("(uint64,uint64,uint256)")
+	require.NoError(t, err)
+
+	opts := JSONOptions{JSSafeIntegers: true}
+	value := []interface{}{uint64(1<<53 - 1), uint64(1 << 53), new(big.Int).Lsh(big.NewInt(1), 200)}
+	expected := `[9007199254740991,"9007199254740992","1606938044258990275541962092341162602522202993782792835301376"]`
+
+	actualJSON, err := abiT.MarshalToJSONWithOptions(value, opts)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(actualJSON))
+
+	decoded, err := abiT.UnmarshalFromJSONWithOptions(actualJSON, opts)
+	require.NoError(t, err)
+	require.Equal(t, value, decoded)
+
+	_, err = abiT.UnmarshalFromJSON(actualJSON)
+	require.Error(t, err, "quoted uint values should only be accepted with JSSafeIntegers")
+}
+
+func TestUnmarshalUintFromString(t *testing.T) {
+	t.Parallel()
+
+	abiT, err := TypeOf("(uint64,uint128,uint8[])")
+	require.NoError(t, err)
+
+	large, ok := new(big.Int).SetString("123456789012345678901234", 10)
+	require.True(t, ok)
+	expected := []interface{}{uint64(7), large, []interface{}{uint8(1), uint8(2)}}
+	opts := JSONOptions{UintFromString: true}
+
+	decoded, err := abiT.UnmarshalFromJSONWithOptions([]byte(`["7","123456789012345678901234",[1,"2"]]`), opts)
+	require.NoError(t, err)
+	require.Equal(t, expected, decoded)
+
+	// output is unaffected by the option
+	actualJSON, err := abiT.MarshalToJSONWithOptions(expected, opts)
+	require.NoError(t, err)
+	require.Equal(t, `[7,123456789012345678901234,[1,2]]`, string(actualJSON))
+
+	_, err = abiT.UnmarshalFromJSON([]byte(`["7","123456789012345678901234",[1,2]]`))
+	require.Error(t, err, "quoted uint values should only be accepted with UintFromString")
+
+	_, err = abiT.UnmarshalFromJSONWithOptions([]byte(`["18446744073709551616",0,[]]`), opts)
+	require.ErrorContains(t, err, "cast big int to nearest primitive failure")
+
+	_, err = abiT.UnmarshalFromJSONWithOptions([]byte(`["seven",0,[]]`), opts)
+	require.ErrorContains(t, err, "cannot cast JSON encoded")
+
+	// only decimal digits are accepted, as produced by MarshalToJSONWithOptions
+	uintType, err := TypeOf("uint64")
+	require.NoError(t, err)
+	for _, input := range []string{`"0x10"`, `"0X10"`, `"0b11"`, `"0o17"`, `"0_17"`, `"1_000"`, `"+1"`, `"-1"`, `" 1"`, `""`, `-1`, `1.0`, `1e3`} {
+		for _, opts := range []JSONOptions{{UintFromString: true}, {UintAsString: true}, {JSSafeIntegers: true}} {
+			_, err := uintType.UnmarshalFromJSONWithOptions([]byte(input), opts)
+			require.ErrorContains(t, err, "not a decimal integer", input)
+		}
+	}
+	// leading zeros do not make a number octal
+	decoded, err = uintType.UnmarshalFromJSONWithOptions([]byte(`"010"`), opts)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), decoded)
+}
+
+func TestUnmarshalFromJSONReader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("large array", func(t *testing.T) {
+		t.Parallel()
+		abiT, err := TypeOf("uint64[]")
+		require.NoError(t, err)
+
+		const count = 100000
+		reader, writer := io.Pipe()
+		go func() {
+			writer.Write([]byte("["))
+			for i := 0; i < count; i++ {
+				if i > 0 {
+					writer.Write([]byte(","))
+				}
+				fmt.Fprintf(writer, "%d", i)
+			}
+			writer.Write([]byte("]"))
+			writer.Close()
+		}()
+
+		decoded, err := abiT.UnmarshalFromJSONReader(reader, JSONOptions{})
+		require.NoError(t, err)
+		values := decoded.([]interface{})
+		require.Len(t, values, count)
+		require.Equal(t, uint64(count-1), values[count-1])
+	})
+
+	t.Run("whitespace", func(t *testing.T) {
+		t.Parallel()
+		abiT, err := TypeOf("(bool,byte[],uint64)")
+		require.NoError(t, err)
+		decoded, err := abiT.UnmarshalFromJSONReader(strings.NewReader("\n [ true , \"AAEC\" , 17 ]\n"), JSONOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{true, []interface{}{byte(0), byte(1), byte(2)}, uint64(17)}, decoded)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			typeStr       string
+			input         string
+			expectedError string
+		}{
+			{typeStr: "uint64[]", input: "[1,2] [3]", expectedError: "unexpected data after JSON encoded uint64[] value"},
+			{typeStr: "uint64[2]", input: "[1,2,3]", expectedError: "JSON array element number != ABI array elem number"},
+			{typeStr: "uint64[2]", input: "[1]", expectedError: "JSON array element number != ABI array elem number"},
+			{typeStr: "(uint64,bool)", input: "[1,true,false]", expectedError: "JSON array element number != ABI tuple elem number"},
+			{typeStr: "(uint64,bool)", input: "{}", expectedError: "expected JSON array"},
+			{typeStr: "uint64[]", input: "[1,2", expectedError: "cannot read JSON encoded uint64 value"},
+			{typeStr: "byte[]", input: `"!!"`, expectedError: "to bytes"},
+		}
+		for _, testCase := range testCases {
+			abiT, err := TypeOf(testCase.typeStr)
+			require.NoError(t, err)
+			_, err = abiT.UnmarshalFromJSONReader(strings.NewReader(testCase.input), JSONOptions{})
+			require.ErrorContains(t, err, testCase.expectedError, "input %s", testCase.input)
+		}
+	})
+}
+
+func TestMarshalToJSONStructs(t *testing.T) {
+	t.Parallel()
+
+	type inner struct {
+		Flag bool
+		Note string
+	}
+	type transfer struct {
+		Receiver [32]byte `abi:"owner"`
+		Amount   uint64   `abi:"amount"`
+		Extra    *inner   `abi:"extra"`
+		Internal string   `abi:"-"`
+		unused   int
+	}
+
+	addr := [32]byte{16, 10, 81, 202, 158, 158, 46, 209, 139, 213, 244, 123, 112, 56, 225, 176, 71, 198, 31, 126, 155, 105, 97, 91, 131, 241, 213, 95, 145, 71, 126, 247}
+	value := transfer{Receiver: addr, Amount: 5, Extra: &inner{Flag: true, Note: "hi"}, Internal: "ignored", unused: 1}
+
+	t.Run("positional", func(t *testing.T) {
+		t.Parallel()
+		abiT, err := TypeOf("(address,uint64,(bool,string))")
+		require.NoError(t, err)
+
+		actual, err := abiT.MarshalToJSON(value)
+		require.NoError(t, err)
+		require.Equal(t, `["CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM",5,[true,"hi"]]`, string(actual))
+
+		actual, err = abiT.MarshalToJSON(&value)
+		require.NoError(t, err)
+		require.Equal(t, `["CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM",5,[true,"hi"]]`, string(actual))
+
+		encoded, err := abiT.Encode(value)
+		require.NoError(t, err)
+		expectedEncoded, err := abiT.Encode([]interface{}{addr, uint64(5), []interface{}{true, "hi"}})
+		require.NoError(t, err)
+		require.Equal(t, expectedEncoded, encoded)
+	})
+
+	t.Run("named", func(t *testing.T) {
+		t.Parallel()
+		uint64Type, err := TypeOf("uint64")
+		require.NoError(t, err)
+		innerType, err := MakeNamedTupleType([]Type{boolType, stringType}, []string{"Flag", "Note"})
+		require.NoError(t, err)
+		// field order differs from the struct declaration order
+		abiT, err := MakeNamedTupleType([]Type{uint64Type, addressType, innerType}, []string{"amount", "owner", "extra"})
+		require.NoError(t, err)
+
+		actual, err := abiT.MarshalToJSON(value)
+		require.NoError(t, err)
+		require.Equal(t, `{"amount":5,"owner":"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM","extra":{"Flag":true,"Note":"hi"}}`, string(actual))
+
+		mismatched, err := MakeNamedTupleType([]Type{uint64Type, addressType, innerType}, []string{"amt", "owner", "extra"})
+		require.NoError(t, err)
+		_, err = mismatched.MarshalToJSON(value)
+		require.ErrorContains(t, err, `unknown field "amount"`)
+	})
+
+	t.Run("typed slices", func(t *testing.T) {
+		t.Parallel()
+		abiT, err := TypeOf("(uint32,bool)[]")
+		require.NoError(t, err)
+		type pair struct {
+			A uint32
+			B bool
+		}
+		actual, err := abiT.MarshalToJSON([]pair{{1, true}, {2, false}})
+		require.NoError(t, err)
+		require.Equal(t, `[[1,true],[2,false]]`, string(actual))
+	})
+}
+
+func TestMarshalToJSONIndent(t *testing.T) {
+	t.Parallel()
+
+	uint64Type, err := TypeOf("uint64")
+	require.NoError(t, err)
+	byteArrayType, err := TypeOf("byte[]")
+	require.NoError(t, err)
+	listType, err := TypeOf("bool[]")
+	require.NoError(t, err)
+	abiT, err := MakeNamedTupleType([]Type{uint64Type, byteArrayType, listType}, []string{"b", "a", "c"})
+	require.NoError(t, err)
+
+	value := map[string]interface{}{"a": []byte{0, 1, 2}, "b": uint64(1), "c": []bool{true, false}}
+	expected := `{
+  "b": 1,
+  "a": "AAEC",
+  "c": [
+    true,
+    false
+  ]
+}`
+	for i := 0; i < 10; i++ {
+		actual, err := abiT.MarshalToJSONWithOptions(value, JSONOptions{Indent: "  "})
+		require.NoError(t, err)
+		require.Equal(t, expected, string(actual))
+	}
+
+	decoded, err := abiT.UnmarshalFromJSON([]byte(expected))
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{uint64(1), []interface{}{byte(0), byte(1), byte(2)}, []interface{}{true, false}}, decoded)
+}
+
+func TestUnmarshalStringFromByteArray(t *testing.T) {
+	t.Parallel()
+
+	abiT, err := TypeOf("(string,string)")
+	require.NoError(t, err)
+
+	input := []byte(`[[65, 66, 67], []]`)
+	_, err = abiT.UnmarshalFromJSON(input)
+	require.ErrorContains(t, err, "consider the byte[] type instead")
+
+	decoded, err := abiT.UnmarshalFromJSONWithOptions(input, JSONOptions{StringFromByteArray: true})
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"ABC", ""}, decoded)
+
+	_, err = abiT.UnmarshalFromJSONWithOptions([]byte(`[[256], []]`), JSONOptions{StringFromByteArray: true})
+	require.Error(t, err)
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	t.Parallel()
+
+	uint64Type, err := TypeOf("uint64")
+	require.NoError(t, err)
+	namedType, err := MakeNamedTupleType([]Type{uint64Type, stringType}, []string{"id", "name"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		abiType  Type
+		inputs   []interface{}
+		expected string
+	}{
+		{
+			abiType:  uint64Type,
+			inputs:   []interface{}{uint64(5), 5, uint8(5), big.NewInt(5)},
+			expected: `5`,
+		},
+		{
+			abiType:  mustTypeOf(t, "ufixed64x4"),
+			inputs:   []interface{}{uint64(15000), 15000},
+			expected: `1.5000`,
+		},
+		{
+			abiType:  mustTypeOf(t, "byte[3]"),
+			inputs:   []interface{}{[]byte{0, 1, 2}, [3]byte{0, 1, 2}, []interface{}{byte(0), byte(1), byte(2)}},
+			expected: `"AAEC"`,
+		},
+		{
+			abiType:  mustTypeOf(t, "(bool[2],address)"),
+			inputs:   []interface{}{[]interface{}{[]bool{true, false}, make([]byte, 32)}, []interface{}{[2]bool{true, false}, [32]byte{}}},
+			expected: `[[true,false],"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAY5HFKQ"]`,
+		},
+		{
+			abiType:  namedType,
+			inputs:   []interface{}{map[string]interface{}{"name": "<a&b>", "id": 1}, []interface{}{uint64(1), "<a&b>"}},
+			expected: `{"id":1,"name":"\u003ca\u0026b\u003e"}`,
+		},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(fmt.Sprintf("i=%d", i), func(t *testing.T) {
+			for _, input := range testCase.inputs {
+				canonical, err := testCase.abiType.CanonicalJSON(input)
+				require.NoError(t, err)
+				require.Equal(t, testCase.expected, string(canonical))
+
+				encoded, err := testCase.abiType.Encode(input)
+				require.NoError(t, err)
+				normalized, err := testCase.abiType.Decode(encoded)
+				require.NoError(t, err)
+				decoded, err := testCase.abiType.UnmarshalFromJSON(canonical)
+				require.NoError(t, err)
+				require.Equal(t, normalized, decoded)
+			}
+		})
+	}
+
+	_, err = stringType.CanonicalJSON("\xff")
+	require.ErrorContains(t, err, "does not round-trip")
+}
+
+func mustTypeOf(t *testing.T, typeStr string) Type {
+	abiT, err := TypeOf(typeStr)
+	require.NoError(t, err)
+	return abiT
+}
+
+func TestUnmarshalZeroFillMissing(t *testing.T) {
+	t.Parallel()
+
+	abiT, err := TypeOf("(uint64,string,bool[2],byte[],uint256,address,(byte,ufixed64x2))")
+	require.NoError(t, err)
+
+	zeros := []interface{}{
+		uint64(0),
+		"",
+		[]interface{}{false, false},
+		[]interface{}{},
+		new(big.Int).SetBytes(make([]byte, 32)), // the same representation Decode produces
+		make([]byte, 32),
+		[]interface{}{byte(0), uint64(0)},
+	}
+	opts := JSONOptions{ZeroFillMissing: true}
+
+	decoded, err := abiT.UnmarshalFromJSONWithOptions([]byte(`[]`), opts)
+	require.NoError(t, err)
+	require.Equal(t, zeros, decoded)
+
+	// the zero values must be encodable
+	_, err = abiT.Encode(decoded)
+	require.NoError(t, err)
+
+	decoded, err = abiT.UnmarshalFromJSONWithOptions([]byte(`[7,"x"]`), opts)
+	require.NoError(t, err)
+	expected := append([]interface{}{uint64(7), "x"}, zeros[2:]...)
+	require.Equal(t, expected, decoded)
+
+	_, err = abiT.UnmarshalFromJSON([]byte(`[7,"x"]`))
+	require.ErrorContains(t, err, "JSON array element number != ABI tuple elem number")
+
+	uint64Type, err := TypeOf("uint64")
+	require.NoError(t, err)
+	namedType, err := MakeNamedTupleType([]Type{uint64Type, stringType, boolType}, []string{"a", "b", "c"})
+	require.NoError(t, err)
+
+	decoded, err = namedType.UnmarshalFromJSONWithOptions([]byte(`{"b":"set"}`), opts)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{uint64(0), "set", false}, decoded)
+
+	_, err = namedType.UnmarshalFromJSONWithOptions([]byte(`{"b":"set","d":1}`), opts)
+	require.ErrorContains(t, err, `unknown field "d"`)
+}
+
+func TestByteArraysAsNumbers(t *testing.T) {
+	t.Parallel()
+
+	abiT, err := TypeOf("(byte[],byte[3],byte[32],string)")
+	require.NoError(t, err)
+
+	addrValue := make([]interface{}, 32)
+	for i := range addrValue {
+		addrValue[i] = byte(i)
+	}
+	value := []interface{}{
+		[]interface{}{byte(1), byte(2)},
+		[]interface{}{byte(0), byte(255), byte(7)},
+		addrValue,
+		"AB",
+	}
+
+	opts := JSONOptions{ByteArraysAsNumbers: true}
+	actual, err := abiT.MarshalToJSONWithOptions(value, opts)
+	require.NoError(t, err)
+	require.Equal(t, `[[1,2],[0,255,7],[0,1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20,21,22,23,24,25,26,27,28,29,30,31],"AB"]`, string(actual))
+
+	decoded, err := abiT.UnmarshalFromJSONWithOptions(actual, opts)
+	require.NoError(t, err)
+	require.Equal(t, value, decoded)
+
+	// base64 input is still accepted
+	decoded, err = abiT.UnmarshalFromJSONWithOptions([]byte(`["AQI=","AP8H","AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=","AB"]`), opts)
+	require.NoError(t, err)
+	require.Equal(t, value, decoded)
+
+	// Byte32AsAddress takes precedence for byte[32]
+	opts.Byte32AsAddress = true
+	actual, err = abiT.MarshalToJSONWithOptions(value, opts)
+	require.NoError(t, err)
+	require.Equal(t, `[[1,2],[0,255,7],"AAAQEAYEAUDAOCAJBIFQYDIOB4IBCEQTCQKRMFYYDENBWHA5DYP7MUPJQE","AB"]`, string(actual))
+}
+
+func TestByte32AsAddress(t *testing.T) {
+	t.Parallel()
+
+	abiT, err := TypeOf("(byte[32],byte[31],byte[32][])")
+	require.NoError(t, err)
+
+	addrBytes := []byte{16, 10, 81, 202, 158, 158, 46, 209, 139, 213, 244, 123, 112, 56, 225, 176, 71, 198, 31, 126, 155, 105, 97, 91, 131, 241, 213, 95, 145, 71, 126, 247}
+	addrValue := make([]interface{}, len(addrBytes))
+	for i, b := range addrBytes {
+		addrValue[i] = b
+	}
+	shortValue := make([]interface{}, 31)
+	for i := range shortValue {
+		shortValue[i] = byte(0)
+	}
+	value := []interface{}{addrValue, shortValue, []interface{}{addrValue}}
+
+	opts := JSONOptions{Byte32AsAddress: true}
+	const addr = "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM"
+	expected := `["` + addr + `","AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA==",["` + addr + `"]]`
+
+	actual, err := abiT.MarshalToJSONWithOptions(value, opts)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(actual))
+
+	decoded, err := abiT.UnmarshalFromJSONWithOptions(actual, opts)
+	require.NoError(t, err)
+	require.Equal(t, value, decoded)
+
+	// base64 input is still accepted
+	plain, err := abiT.MarshalToJSON(value)
+	require.NoError(t, err)
+	decoded, err = abiT.UnmarshalFromJSONWithOptions(plain, opts)
+	require.NoError(t, err)
+	require.Equal(t, value, decoded)
+
+	// without the option, address strings are not valid byte[32] values
+	_, err = abiT.UnmarshalFromJSON(actual)
+	require.ErrorContains(t, err, "to bytes")
+
+	byte32Type, err := TypeOf("byte[32]")
+	require.NoError(t, err)
+	_, err = byte32Type.UnmarshalFromJSONWithOptions([]byte(`"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAQM"`), opts)
+	require.ErrorContains(t, err, "checksum mismatch")
+}
+
+func TestUnmarshalLimits(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		typeStr string
+		input   string
+		opts    JSONOptions
+		err     error
+	}{
+		{typeStr: "uint8[]", input: `[1,2,3]`, opts: JSONOptions{MaxArrayLength: 3}},
+		{typeStr: "uint8[]", input: `[1,2,3,4]`, opts: JSONOptions{MaxArrayLength: 3}, err: ErrArrayTooLong},
+		{typeStr: "uint8[4]", input: `[1,2,3,4]`, opts: JSONOptions{MaxArrayLength: 3}, err: ErrArrayTooLong},
+		{typeStr: "byte[]", input: `"AQID"`, opts: JSONOptions{MaxArrayLength: 3}},
+		{typeStr: "byte[]", input: `"AQIDBA=="`, opts: JSONOptions{MaxArrayLength: 3}, err: ErrArrayTooLong},
+		{typeStr: "(bool,uint8[])", input: `[true,[1,2,3,4]]`, opts: JSONOptions{MaxArrayLength: 3}, err: ErrArrayTooLong},
+		{typeStr: "string", input: `"abc"`, opts: JSONOptions{MaxStringLength: 3}},
+		{typeStr: "string", input: `"abcd"`, opts: JSONOptions{MaxStringLength: 3}, err: ErrStringTooLong},
+		{typeStr: "string", input: `"\u00e9\u00e9"`, opts: JSONOptions{MaxStringLength: 3}, err: ErrStringTooLong},
+		{typeStr: "string", input: `"\u0061\u0062\u0063"`, opts: JSONOptions{MaxStringLength: 3}},
+		{typeStr: "string", input: `[1,2,3,4]`, opts: JSONOptions{MaxStringLength: 3, StringFromByteArray: true}, err: ErrStringTooLong},
+		{typeStr: "string[]", input: `["a","abcd"]`, opts: JSONOptions{MaxStringLength: 3}, err: ErrStringTooLong},
+		{typeStr: "uint8[][]", input: `[[1],[2]]`, opts: JSONOptions{MaxDepth: 2}},
+		{typeStr: "uint8[][][]", input: `[[[1]]]`, opts: JSONOptions{MaxDepth: 2}, err: ErrTooDeep},
+		{typeStr: "(uint8,(bool,(bool)))", input: `[1,[true,[false]]]`, opts: JSONOptions{MaxDepth: 2}, err: ErrTooDeep},
+		{typeStr: "uint8[][][]", input: `[]`, opts: JSONOptions{MaxDepth: 1}},
+		{typeStr: "byte[][]", input: `["AQID"]`, opts: JSONOptions{MaxDepth: 1}},
+		{typeStr: "string[]", input: `[[1]]`, opts: JSONOptions{MaxDepth: 1, StringFromByteArray: true}, err: ErrTooDeep},
+		{typeStr: "uint8[]", input: ` [1,2,3] `, opts: JSONOptions{MaxInputSize: 9}},
+		{typeStr: "uint8[]", input: ` [1,2,3] `, opts: JSONOptions{MaxInputSize: 8}, err: ErrInputTooLarge},
+		{typeStr: "string", input: `"abcd"`, opts: JSONOptions{MaxInputSize: 4}, err: ErrInputTooLarge},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(fmt.Sprintf("i=%d", i), func(t *testing.T) {
+			t.Parallel()
+			abiT, err := TypeOf(testCase.typeStr)
+			require.NoError(t, err)
+			_, err = abiT.UnmarshalFromJSONWithOptions([]byte(testCase.input), testCase.opts)
+			if testCase.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, testCase.err)
+		})
+	}
+
+	t.Run("named tuple", func(t *testing.T) {
+		t.Parallel()
+		innerType := mustTypeOf(t, "(uint8)")
+		abiT, err := MakeNamedTupleType([]Type{innerType}, []string{"inner"})
+		require.NoError(t, err)
+		_, err = abiT.UnmarshalFromJSONWithOptions([]byte(`{"inner":[1]}`), JSONOptions{MaxDepth: 1})
+		require.ErrorIs(t, err, ErrTooDeep)
+	})
+
+	t.Run("method arguments", func(t *testing.T) {
+		t.Parallel()
+		_, err := UnmarshalMethodArgsFromJSON("f(uint8[])void", nil, []byte(`{"arg0":[1]}`), JSONOptions{MaxDepth: 1})
+		require.ErrorIs(t, err, ErrTooDeep)
+		_, err = UnmarshalMethodArgsFromJSON("f(uint8[])void", nil, []byte(`{"arg0":[1]}`), JSONOptions{MaxInputSize: 11})
+		require.ErrorIs(t, err, ErrInputTooLarge)
+	})
+
+	t.Run("nested scalar", func(t *testing.T) {
+		t.Parallel()
+		// the nested arrays are rejected when the first one is opened, before the rest is read
+		input := io.MultiReader(strings.NewReader(`[1,`), repeatReader('['))
+		_, err := mustTypeOf(t, "(uint8,uint8)").UnmarshalFromJSONReader(input, JSONOptions{})
+		require.EqualError(t, err, "cannot cast JSON encoded ([) to uint8: unexpected JSON array or object")
+	})
+
+	// the endless inputs are rejected as soon as a limit is crossed
+	endlessCases := []struct {
+		typeStr string
+		input   io.Reader
+		opts    JSONOptions
+		err     error
+	}{
+		{typeStr: "string", input: io.MultiReader(strings.NewReader(`"`), repeatReader('a')), opts: JSONOptions{MaxStringLength: 3}, err: ErrStringTooLong},
+		{typeStr: "string", input: io.MultiReader(strings.NewReader(`[`), repeatReader(' ')), opts: JSONOptions{MaxInputSize: 1 << 16, StringFromByteArray: true}, err: ErrInputTooLarge},
+		{typeStr: "byte[]", input: io.MultiReader(strings.NewReader(`"`), repeatReader('A')), opts: JSONOptions{MaxArrayLength: 3}, err: ErrArrayTooLong},
+		{typeStr: "uint64[]", input: io.MultiReader(strings.NewReader(`[`), repeatReader(' ')), opts: JSONOptions{MaxInputSize: 1 << 16}, err: ErrInputTooLarge},
+	}
+	for i, endlessCase := range endlessCases {
+		t.Run(fmt.Sprintf("endless i=%d", i), func(t *testing.T) {
+			t.Parallel()
+			_, err := mustTypeOf(t, endlessCase.typeStr).UnmarshalFromJSONReader(endlessCase.input, endlessCase.opts)
+			require.ErrorIs(t, err, endlessCase.err)
+		})
+	}
+}
+
+// repeatReader endlessly reads the same byte.
+type repeatReader byte
+
+func (r repeatReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,117 @@
+package abi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GenerateMarkdown renders reference documentation for the contract as Markdown. Each method is
+// documented with its signature, selector, description, argument table, return type, read-only
+// flag, and events. The contract's networks and events are listed after the methods.
+func (c Contract) GenerateMarkdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n", c.Name)
+	if c.Desc != "" {
+		fmt.Fprintf(&b, "\n%s\n", c.Desc)
+	}
+
+	if len(c.Methods) > 0 {
+		b.WriteString("\n## Methods\n")
+	}
+	for _, method := range c.Methods {
+		writeMethodMarkdown(&b, method)
+	}
+
+	if len(c.Events) > 0 {
+		b.WriteString("\n## Events\n")
+		for _, event := range c.Events {
+			writeEventMarkdown(&b, event)
+		}
+	}
+
+	if len(c.Networks) > 0 {
+		genesisHashes := make([]string, 0, len(c.Networks))
+		for genesisHash := range c.Networks {
+			genesisHashes = append(genesisHashes, genesisHash)
+		}
+		sort.Strings(genesisHashes)
+
+		b.WriteString("\n## Networks\n\n")
+		b.WriteString("| Genesis hash | App ID |\n")
+		b.WriteString("| --- | --- |\n")
+		for _, genesisHash := range genesisHashes {
+			fmt.Fprintf(&b, "| `%s` | %d |\n", genesisHash, c.Networks[genesisHash].AppID)
+		}
+	}
+
+	return b.String()
+}
+
+func writeMethodMarkdown(b *strings.Builder, method Method) {
+	fmt.Fprintf(b, "\n### %s\n\n", method.Name)
+	fmt.Fprintf(b, "`%s`\n\n", method.GetSignature())
+	fmt.Fprintf(b, "Selector: `%s`\n", method.GetSelector().Hex())
+	if method.ReadOnly {
+		b.WriteString("\nRead-only: this method does not modify state.\n")
+	}
+	if method.Desc != "" {
+		fmt.Fprintf(b, "\n%s\n", method.Desc)
+	}
+
+	if len(method.Args) > 0 {
+		b.WriteString("\n| Argument | Type | Description |\n")
+		b.WriteString("| --- | --- | --- |\n")
+		names := method.ArgNames()
+		for i, arg := range method.Args {
+			fmt.Fprintf(b, "| %s | `%s` | %s |\n", markdownCell(names[i]), canonicalArgType(arg.Type), markdownCell(arg.Desc))
+		}
+	}
+
+	fmt.Fprintf(b, "\nReturns: `%s`", canonicalArgType(method.Returns.Type))
+	if method.Returns.Desc != "" {
+		fmt.Fprintf(b, " - %s", method.Returns.Desc)
+	}
+	b.WriteString("\n")
+
+	if len(method.Events) > 0 {
+		b.WriteString("\nEvents:\n\n")
+		for _, event := range method.Events {
+			fmt.Fprintf(b, "- `%s`", event.GetSignature())
+			if event.Desc != "" {
+				fmt.Fprintf(b, " - %s", event.Desc)
+			}
+			b.WriteString("\n")
+		}
+	}
+}
+
+func writeEventMarkdown(b *strings.Builder, event Event) {
+	fmt.Fprintf(b, "\n### %s\n\n", event.Name)
+	fmt.Fprintf(b, "`%s`\n\n", event.GetSignature())
+	fmt.Fprintf(b, "Selector: `%s`\n", event.GetSelector().Hex())
+	if event.Desc != "" {
+		fmt.Fprintf(b, "\n%s\n", event.Desc)
+	}
+
+	if len(event.Args) > 0 {
+		b.WriteString("\n| Argument | Type | Description |\n")
+		b.WriteString("| --- | --- | --- |\n")
+		for i, arg := range event.Args {
+			name := arg.Name
+			if name == "" {
+				name = fmt.Sprintf("arg%d", i)
+			}
+			fmt.Fprintf(b, "| %s | `%s` | %s |\n", markdownCell(name), canonicalArgType(arg.Type), markdownCell(arg.Desc))
+		}
+	}
+}
+
+// markdownCell escapes text for use in a Markdown table cell, which cannot contain pipes or line
+// breaks.
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	text = strings.ReplaceAll(text, "\r\n", "<br>")
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
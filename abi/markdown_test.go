@@ -0,0 +1,116 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractGenerateMarkdown(t *testing.T) {
+	t.Parallel()
+
+	contract := Contract{
+		Name: "Calculator",
+		Desc: "Simple arithmetic",
+		Networks: map[string]ContractNetworkInfo{
+			"wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8=": {AppID: 1234},
+			"SGO1GKSzyE7IEPItTxCByw9x8FmnrCDexi9/cOUJOiI=": {AppID: 5678},
+		},
+		Methods: []Method{
+			{
+				Name: "add",
+				Desc: "Add two numbers",
+				Args: []MethodArg{
+					{Name: "a", Type: "uint64", Desc: "The first | number"},
+					{Type: "uint64", Desc: "The second\nnumber"},
+				},
+				Returns:  MethodReturn{Type: "uint128", Desc: "The sum"},
+				ReadOnly: true,
+			},
+			{
+				Name:    "reset",
+				Returns: MethodReturn{Type: "void"},
+				Events:  []Event{{Name: "Reset", Desc: "Emitted on reset", Args: []EventArg{{Type: "uint64"}}}},
+			},
+		},
+		Events: []Event{{Name: "Reset", Args: []EventArg{{Name: "count", Type: "uint64"}}}},
+	}
+
+	expected := "# Calculator\n" +
+		"\n" +
+		"Simple arithmetic\n" +
+		"\n" +
+		"## Methods\n" +
+		"\n" +
+		"### add\n" +
+		"\n" +
+		"`add(uint64,uint64)uint128`\n" +
+		"\n" +
+		"Selector: `0x8aa3b61f`\n" +
+		"\n" +
+		"Read-only: this method does not modify state.\n" +
+		"\n" +
+		"Add two numbers\n" +
+		"\n" +
+		"| Argument | Type | Description |\n" +
+		"| --- | --- | --- |\n" +
+		"| a | `uint64` | The first \\| number |\n" +
+		"| arg1 | `uint64` | The second<br>number |\n" +
+		"\n" +
+		"Returns: `uint128` - The sum\n" +
+		"\n" +
+		"### reset\n" +
+		"\n" +
+		"`reset()void`\n" +
+		"\n" +
+		"Selector: `0x19c02cb3`\n" +
+		"\n" +
+		"Returns: `void`\n" +
+		"\n" +
+		"Events:\n" +
+		"\n" +
+		"- `Reset(uint64)` - Emitted on reset\n" +
+		"\n" +
+		"## Events\n" +
+		"\n" +
+		"### Reset\n" +
+		"\n" +
+		"`Reset(uint64)`\n" +
+		"\n" +
+		"Selector: `0x24ada9ba`\n" +
+		"\n" +
+		"| Argument | Type | Description |\n" +
+		"| --- | --- | --- |\n" +
+		"| count | `uint64` |  |\n" +
+		"\n" +
+		"## Networks\n" +
+		"\n" +
+		"| Genesis hash | App ID |\n" +
+		"| --- | --- |\n" +
+		"| `SGO1GKSzyE7IEPItTxCByw9x8FmnrCDexi9/cOUJOiI=` | 5678 |\n" +
+		"| `wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8=` | 1234 |\n"
+	require.Equal(t, expected, contract.GenerateMarkdown())
+
+	require.Equal(t, "# Empty\n", Contract{Name: "Empty"}.GenerateMarkdown())
+}
+
+func TestContractGenerateMarkdownCanonicalTypes(t *testing.T) {
+	t.Parallel()
+
+	contract := Contract{
+		Name: "Vault",
+		Methods: []Method{{
+			Name:    "deposit",
+			Args:    []MethodArg{{Name: "payment", Type: "(address owner,uint64 amount)"}, {Type: "pay"}},
+			Returns: MethodReturn{Type: "(bool ok,uint64 balance)"},
+		}},
+		Events: []Event{{Name: "Deposit", Args: []EventArg{{Type: "(address owner,uint64 amount)[]"}}}},
+	}
+	markdown := contract.GenerateMarkdown()
+	require.Contains(t, markdown, "Selector: `"+contract.Methods[0].GetSelector().Hex()+"`\n")
+	require.Contains(t, markdown, "| payment | `(address,uint64)` |  |\n")
+	require.Contains(t, markdown, "| arg1 | `pay` |  |\n")
+	require.Contains(t, markdown, "Returns: `(bool,uint64)`\n")
+	require.Contains(t, markdown, "| arg0 | `(address,uint64)[]` |  |\n")
+	require.NotContains(t, markdown, "owner")
+}
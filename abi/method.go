@@ -0,0 +1,274 @@
+package abi
+
+import (
+	"crypto/sha512"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// MethodArg is an argument of an ARC-4 method.
+type MethodArg struct {
+	// Name is the name of the argument, which is optional.
+	Name string `json:"name,omitempty"`
+	// Type is the type of the argument: an ABI type string, a transaction type such as "pay", or a
+	// reference type such as "account".
+	Type string `json:"type"`
+	// Desc is an optional description of the argument.
+	Desc string `json:"desc,omitempty"`
+	// StructType is an optional named tuple type of the argument, such as an ARC-56 struct, which
+	// must have the same positional type as Type. It is used instead of Type when the argument is
+	// parsed, so its values are converted to and from JSON objects keyed by field name. It is not
+	// part of ARC-4 descriptions, so it is not encoded in JSON.
+	StructType *Type `json:"-"`
+}
+
+// MethodReturn is the return value of an ARC-4 method.
+type MethodReturn struct {
+	// Type is the type of the return value: an ABI type string, or "void" if the method does not
+	// return a value.
+	Type string `json:"type"`
+	// Desc is an optional description of the return value.
+	Desc string `json:"desc,omitempty"`
+	// StructType is an optional named tuple type of the return value, as in `MethodArg`.
+	StructType *Type `json:"-"`
+}
+
+// Method is an ARC-4 method, as described in the method descriptions of the ARC-4 JSON interface
+// and contract formats.
+type Method struct {
+	// Name is the name of the method.
+	Name string `json:"name"`
+	// Desc is an optional description of the method.
+	Desc string `json:"desc,omitempty"`
+	// Args are the arguments of the method, in order.
+	Args []MethodArg `json:"args"`
+	// Returns is the return value of the method.
+	Returns MethodReturn `json:"returns"`
+	// ReadOnly is the ARC-22 flag marking methods which do not modify state, so they can be
+	// called with simulate or dryrun instead of being submitted.
+	ReadOnly bool `json:"readonly,omitempty"`
+	// Events are the ARC-28 events the method may emit.
+	Events []Event `json:"events,omitempty"`
+}
+
+// MethodFromSignature parses a method signature, such as "add(uint64,uint64)uint128", into a
+// Method without names or descriptions. Argument and return types are checked and stored in their
+// canonical form, and insignificant whitespace is accepted as in `ParseMethodSignature`. If some
+// types are invalid, the returned error is a *MethodSignatureError listing all of them.
+func MethodFromSignature(methodSig string) (Method, error) {
+	info, err := AnalyzeMethodSignature(methodSig)
+	if err != nil {
+		return Method{}, err
+	}
+	if strings.IndexFunc(info.Name, unicode.IsSpace) != -1 {
+		return Method{}, fmt.Errorf(`Method name contains whitespace: "%s"`, info.Name)
+	}
+	method := Method{
+		Name:    info.Name,
+		Args:    make([]MethodArg, len(info.ArgTypes)),
+		Returns: MethodReturn{Type: canonicalArgType(info.ReturnType)},
+	}
+	for i, argType := range info.ArgTypes {
+		method.Args[i] = MethodArg{Type: canonicalArgType(argType)}
+	}
+	return method, nil
+}
+
+// ArgNames returns the name of each argument of the method, in order. Unnamed arguments are named
+// "arg<index>", as in `MarshalMethodArgsToJSON`.
+func (m Method) ArgNames() []string {
+	names := make([]string, len(m.Args))
+	for i, arg := range m.Args {
+		names[i] = arg.Name
+		if names[i] == "" {
+			names[i] = fmt.Sprintf("arg%d", i)
+		}
+	}
+	return names
+}
+
+// ArgDescriptions returns the description of each argument of the method, in order, with an empty
+// string for arguments without a description.
+func (m Method) ArgDescriptions() []string {
+	descriptions := make([]string, len(m.Args))
+	for i, arg := range m.Args {
+		descriptions[i] = arg.Desc
+	}
+	return descriptions
+}
+
+// Kind returns the kind of the argument's type.
+func (a MethodArg) Kind() ArgKind {
+	return ArgKindOf(a.Type)
+}
+
+// Parse parses the argument's type. If StructType is set, it is the type of the parsed argument.
+func (a MethodArg) Parse() (Arg, error) {
+	if a.StructType == nil {
+		return ParseArg(a.Type)
+	}
+	abiType, err := resolveStructType(a.Type, a.StructType)
+	if err != nil {
+		return Arg{}, err
+	}
+	return Arg{kind: ValueArg, abiType: abiType}, nil
+}
+
+// Parse parses the type of a non-void return value. If StructType is set, it is the returned type.
+func (r MethodReturn) Parse() (Type, error) {
+	if r.Type == VoidReturnType {
+		return Type{}, fmt.Errorf("void return value has no ABI type")
+	}
+	return resolveStructType(r.Type, r.StructType)
+}
+
+// resolveStructType returns the type of typeStr, or structType if it is not nil, which must have
+// the same positional type.
+func resolveStructType(typeStr string, structType *Type) (Type, error) {
+	abiType, err := TypeOf(typeStr)
+	if err != nil {
+		return Type{}, err
+	}
+	if structType == nil {
+		return abiType, nil
+	}
+	if structType.String() != abiType.String() {
+		return Type{}, fmt.Errorf("struct type %s does not match type %s", structType, abiType)
+	}
+	return *structType, nil
+}
+
+// ParsedArgs parses the type of each argument of the method, in order.
+func (m Method) ParsedArgs() ([]Arg, error) {
+	args := make([]Arg, len(m.Args))
+	for i, arg := range m.Args {
+		parsed, err := arg.Parse()
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing argument type at index %d of method %s: %w", i, m.Name, err)
+		}
+		args[i] = parsed
+	}
+	return args, nil
+}
+
+// ArgKinds returns the kind of each argument of the method, in order.
+func (m Method) ArgKinds() []ArgKind {
+	kinds := make([]ArgKind, len(m.Args))
+	for i, arg := range m.Args {
+		kinds[i] = arg.Kind()
+	}
+	return kinds
+}
+
+// TxnArgCount returns the number of transaction arguments of the method, which is the number of
+// transactions that must immediately precede a call to it in an atomic group.
+func (m Method) TxnArgCount() int {
+	return m.countArgs(TransactionArg)
+}
+
+// RefArgCount returns the number of reference arguments of the method. Each reference argument
+// may need a slot in the call's foreign accounts, assets, or applications array.
+func (m Method) RefArgCount() int {
+	return m.countArgs(ReferenceArg)
+}
+
+func (m Method) countArgs(kind ArgKind) int {
+	count := 0
+	for _, arg := range m.Args {
+		if arg.Kind() == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// MarshalJSON encodes the method as an ARC-4 method description. Fields are in a fixed order and
+// argument and return types are rendered in their canonical form, so equivalent methods are
+// encoded identically. Events keep the order they were declared in.
+func (m Method) MarshalJSON() ([]byte, error) {
+	// methodJSON has the same fields as Method, without its MarshalJSON method
+	type methodJSON Method
+	canonical := methodJSON(m)
+	canonical.Args = make([]MethodArg, len(m.Args))
+	for i, arg := range m.Args {
+		canonical.Args[i] = arg
+		canonical.Args[i].Type = canonicalArgType(arg.Type)
+	}
+	canonical.Returns.Type = canonicalArgType(m.Returns.Type)
+	return json.Marshal(canonical)
+}
+
+// MethodSelectorLength is the length in bytes of a method selector.
+const MethodSelectorLength = 4
+
+// canonicalArgType returns the canonical form of an argument or return type string, or the type
+// string unchanged if it is not an ABI type.
+func canonicalArgType(typeStr string) string {
+	if IsReferenceType(typeStr) || IsTransactionType(typeStr) || typeStr == VoidReturnType {
+		return typeStr
+	}
+	abiType, err := TypeOf(typeStr)
+	if err != nil {
+		return typeStr
+	}
+	return abiType.String()
+}
+
+// GetSignature returns the canonical signature of the method, of format
+// `name(argType1,argType2,...)retType`. ABI types are rendered in their canonical form as returned
+// by `Type.String`. All arguments are listed individually, even if the method has more than 15
+// arguments and the trailing ones are packed into a tuple when the method is called.
+//
+// If the method has a name without parentheses or commas, and valid argument and return types,
+// `ParseMethodSignature` of the result returns its name and canonical types.
+func (m Method) GetSignature() string {
+	var builder strings.Builder
+	builder.WriteString(m.Name)
+	builder.WriteByte('(')
+	for i, arg := range m.Args {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteString(canonicalArgType(arg.Type))
+	}
+	builder.WriteByte(')')
+	builder.WriteString(canonicalArgType(m.Returns.Type))
+	return builder.String()
+}
+
+// GetSelector returns the selector of the method, which is the first 4 bytes of the SHA-512/256
+// hash of the method signature returned by `GetSignature`. The selector is used as the first
+// application argument of a method call. Services which look up many calls by selector should
+// compute the selectors once with `Contract.NewMethodIndex`.
+func (m Method) GetSelector() Selector {
+	return computeSelector(m.GetSignature())
+}
+
+// computeSelector returns the first 4 bytes of the SHA-512/256 hash of signature.
+func computeSelector(signature string) Selector {
+	hash := sha512.Sum512_256([]byte(signature))
+	return Selector(hash[:MethodSelectorLength])
+}
+
+// verifyTypes checks that the method has a name and that its argument and return types are valid.
+func (m Method) verifyTypes() error {
+	if m.Name == "" {
+		return fmt.Errorf("method has no name")
+	}
+	if _, err := m.ParsedArgs(); err != nil {
+		return err
+	}
+	if m.Returns.Type != VoidReturnType {
+		if _, err := m.Returns.Parse(); err != nil {
+			return fmt.Errorf("Error parsing return type of method %s: %w", m.Name, err)
+		}
+	}
+	for i, event := range m.Events {
+		if err := event.verifyTypes(); err != nil {
+			return fmt.Errorf("invalid event at index %d of method %s: %w", i, m.Name, err)
+		}
+	}
+	return nil
+}
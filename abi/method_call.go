@@ -0,0 +1,324 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/algorand/avm-abi/address"
+)
+
+// maxMethodCallArgs is the number of application arguments available to a method call's
+// arguments, after the selector. Arguments beyond this limit are packed into a tuple in the last
+// application argument.
+const maxMethodCallArgs = 15
+
+// methodReturnPrefix is the prefix of the log containing the encoded return value of a method call.
+var methodReturnPrefix = [4]byte{0x15, 0x1f, 0x7c, 0x75}
+
+// MethodReturnPrefix returns the prefix of the log containing the encoded return value of a method
+// call. The returned slice is a copy, which the caller may modify.
+func MethodReturnPrefix() []byte {
+	prefix := methodReturnPrefix
+	return prefix[:]
+}
+
+// MethodCallArgs holds the application call fields produced by `BuildMethodCallArgs`.
+type MethodCallArgs struct {
+	// ApplicationArgs are the application arguments of the call, starting with the method selector.
+	ApplicationArgs [][]byte
+	// Accounts is the foreign accounts array of the call.
+	Accounts [][address.BytesSize]byte
+	// ForeignAssets is the foreign assets array of the call.
+	ForeignAssets []uint64
+	// ForeignApps is the foreign applications array of the call.
+	ForeignApps []uint64
+	// ReferenceIndexes maps the index of each reference argument of the method to the uint8 value
+	// substituted for it, which indexes into the corresponding foreign array.
+	ReferenceIndexes map[int]uint8
+}
+
+// BuildMethodCallArgs encodes the arguments of a call to method, made by sender to the application
+// appID, following the ARC-4 encoding rules.
+//
+// The args slice must have one value for every argument of the method. Values of transaction
+// arguments are ignored, since those arguments are passed as preceding transactions in the group.
+// Values of ABI type arguments are encoded with `Type.Encode`.
+//
+// Reference arguments are substituted with their index in the corresponding foreign array, which
+// is extended as needed. Account values may be a [32]byte, a 32 byte []byte, or an address string;
+// the sender is referenced by index 0 and other accounts start at index 1. Asset and application
+// values are unsigned integer IDs; appID is referenced by index 0 and other applications start at
+// index 1.
+//
+// If the method has more than 15 non-transaction arguments, the 15th and later ones are encoded
+// together as a tuple in the last application argument.
+func BuildMethodCallArgs(method Method, sender [address.BytesSize]byte, appID uint64, args []interface{}) (*MethodCallArgs, error) {
+	if len(args) != len(method.Args) {
+		return nil, fmt.Errorf("method %s expects %d arguments, got %d", method.Name, len(method.Args), len(args))
+	}
+
+	parsedArgs, err := method.ParsedArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	selector := method.GetSelector()
+	result := &MethodCallArgs{
+		ApplicationArgs:  [][]byte{selector[:]},
+		ReferenceIndexes: make(map[int]uint8),
+	}
+
+	var argTypes []Type
+	var argValues []interface{}
+	for i, arg := range parsedArgs {
+		encodedType, ok := arg.EncodedType()
+		if !ok {
+			continue
+		}
+		value := args[i]
+		if arg.Kind() == ReferenceArg {
+			index, err := result.addReference(arg, value, sender, appID)
+			if err != nil {
+				return nil, fmt.Errorf("cannot substitute argument %d of method %s: %w", i, method.Name, err)
+			}
+			result.ReferenceIndexes[i] = index
+			value = index
+		}
+		argTypes = append(argTypes, encodedType)
+		argValues = append(argValues, value)
+	}
+
+	if len(argTypes) > maxMethodCallArgs {
+		// copy the packed arguments, since the slices are truncated in place below
+		packedTypes := append([]Type(nil), argTypes[maxMethodCallArgs-1:]...)
+		packedValues := append([]interface{}(nil), argValues[maxMethodCallArgs-1:]...)
+		tupleType, err := MakeTupleType(packedTypes)
+		if err != nil {
+			return nil, err
+		}
+		argTypes = append(argTypes[:maxMethodCallArgs-1], tupleType)
+		argValues = append(argValues[:maxMethodCallArgs-1], packedValues)
+	}
+
+	for i, argType := range argTypes {
+		encoded, err := argType.Encode(argValues[i])
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode argument of type %s for method %s: %w", argType, method.Name, err)
+		}
+		result.ApplicationArgs = append(result.ApplicationArgs, encoded)
+	}
+
+	return result, nil
+}
+
+// addReference returns the foreign array index of the value of reference argument arg, adding the
+// value to the corresponding array if it is not already present.
+func (r *MethodCallArgs) addReference(arg Arg, value interface{}, sender [address.BytesSize]byte, appID uint64) (uint8, error) {
+	switch arg.String() {
+	case AccountReferenceType:
+		account, err := referencedAccount(value)
+		if err != nil {
+			return 0, err
+		}
+		if account == sender {
+			return 0, nil
+		}
+		for i, existing := range r.Accounts {
+			if existing == account {
+				return referenceIndex(i + 1)
+			}
+		}
+		r.Accounts = append(r.Accounts, account)
+		return referenceIndex(len(r.Accounts))
+	case AssetReferenceType:
+		asset, err := referencedID(value)
+		if err != nil {
+			return 0, err
+		}
+		for i, existing := range r.ForeignAssets {
+			if existing == asset {
+				return referenceIndex(i)
+			}
+		}
+		r.ForeignAssets = append(r.ForeignAssets, asset)
+		return referenceIndex(len(r.ForeignAssets) - 1)
+	case ApplicationReferenceType:
+		app, err := referencedID(value)
+		if err != nil {
+			return 0, err
+		}
+		if app == appID {
+			return 0, nil
+		}
+		for i, existing := range r.ForeignApps {
+			if existing == app {
+				return referenceIndex(i + 1)
+			}
+		}
+		r.ForeignApps = append(r.ForeignApps, app)
+		return referenceIndex(len(r.ForeignApps))
+	default:
+		return 0, fmt.Errorf("unknown reference type: %s", arg)
+	}
+}
+
+func referenceIndex(index int) (uint8, error) {
+	if index > 255 {
+		return 0, fmt.Errorf("foreign array index %d does not fit in a uint8", index)
+	}
+	return uint8(index), nil
+}
+
+func referencedAccount(value interface{}) ([address.BytesSize]byte, error) {
+	if addressString, ok := value.(string); ok {
+		return address.FromString(addressString)
+	}
+	encoded, err := addressType.Encode(value)
+	if err != nil {
+		return [address.BytesSize]byte{}, err
+	}
+	account, err := address.FromBytes(encoded)
+	return account, err
+}
+
+func referencedID(value interface{}) (uint64, error) {
+	encoded, err := uint64Type.Encode(value)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(encoded), nil
+}
+
+// DecodedReference is the placeholder value of a reference argument in a `DecodedCall`.
+type DecodedReference struct {
+	// Type is the reference type of the argument, such as "account".
+	Type string
+	// Index is the index of the referenced value in the corresponding foreign array of the call.
+	Index uint8
+}
+
+// DecodedTransaction is the placeholder value of a transaction argument in a `DecodedCall`.
+type DecodedTransaction struct {
+	// Type is the transaction type of the argument, such as "pay".
+	Type string
+	// GroupOffset is the position of the argument's transaction in the atomic group, relative to the
+	// method call. It is always negative, since transaction arguments precede the call.
+	GroupOffset int
+}
+
+// DecodedCall is a method call decoded by `Contract.DecodeCall` or `MethodIndex.DecodeCall`.
+type DecodedCall struct {
+	// Method is the called method.
+	Method Method
+	// Args are the argument values of the call, one for every argument of the method. ABI type
+	// arguments are decoded with `Type.Decode`, while reference and transaction arguments are
+	// represented by a `DecodedReference` or `DecodedTransaction`.
+	Args []interface{}
+}
+
+// DecodeCall decodes the application arguments of a call to one of the contract's methods. The
+// first argument must be the selector of a method of the contract, and the remaining arguments
+// must be encoded as described by ARC-4, including the tuple of packed arguments beyond the 15th.
+func (c Contract) DecodeCall(args [][]byte) (*DecodedCall, error) {
+	return decodeCall(c.MethodBySelector, args)
+}
+
+// decodeCall decodes the application arguments of a method call, looking up the called method by
+// its selector with methodBySelector.
+func decodeCall(methodBySelector func(Selector) (Method, error), args [][]byte) (*DecodedCall, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("method call has no application arguments")
+	}
+	if len(args[0]) != MethodSelectorLength {
+		return nil, fmt.Errorf("method selector should be length %d, got %d", MethodSelectorLength, len(args[0]))
+	}
+	method, err := methodBySelector(Selector(args[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	parsedArgs, err := method.ParsedArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := &DecodedCall{Method: method, Args: make([]interface{}, len(method.Args))}
+	var argTypes []Type
+	var argIndexes []int
+	txnOffset := -method.TxnArgCount()
+	for i, arg := range parsedArgs {
+		encodedType, ok := arg.EncodedType()
+		if !ok {
+			decoded.Args[i] = DecodedTransaction{Type: arg.String(), GroupOffset: txnOffset}
+			txnOffset++
+			continue
+		}
+		argTypes = append(argTypes, encodedType)
+		argIndexes = append(argIndexes, i)
+	}
+
+	encodedArgs := args[1:]
+	expectedArgs := len(argTypes)
+	if expectedArgs > maxMethodCallArgs {
+		expectedArgs = maxMethodCallArgs
+	}
+	if len(encodedArgs) != expectedArgs {
+		return nil, fmt.Errorf("method %s expects %d application arguments after the selector, got %d", method.Name, expectedArgs, len(encodedArgs))
+	}
+
+	values := make([]interface{}, 0, len(argTypes))
+	for i, encoded := range encodedArgs {
+		if i == maxMethodCallArgs-1 && len(argTypes) > maxMethodCallArgs {
+			tupleType, err := MakeTupleType(argTypes[i:])
+			if err != nil {
+				return nil, err
+			}
+			packed, err := tupleType.Decode(encoded)
+			if err != nil {
+				return nil, fmt.Errorf("cannot decode packed arguments of method %s: %w", method.Name, err)
+			}
+			values = append(values, packed.([]interface{})...)
+			break
+		}
+		value, err := argTypes[i].Decode(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode argument %d of method %s: %w", argIndexes[i], method.Name, err)
+		}
+		values = append(values, value)
+	}
+
+	for i, value := range values {
+		argIndex := argIndexes[i]
+		if parsedArgs[argIndex].Kind() == ReferenceArg {
+			value = DecodedReference{Type: parsedArgs[argIndex].String(), Index: value.(uint8)}
+		}
+		decoded.Args[argIndex] = value
+	}
+	return decoded, nil
+}
+
+// DecodeReturn decodes the return value of a call to the method from the logs of the call. The
+// value is taken from the last log starting with `MethodReturnPrefix()`, since earlier logs may be
+// emitted by the method itself. Methods with a void return type return a nil value without
+// inspecting the logs.
+func (m Method) DecodeReturn(logs [][]byte) (interface{}, error) {
+	if m.Returns.Type == VoidReturnType {
+		return nil, nil
+	}
+	returnType, err := m.Returns.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse return type of method %s: %w", m.Name, err)
+	}
+	for i := len(logs) - 1; i >= 0; i-- {
+		if !bytes.HasPrefix(logs[i], methodReturnPrefix[:]) {
+			continue
+		}
+		value, err := returnType.Decode(logs[i][len(methodReturnPrefix):])
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode return value of method %s: %w", m.Name, err)
+		}
+		return value, nil
+	}
+	return nil, fmt.Errorf("no log of method %s starts with the return prefix %x", m.Name, methodReturnPrefix)
+}
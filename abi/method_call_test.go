@@ -0,0 +1,216 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/algorand/avm-abi/address"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMethodCallArgs(t *testing.T) {
+	t.Parallel()
+
+	sender := [address.BytesSize]byte{1}
+	other := [address.BytesSize]byte{2}
+	third := [address.BytesSize]byte{3}
+
+	method := Method{
+		Name: "transfer",
+		Args: []MethodArg{
+			{Type: "pay"},
+			{Type: "account"},
+			{Type: "account"},
+			{Type: "asset"},
+			{Type: "uint16"},
+			{Type: "application"},
+			{Type: "application"},
+			{Type: "account"},
+			{Type: "asset"},
+			{Type: "account"},
+		},
+		Returns: MethodReturn{Type: "void"},
+	}
+	selector := method.GetSelector()
+
+	result, err := BuildMethodCallArgs(method, sender, 10, []interface{}{
+		nil,
+		other,
+		sender[:],
+		uint64(55),
+		uint16(0x0102),
+		uint64(10),
+		20,
+		address.ToString(third),
+		uint64(55),
+		other,
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{
+		selector[:],
+		{1},
+		{0},
+		{0},
+		{0x01, 0x02},
+		{0},
+		{1},
+		{2},
+		{0},
+		{1},
+	}, result.ApplicationArgs)
+	require.Equal(t, [][address.BytesSize]byte{other, third}, result.Accounts)
+	require.Equal(t, []uint64{55}, result.ForeignAssets)
+	require.Equal(t, []uint64{20}, result.ForeignApps)
+	require.Equal(t, map[int]uint8{1: 1, 2: 0, 3: 0, 5: 0, 6: 1, 7: 2, 8: 0, 9: 1}, result.ReferenceIndexes)
+
+	_, err = BuildMethodCallArgs(method, sender, 10, []interface{}{nil})
+	require.EqualError(t, err, "method transfer expects 10 arguments, got 1")
+
+	_, err = BuildMethodCallArgs(Method{Name: "f", Args: []MethodArg{{Type: "account"}}}, sender, 10, []interface{}{"bad"})
+	require.ErrorContains(t, err, "cannot substitute argument 0 of method f")
+
+	_, err = BuildMethodCallArgs(Method{Name: "f", Args: []MethodArg{{Type: "asset"}}}, sender, 10, []interface{}{-1})
+	require.ErrorContains(t, err, "cannot substitute argument 0 of method f")
+
+	_, err = BuildMethodCallArgs(Method{Name: "f", Args: []MethodArg{{Type: "uint8"}}}, sender, 10, []interface{}{"x"})
+	require.ErrorContains(t, err, "cannot encode argument of type uint8 for method f")
+}
+
+func TestBuildMethodCallArgsPacking(t *testing.T) {
+	t.Parallel()
+
+	// 18 non-transaction arguments, so the 15th through 18th are packed into a tuple
+	method := Method{Name: "many", Returns: MethodReturn{Type: "void"}}
+	args := []interface{}{}
+	for i := 0; i < 17; i++ {
+		method.Args = append(method.Args, MethodArg{Type: "uint8"})
+		args = append(args, uint8(i))
+	}
+	method.Args = append(method.Args, MethodArg{Type: "axfer"}, MethodArg{Type: "asset"})
+	args = append(args, nil, uint64(99))
+
+	result, err := BuildMethodCallArgs(method, [address.BytesSize]byte{}, 1, args)
+	require.NoError(t, err)
+	require.Len(t, result.ApplicationArgs, 16)
+	for i := 0; i < 14; i++ {
+		require.Equal(t, []byte{byte(i)}, result.ApplicationArgs[i+1])
+	}
+	require.Equal(t, []byte{14, 15, 16, 0}, result.ApplicationArgs[15])
+	require.Equal(t, []uint64{99}, result.ForeignAssets)
+	require.Equal(t, map[int]uint8{18: 0}, result.ReferenceIndexes)
+
+	// exactly 15 arguments are not packed
+	result, err = BuildMethodCallArgs(Method{Name: "many", Args: method.Args[:15]}, [address.BytesSize]byte{}, 1, args[:15])
+	require.NoError(t, err)
+	require.Len(t, result.ApplicationArgs, 16)
+	require.Equal(t, []byte{14}, result.ApplicationArgs[15])
+}
+
+func TestContractDecodeCall(t *testing.T) {
+	t.Parallel()
+
+	sender := [address.BytesSize]byte{1}
+	transfer := Method{
+		Name: "transfer",
+		Args: []MethodArg{
+			{Type: "pay"},
+			{Type: "account"},
+			{Type: "axfer"},
+			{Type: "asset"},
+			{Type: "(uint16,string)"},
+		},
+		Returns: MethodReturn{Type: "void"},
+	}
+	many := Method{Name: "many", Returns: MethodReturn{Type: "void"}}
+	manyArgs := []interface{}{}
+	for i := 0; i < 16; i++ {
+		many.Args = append(many.Args, MethodArg{Type: "uint64"})
+		manyArgs = append(manyArgs, uint64(i))
+	}
+	many.Args = append(many.Args, MethodArg{Type: "application"})
+	manyArgs = append(manyArgs, uint64(7))
+	contract := Contract{Name: "Token", Methods: []Method{transfer, many}}
+
+	call, err := BuildMethodCallArgs(transfer, sender, 1, []interface{}{
+		nil, [address.BytesSize]byte{2}, nil, uint64(3), []interface{}{uint16(4), "memo"},
+	})
+	require.NoError(t, err)
+	decoded, err := contract.DecodeCall(call.ApplicationArgs)
+	require.NoError(t, err)
+	require.Equal(t, &DecodedCall{
+		Method: transfer,
+		Args: []interface{}{
+			DecodedTransaction{Type: "pay", GroupOffset: -2},
+			DecodedReference{Type: "account", Index: 1},
+			DecodedTransaction{Type: "axfer", GroupOffset: -1},
+			DecodedReference{Type: "asset", Index: 0},
+			[]interface{}{uint16(4), "memo"},
+		},
+	}, decoded)
+
+	call, err = BuildMethodCallArgs(many, sender, 1, manyArgs)
+	require.NoError(t, err)
+	decoded, err = contract.DecodeCall(call.ApplicationArgs)
+	require.NoError(t, err)
+	require.Equal(t, many, decoded.Method)
+	require.Equal(t, manyArgs[:16], decoded.Args[:16])
+	require.Equal(t, DecodedReference{Type: "application", Index: 1}, decoded.Args[16])
+
+	_, err = contract.DecodeCall(nil)
+	require.EqualError(t, err, "method call has no application arguments")
+
+	_, err = contract.DecodeCall([][]byte{{1, 2, 3}})
+	require.EqualError(t, err, "method selector should be length 4, got 3")
+
+	_, err = contract.DecodeCall([][]byte{{1, 2, 3, 4}})
+	require.EqualError(t, err, "contract Token has no method with selector 01020304")
+
+	_, err = contract.DecodeCall(call.ApplicationArgs[:15])
+	require.EqualError(t, err, "method many expects 15 application arguments after the selector, got 14")
+
+	badArgs := append([][]byte{}, call.ApplicationArgs...)
+	badArgs[3] = []byte{1}
+	_, err = contract.DecodeCall(badArgs)
+	require.ErrorContains(t, err, "cannot decode argument 2 of method many")
+
+	badArgs[3] = call.ApplicationArgs[3]
+	badArgs[15] = []byte{1}
+	_, err = contract.DecodeCall(badArgs)
+	require.ErrorContains(t, err, "cannot decode packed arguments of method many")
+}
+
+func TestMethodDecodeReturn(t *testing.T) {
+	t.Parallel()
+
+	method := Method{Name: "get", Returns: MethodReturn{Type: "(uint16,string)"}}
+	returnLog := append(MethodReturnPrefix(), 0x00, 0x07, 0x00, 0x04, 0x00, 0x02, 'o', 'k')
+
+	value, err := method.DecodeReturn([][]byte{[]byte("event"), returnLog})
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{uint16(7), "ok"}, value)
+
+	// the last log with the prefix holds the return value
+	earlier := append(MethodReturnPrefix(), 0xff)
+	value, err = method.DecodeReturn([][]byte{earlier, returnLog, []byte("trailing")})
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{uint16(7), "ok"}, value)
+
+	// the prefix cannot be modified through the returned slice
+	prefix := MethodReturnPrefix()
+	prefix[0] = 0
+	require.Equal(t, []byte{0x15, 0x1f, 0x7c, 0x75}, MethodReturnPrefix())
+
+	_, err = method.DecodeReturn([][]byte{[]byte("event")})
+	require.EqualError(t, err, "no log of method get starts with the return prefix 151f7c75")
+
+	_, err = method.DecodeReturn([][]byte{returnLog, earlier})
+	require.ErrorContains(t, err, "cannot decode return value of method get")
+
+	void := Method{Name: "set", Returns: MethodReturn{Type: "void"}}
+	value, err = void.DecodeReturn(nil)
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	invalid := Method{Name: "bad", Returns: MethodReturn{Type: "uint7"}}
+	_, err = invalid.DecodeReturn([][]byte{returnLog})
+	require.ErrorContains(t, err, "cannot parse return type of method bad")
+}
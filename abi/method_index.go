@@ -0,0 +1,128 @@
+package abi
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MethodIndex looks up the methods of a contract by selector in constant time, for routers and
+// decoders which handle many calls. The selectors are computed once, when the index is created,
+// and the index keeps a copy of the methods, so later changes to the contract do not affect it. A
+// MethodIndex is safe for concurrent use.
+type MethodIndex struct {
+	contractName string
+	methods      []Method
+	// bySelector maps the selector of each method to its position in methods
+	bySelector map[Selector]int
+}
+
+// NewMethodIndex indexes the current methods of the contract by selector. If several methods
+// share a selector, the first one is indexed, as in `Contract.MethodBySelector`.
+func (c Contract) NewMethodIndex() *MethodIndex {
+	index := &MethodIndex{
+		contractName: c.Name,
+		methods:      append([]Method(nil), c.Methods...),
+		bySelector:   make(map[Selector]int, len(c.Methods)),
+	}
+	for i, method := range index.methods {
+		selector := method.GetSelector()
+		if _, ok := index.bySelector[selector]; !ok {
+			index.bySelector[selector] = i
+		}
+	}
+	return index
+}
+
+// MethodBySelector returns the indexed method with the given selector, which is usually the first
+// application argument of a method call.
+func (idx *MethodIndex) MethodBySelector(selector Selector) (Method, error) {
+	index, ok := idx.bySelector[selector]
+	if !ok {
+		return Method{}, fmt.Errorf("contract %s has no method with selector %s", idx.contractName, selector)
+	}
+	return idx.methods[index], nil
+}
+
+// DecodeCall decodes the application arguments of a call to one of the indexed methods, as
+// described by `Contract.DecodeCall`.
+func (idx *MethodIndex) DecodeCall(args [][]byte) (*DecodedCall, error) {
+	return decodeCall(idx.MethodBySelector, args)
+}
+
+// selectorIndex is the index of the methods of a contract by selector, which `Contract` builds on
+// the first call to MethodBySelector and shares between its copies. It records the name and types
+// of each indexed method, which are all its selector depends on, so changes to the methods of the
+// contract are detected by comparing strings instead of computing selectors again.
+type selectorIndex struct {
+	mu sync.RWMutex
+	// keys holds the signature parts of each indexed method, by position in Contract.Methods
+	keys []selectorKey
+	// bySelector maps the selector of each method to its position, keeping the first of the
+	// methods sharing a selector
+	bySelector map[Selector]int
+}
+
+// selectorKey holds the parts of the signature of a method.
+type selectorKey struct {
+	name    string
+	args    []string
+	returns string
+}
+
+func newSelectorKey(method Method) selectorKey {
+	key := selectorKey{name: method.Name, args: make([]string, len(method.Args)), returns: method.Returns.Type}
+	for i, arg := range method.Args {
+		key.args[i] = arg.Type
+	}
+	return key
+}
+
+// matches reports whether the method still has the signature parts recorded in the key.
+func (k selectorKey) matches(method Method) bool {
+	if method.Name != k.name || method.Returns.Type != k.returns || len(method.Args) != len(k.args) {
+		return false
+	}
+	for i, arg := range method.Args {
+		if arg.Type != k.args[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// lookup returns the position of the first of methods with the given selector, and whether the
+// index matches methods. It does not compute any selector.
+func (idx *selectorIndex) lookup(methods []Method, selector Selector) (int, bool, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	if idx.bySelector == nil || len(idx.keys) != len(methods) {
+		return 0, false, false
+	}
+	position, found := idx.bySelector[selector]
+	if found {
+		return position, true, idx.keys[position].matches(methods[position])
+	}
+	// a method may have been changed to have the selector
+	for i, key := range idx.keys {
+		if !key.matches(methods[i]) {
+			return 0, false, false
+		}
+	}
+	return 0, false, true
+}
+
+// rebuild indexes methods, replacing the current index.
+func (idx *selectorIndex) rebuild(methods []Method) {
+	keys := make([]selectorKey, len(methods))
+	bySelector := make(map[Selector]int, len(methods))
+	for i, method := range methods {
+		keys[i] = newSelectorKey(method)
+		selector := method.GetSelector()
+		if _, ok := bySelector[selector]; !ok {
+			bySelector[selector] = i
+		}
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.keys, idx.bySelector = keys, bySelector
+}
@@ -0,0 +1,42 @@
+package abi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodIndex(t *testing.T) {
+	t.Parallel()
+
+	var contract Contract
+	require.NoError(t, json.Unmarshal([]byte(exampleContractJSON), &contract))
+	// a later method with the same selector is not indexed
+	contract.Methods = append(contract.Methods, Method{Name: "add", Args: contract.Methods[0].Args, Returns: contract.Methods[0].Returns})
+	index := contract.NewMethodIndex()
+
+	addSelector := Selector{0x8a, 0xa3, 0xb6, 0x1f}
+	method, err := index.MethodBySelector(addSelector)
+	require.NoError(t, err)
+	require.Equal(t, contract.Methods[0], method)
+
+	method, err = index.MethodBySelector(contract.Methods[1].GetSelector())
+	require.NoError(t, err)
+	require.Equal(t, "deposit", method.Name)
+
+	_, err = index.MethodBySelector(Selector{1, 2, 3, 4})
+	require.EqualError(t, err, "contract Calculator has no method with selector 01020304")
+
+	// the index is not affected by changes to the contract
+	contract.Methods[0].Returns.Type = "uint64"
+	method, err = index.MethodBySelector(addSelector)
+	require.NoError(t, err)
+	require.Equal(t, "uint128", method.Returns.Type)
+
+	call, err := BuildMethodCallArgs(method, [32]byte{}, 1, []interface{}{uint64(1), uint64(2)})
+	require.NoError(t, err)
+	decoded, err := index.DecodeCall(call.ApplicationArgs)
+	require.NoError(t, err)
+	require.Equal(t, &DecodedCall{Method: method, Args: []interface{}{uint64(1), uint64(2)}}, decoded)
+}
@@ -0,0 +1,229 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// methodArgsCodec converts the arguments of a method call to and from a JSON object keyed by
+// argument name.
+type methodArgsCodec struct {
+	methodSig string
+	names     []string
+	// nil for transaction arguments, which are not part of the encoded arguments
+	codecs  []*JSONCodec
+	indexes map[string]int
+	opts    JSONOptions
+}
+
+// methodArgType returns the ABI type used to represent the value of an encoded argument in JSON.
+// Account references are represented by their address, and asset and application references by
+// their uint64 ID.
+func methodArgType(arg Arg) Type {
+	if abiType, ok := arg.Type(); ok {
+		return abiType
+	}
+	if arg.String() == AccountReferenceType {
+		return addressType
+	}
+	return uint64Type
+}
+
+// newMethodArgsCodecFromSignature returns the codec of the arguments of the method with signature
+// methodSig, named by argNames, or "arg0", "arg1", etc. if argNames is nil.
+func newMethodArgsCodecFromSignature(methodSig string, argNames []string, opts JSONOptions) (*methodArgsCodec, error) {
+	_, argTypes, _, err := ParseMethodSignature(methodSig)
+	if err != nil {
+		return nil, err
+	}
+	if argNames == nil {
+		argNames = make([]string, len(argTypes))
+		for i := range argNames {
+			argNames[i] = fmt.Sprintf("arg%d", i)
+		}
+	}
+	if len(argNames) != len(argTypes) {
+		return nil, fmt.Errorf("method %s has %d arguments, but %d argument names were given", methodSig, len(argTypes), len(argNames))
+	}
+	args := make([]Arg, len(argTypes))
+	for i, argType := range argTypes {
+		args[i], err = ParseArg(argType)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing argument type at index %d: %w", i, err)
+		}
+	}
+	return newMethodArgsCodec(methodSig, argNames, args, opts)
+}
+
+// newMethodArgsCodec returns the codec of the arguments args of the method with signature
+// methodSig, named by argNames.
+func newMethodArgsCodec(methodSig string, argNames []string, args []Arg, opts JSONOptions) (*methodArgsCodec, error) {
+	codec := &methodArgsCodec{
+		methodSig: methodSig,
+		names:     argNames,
+		codecs:    make([]*JSONCodec, len(args)),
+		indexes:   make(map[string]int, len(argNames)),
+		opts:      opts,
+	}
+	for i, arg := range args {
+		name := argNames[i]
+		if name == "" {
+			return nil, fmt.Errorf("method %s argument %d has an empty name", methodSig, i)
+		}
+		if _, ok := codec.indexes[name]; ok {
+			return nil, fmt.Errorf(`method %s has duplicate argument name "%s"`, methodSig, name)
+		}
+		codec.indexes[name] = i
+
+		if !arg.IsEncoded() {
+			continue
+		}
+		// arguments are nested within the JSON object
+		codec.codecs[i] = methodArgType(arg).newJSONCodec(opts, 2)
+	}
+	return codec, nil
+}
+
+// newArgsCodec returns the codec of the arguments of the method, named by `Method.ArgNames`.
+func (m Method) newArgsCodec(opts JSONOptions) (*methodArgsCodec, error) {
+	args, err := m.ParsedArgs()
+	if err != nil {
+		return nil, err
+	}
+	return newMethodArgsCodec(m.GetSignature(), m.ArgNames(), args, opts)
+}
+
+// MarshalMethodArgsToJSON converts the arguments of a call to the method with signature methodSig to
+// a JSON object keyed by argument name, with keys in argument order. This gives a human-readable
+// representation of a call, for example for audit logs.
+//
+// argNames gives the name of each argument; if nil, the arguments are named "arg0", "arg1", etc.
+// Account reference arguments are represented by their address, and asset and application reference
+// arguments by their uint64 ID. Transaction arguments are not part of the encoded arguments, so they
+// are always rendered as null, and their values in args are ignored.
+func MarshalMethodArgsToJSON(methodSig string, argNames []string, args []interface{}, opts JSONOptions) ([]byte, error) {
+	codec, err := newMethodArgsCodecFromSignature(methodSig, argNames, opts)
+	if err != nil {
+		return nil, err
+	}
+	return codec.marshal(args)
+}
+
+// MarshalArgsToJSON is like `MarshalMethodArgsToJSON`, with the arguments named by `ArgNames`.
+// Arguments with a StructType are converted to JSON objects keyed by field name.
+func (m Method) MarshalArgsToJSON(args []interface{}, opts JSONOptions) ([]byte, error) {
+	codec, err := m.newArgsCodec(opts)
+	if err != nil {
+		return nil, err
+	}
+	return codec.marshal(args)
+}
+
+func (c *methodArgsCodec) marshal(args []interface{}) ([]byte, error) {
+	if len(args) != len(c.codecs) {
+		return nil, fmt.Errorf("method %s has %d arguments, but %d values were given", c.methodSig, len(c.codecs), len(args))
+	}
+	rawValues := make([]json.RawMessage, len(args))
+	for i, argCodec := range c.codecs {
+		if argCodec == nil {
+			rawValues[i] = json.RawMessage("null")
+			continue
+		}
+		var err error
+		rawValues[i], err = argCodec.marshal(args[i])
+		if err != nil {
+			return nil, fmt.Errorf(`cannot marshal argument "%s" of method %s: %w`, c.names[i], c.methodSig, err)
+		}
+	}
+	encoded, err := marshalJSONObject(c.names, rawValues)
+	if err != nil {
+		return nil, err
+	}
+	return indentJSON(encoded, c.opts.Indent)
+}
+
+// UnmarshalMethodArgsFromJSON converts a JSON object produced by MarshalMethodArgsToJSON back to the
+// arguments of a call to the method with signature methodSig, in argument order. The value of each
+// transaction argument must be null, and is returned as nil.
+func UnmarshalMethodArgsFromJSON(methodSig string, argNames []string, jsonEncoded []byte, opts JSONOptions) ([]interface{}, error) {
+	codec, err := newMethodArgsCodecFromSignature(methodSig, argNames, opts)
+	if err != nil {
+		return nil, err
+	}
+	return codec.unmarshal(jsonEncoded)
+}
+
+// UnmarshalArgsFromJSON converts a JSON object produced by `MarshalArgsToJSON` back to the
+// arguments of a call to the method, in argument order, as in `UnmarshalMethodArgsFromJSON`.
+func (m Method) UnmarshalArgsFromJSON(jsonEncoded []byte, opts JSONOptions) ([]interface{}, error) {
+	codec, err := m.newArgsCodec(opts)
+	if err != nil {
+		return nil, err
+	}
+	return codec.unmarshal(jsonEncoded)
+}
+
+func (c *methodArgsCodec) unmarshal(jsonEncoded []byte) ([]interface{}, error) {
+	methodSig := c.methodSig
+	reader := newJSONReader(bytes.NewReader(jsonEncoded), c.opts)
+	token, err := reader.dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read JSON encoded arguments of method %s: %w", methodSig, err)
+	}
+	if token != json.Delim('{') {
+		return nil, fmt.Errorf("cannot cast JSON encoded (%v) to arguments of method %s: expected JSON object", token, methodSig)
+	}
+
+	values := make([]interface{}, len(c.codecs))
+	present := make([]bool, len(c.codecs))
+	for reader.dec.More() {
+		token, err := reader.dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read JSON encoded arguments of method %s: %w", methodSig, err)
+		}
+		name, _ := token.(string)
+		index, ok := c.indexes[name]
+		if !ok {
+			return nil, fmt.Errorf(`unknown argument "%s" for method %s`, name, methodSig)
+		}
+		if present[index] {
+			return nil, fmt.Errorf(`duplicate argument "%s" for method %s`, name, methodSig)
+		}
+		present[index] = true
+
+		argCodec := c.codecs[index]
+		if argCodec == nil {
+			token, err := reader.dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("cannot read JSON encoded arguments of method %s: %w", methodSig, err)
+			}
+			if token != nil {
+				return nil, fmt.Errorf(`transaction argument "%s" for method %s must be null`, name, methodSig)
+			}
+			continue
+		}
+		values[index], err = argCodec.unmarshalValue(reader)
+		if err != nil {
+			return nil, fmt.Errorf(`cannot unmarshal argument "%s" of method %s: %w`, name, methodSig, err)
+		}
+	}
+	if _, err := reader.dec.Token(); err != nil {
+		return nil, fmt.Errorf("cannot read JSON encoded arguments of method %s: %w", methodSig, err)
+	}
+	if _, err := reader.dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after JSON encoded arguments of method %s", methodSig)
+	}
+
+	for i, name := range c.names {
+		if present[i] || c.codecs[i] == nil {
+			continue
+		}
+		if !c.opts.ZeroFillMissing {
+			return nil, fmt.Errorf(`missing argument "%s" for method %s`, name, methodSig)
+		}
+		values[i] = c.codecs[i].abiType.zeroValue()
+	}
+	return values, nil
+}
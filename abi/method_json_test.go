@@ -0,0 +1,126 @@
+package abi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodArgsJSON(t *testing.T) {
+	t.Parallel()
+
+	const methodSig = "transfer(pay,account,asset,uint64,(string,bool))void"
+	argNames := []string{"payment", "receiver", "asset", "amount", "memo"}
+
+	receiver := make([]byte, 32)
+	receiver[31] = 1
+	args := []interface{}{
+		nil,
+		receiver,
+		uint64(1234),
+		uint64(5),
+		[]interface{}{"hello", true},
+	}
+	expected := `{"payment":null,"receiver":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAVIOOBQA","asset":1234,"amount":5,"memo":["hello",true]}`
+
+	actual, err := MarshalMethodArgsToJSON(methodSig, argNames, args, JSONOptions{})
+	require.NoError(t, err)
+	require.Equal(t, expected, string(actual))
+
+	decoded, err := UnmarshalMethodArgsFromJSON(methodSig, argNames, actual, JSONOptions{})
+	require.NoError(t, err)
+	require.Equal(t, args, decoded)
+
+	t.Run("default names", func(t *testing.T) {
+		t.Parallel()
+		actual, err := MarshalMethodArgsToJSON("add(uint64,uint64)uint128", nil, []interface{}{uint64(1), uint64(2)}, JSONOptions{})
+		require.NoError(t, err)
+		require.Equal(t, `{"arg0":1,"arg1":2}`, string(actual))
+	})
+
+	t.Run("options", func(t *testing.T) {
+		t.Parallel()
+		opts := JSONOptions{UintAsString: true, Indent: "  "}
+		actual, err := MarshalMethodArgsToJSON("add(uint64,uint64)uint128", []string{"a", "b"}, []interface{}{uint64(1), uint64(2)}, opts)
+		require.NoError(t, err)
+		require.Equal(t, "{\n  \"a\": \"1\",\n  \"b\": \"2\"\n}", string(actual))
+
+		decoded, err := UnmarshalMethodArgsFromJSON("add(uint64,uint64)uint128", []string{"a", "b"}, []byte(`{"b":2}`), JSONOptions{ZeroFillMissing: true})
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{uint64(0), uint64(2)}, decoded)
+	})
+
+	errorCases := []struct {
+		input string
+		err   string
+	}{
+		{input: `[]`, err: "expected JSON object"},
+		{input: `{"payment":null,"receiver":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAVIOOBQA","asset":1234,"amount":5}`, err: `missing argument "memo"`},
+		{input: `{"payment":{},"receiver":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAVIOOBQA","asset":1234,"amount":5,"memo":["",true]}`, err: `transaction argument "payment" for method ` + methodSig + ` must be null`},
+		{input: `{"amount":5,"amount":6}`, err: `duplicate argument "amount"`},
+		{input: `{"other":5}`, err: `unknown argument "other"`},
+		{input: `{"amount":"5"}`, err: `cannot unmarshal argument "amount"`},
+		{input: `{"payment":null,"receiver":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAVIOOBQA","asset":1234,"amount":5,"memo":["",true]} {}`, err: "unexpected data after JSON encoded arguments"},
+	}
+
+	for i, errorCase := range errorCases {
+		t.Run(fmt.Sprintf("error i=%d", i), func(t *testing.T) {
+			t.Parallel()
+			_, err := UnmarshalMethodArgsFromJSON(methodSig, argNames, []byte(errorCase.input), JSONOptions{})
+			require.ErrorContains(t, err, errorCase.err)
+		})
+	}
+
+	_, err = MarshalMethodArgsToJSON(methodSig, argNames[:2], args, JSONOptions{})
+	require.ErrorContains(t, err, "but 2 argument names were given")
+
+	_, err = MarshalMethodArgsToJSON(methodSig, []string{"a", "b", "c", "d", "a"}, args, JSONOptions{})
+	require.ErrorContains(t, err, `duplicate argument name "a"`)
+
+	_, err = MarshalMethodArgsToJSON(methodSig, argNames, args[:4], JSONOptions{})
+	require.ErrorContains(t, err, "but 4 values were given")
+
+	_, err = MarshalMethodArgsToJSON("bad(uint7)void", nil, []interface{}{uint64(0)}, JSONOptions{})
+	require.ErrorContains(t, err, "Error parsing argument type at index 0")
+}
+
+func TestMethodArgsToJSON(t *testing.T) {
+	t.Parallel()
+
+	uintType, err := TypeOf("uint64")
+	require.NoError(t, err)
+	point, err := MakeNamedTupleType([]Type{uintType, uintType}, []string{"x", "y"})
+	require.NoError(t, err)
+	method := Method{
+		Name:    "move",
+		Args:    []MethodArg{{Name: "payment", Type: "pay"}, {Name: "to", Type: "(uint64,uint64)", StructType: &point}, {Type: "uint64"}},
+		Returns: MethodReturn{Type: "void"},
+	}
+
+	args := []interface{}{nil, []interface{}{uint64(1), uint64(2)}, uint64(3)}
+	encoded, err := method.MarshalArgsToJSON(args, JSONOptions{})
+	require.NoError(t, err)
+	require.Equal(t, `{"payment":null,"to":{"x":1,"y":2},"arg2":3}`, string(encoded))
+
+	decoded, err := method.UnmarshalArgsFromJSON(encoded, JSONOptions{})
+	require.NoError(t, err)
+	require.Equal(t, args, decoded)
+
+	// without a struct type, the argument is a JSON array
+	method.Args[1].StructType = nil
+	encoded, err = method.MarshalArgsToJSON(args, JSONOptions{})
+	require.NoError(t, err)
+	require.Equal(t, `{"payment":null,"to":[1,2],"arg2":3}`, string(encoded))
+
+	_, err = method.UnmarshalArgsFromJSON([]byte(`{"to":[1,2]}`), JSONOptions{})
+	require.ErrorContains(t, err, `missing argument "arg2" for method move(pay,(uint64,uint64),uint64)void`)
+
+	method.Args = append(method.Args, MethodArg{Name: "to", Type: "bool"})
+	_, err = method.MarshalArgsToJSON(append(args, true), JSONOptions{})
+	require.ErrorContains(t, err, `duplicate argument name "to"`)
+
+	method.Args[3].Type = "uint7"
+	_, err = method.UnmarshalArgsFromJSON([]byte(`{}`), JSONOptions{})
+	require.ErrorContains(t, err, "Error parsing argument type at index 3 of method move")
+}
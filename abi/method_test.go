@@ -0,0 +1,200 @@
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodGetSelector(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		method   Method
+		expected string
+	}{
+		{
+			method: Method{
+				Name:    "add",
+				Args:    []MethodArg{{Name: "a", Type: "uint64"}, {Name: "b", Type: "uint64"}},
+				Returns: MethodReturn{Type: "uint128"},
+			},
+			expected: "8aa3b61f",
+		},
+		{
+			method: Method{
+				Name:    "optIn",
+				Args:    []MethodArg{{Type: "pay", Desc: "the payment"}, {Type: "account"}},
+				Returns: MethodReturn{Type: "void"},
+			},
+			expected: "1f91add8",
+		},
+		{
+			method: Method{
+				Name:    "create",
+				Returns: MethodReturn{Type: "void"},
+			},
+			expected: "4c5c61ba",
+		},
+	}
+
+	for _, testCase := range testCases {
+		selector := testCase.method.GetSelector()
+		require.Equal(t, testCase.expected, hex.EncodeToString(selector[:]))
+	}
+}
+
+func TestMethodGetSignature(t *testing.T) {
+	t.Parallel()
+
+	manyArgs := make([]MethodArg, 17)
+	manyArgTypes := make([]string, len(manyArgs))
+	for i := range manyArgs {
+		manyArgs[i] = MethodArg{Type: "uint64"}
+		manyArgTypes[i] = "uint64"
+	}
+
+	testCases := []struct {
+		method   Method
+		expected string
+		argTypes []string
+	}{
+		{
+			method: Method{
+				Name:    "add",
+				Args:    []MethodArg{{Name: "a", Type: "uint64"}, {Name: "b", Type: "uint64"}},
+				Returns: MethodReturn{Type: "uint128"},
+			},
+			expected: "add(uint64,uint64)uint128",
+			argTypes: []string{"uint64", "uint64"},
+		},
+		{
+			method: Method{
+				Name:    "complex",
+				Args:    []MethodArg{{Type: "(bool,(address,byte[]),string[2])"}, {Type: "axfer"}, {Type: "application"}},
+				Returns: MethodReturn{Type: "(uint8,ufixed64x2)[]"},
+			},
+			expected: "complex((bool,(address,byte[]),string[2]),axfer,application)(uint8,ufixed64x2)[]",
+			argTypes: []string{"(bool,(address,byte[]),string[2])", "axfer", "application"},
+		},
+		{
+			method:   Method{Name: "create", Returns: MethodReturn{Type: "void"}},
+			expected: "create()void",
+			argTypes: []string{},
+		},
+		{
+			method: Method{Name: "many", Args: manyArgs, Returns: MethodReturn{Type: "void"}},
+			expected: "many(uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64," +
+				"uint64,uint64,uint64,uint64,uint64)void",
+			argTypes: manyArgTypes,
+		},
+	}
+
+	for _, testCase := range testCases {
+		signature := testCase.method.GetSignature()
+		require.Equal(t, testCase.expected, signature)
+
+		name, argTypes, returnType, err := ParseMethodSignature(signature)
+		require.NoError(t, err)
+		require.Equal(t, testCase.method.Name, name)
+		require.Equal(t, testCase.argTypes, argTypes)
+		require.Equal(t, testCase.method.Returns.Type, returnType)
+		require.NoError(t, VerifyMethodSignature(signature))
+	}
+}
+
+func TestMethodArgMetadata(t *testing.T) {
+	t.Parallel()
+
+	var contract Contract
+	err := json.Unmarshal([]byte(`{
+		"name": "Token",
+		"methods": [
+			{
+				"name": "balance",
+				"desc": "Get a balance",
+				"args": [{"type": "account", "name": "owner", "desc": "The account"}, {"type": "uint64"}],
+				"returns": {"type": "uint64"},
+				"readonly": true
+			},
+			{"name": "reset", "args": [], "returns": {"type": "void"}}
+		]
+	}`), &contract)
+	require.NoError(t, err)
+
+	balance := contract.Methods[0]
+	require.True(t, balance.ReadOnly)
+	require.Equal(t, []string{"owner", "arg1"}, balance.ArgNames())
+	require.Equal(t, []string{"The account", ""}, balance.ArgDescriptions())
+
+	reset := contract.Methods[1]
+	require.False(t, reset.ReadOnly)
+	require.Empty(t, reset.ArgNames())
+
+	// the readonly flag does not affect the selector, and is omitted when false
+	require.Equal(t, Method{Name: balance.Name, Args: balance.Args, Returns: balance.Returns}.GetSelector(), balance.GetSelector())
+	encoded, err := json.Marshal(reset)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"reset","args":[],"returns":{"type":"void"}}`, string(encoded))
+	encoded, err = json.Marshal(Method{Name: "get", Args: []MethodArg{}, Returns: MethodReturn{Type: "uint64"}, ReadOnly: true})
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"get","args":[],"returns":{"type":"uint64"},"readonly":true}`, string(encoded))
+}
+
+func TestMethodArgKinds(t *testing.T) {
+	t.Parallel()
+
+	method := Method{
+		Name: "swap",
+		Args: []MethodArg{
+			{Type: "axfer"},
+			{Type: "pay"},
+			{Type: "asset"},
+			{Type: "uint64"},
+			{Type: "account"},
+			{Type: "txn"},
+			{Type: "(application,uint8)"},
+		},
+		Returns: MethodReturn{Type: "void"},
+	}
+	require.Equal(t, []ArgKind{TransactionArg, TransactionArg, ReferenceArg, ValueArg, ReferenceArg, TransactionArg, ValueArg}, method.ArgKinds())
+	require.Equal(t, ReferenceArg, method.Args[2].Kind())
+	require.Equal(t, 3, method.TxnArgCount())
+	require.Equal(t, 2, method.RefArgCount())
+
+	empty := Method{Name: "noop", Returns: MethodReturn{Type: "void"}}
+	require.Empty(t, empty.ArgKinds())
+	require.Zero(t, empty.TxnArgCount())
+	require.Zero(t, empty.RefArgCount())
+}
+
+func TestMethodFromSignature(t *testing.T) {
+	t.Parallel()
+
+	method, err := MethodFromSignature("swap(axfer, account, uint064, (uint8,string))(bool,uint64)")
+	require.NoError(t, err)
+	require.Equal(t, Method{
+		Name:    "swap",
+		Args:    []MethodArg{{Type: "axfer"}, {Type: "account"}, {Type: "uint64"}, {Type: "(uint8,string)"}},
+		Returns: MethodReturn{Type: "(bool,uint64)"},
+	}, method)
+	require.Equal(t, []ArgKind{TransactionArg, ReferenceArg, ValueArg, ValueArg}, method.ArgKinds())
+	require.Equal(t, "swap(axfer,account,uint64,(uint8,string))(bool,uint64)", method.GetSignature())
+
+	method, err = MethodFromSignature("noop()void")
+	require.NoError(t, err)
+	require.Equal(t, Method{Name: "noop", Args: []MethodArg{}, Returns: MethodReturn{Type: "void"}}, method)
+
+	_, err = MethodFromSignature("bad(uint7,string[x])uint64")
+	var sigErr *MethodSignatureError
+	require.ErrorAs(t, err, &sigErr)
+	require.Len(t, sigErr.ArgErrors, 2)
+
+	_, err = MethodFromSignature("missing")
+	require.Error(t, err)
+
+	_, err = MethodFromSignature("a dd(uint64)void")
+	require.EqualError(t, err, `Method name contains whitespace: "a dd"`)
+}
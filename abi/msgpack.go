@@ -0,0 +1,509 @@
+package abi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/algorand/avm-abi/address"
+)
+
+// MarshalToMsgpack converts golang value to MessagePack format from ABI type. It accepts the same
+// values as `Encode`. ABI types are represented as follows:
+//
+//   - bool values are MessagePack booleans
+//   - byte values, and uint<N> and ufixed<N>x<M> values with N <= 64, are MessagePack unsigned
+//     integers; ufixed values are represented by their integer numerator
+//   - uint<N> and ufixed<N>x<M> values with N > 64 are MessagePack bin values holding the N/8 byte
+//     big-endian integer, since MessagePack integers are limited to 64 bits
+//   - address values, and static and dynamic byte arrays, are MessagePack bin values
+//   - string values are MessagePack str values
+//   - named tuples are MessagePack maps keyed by field name, with fields in declaration order, and
+//     other tuples and arrays are MessagePack arrays
+//
+// The output is deterministic, and always uses the smallest MessagePack representation of each
+// integer and length.
+func (t Type) MarshalToMsgpack(value interface{}) ([]byte, error) {
+	return t.appendMsgpack(nil, value)
+}
+
+// UnmarshalFromMsgpack converts MessagePack encoded bytes to golang value following ABI type. The
+// result has the same form as the result of `Decode`.
+//
+// In addition to the representations produced by `MarshalToMsgpack`, byte arrays are accepted as
+// MessagePack arrays of integers, named tuples are accepted as MessagePack arrays, and signed
+// MessagePack integers are accepted for uint and ufixed values if they are not negative.
+func (t Type) UnmarshalFromMsgpack(encoded []byte) (interface{}, error) {
+	reader := msgpackReader{data: encoded}
+	value, err := t.readMsgpack(&reader)
+	if err != nil {
+		return nil, err
+	}
+	if reader.pos != len(reader.data) {
+		return nil, fmt.Errorf("unexpected data after MessagePack encoded %s value", t.String())
+	}
+	return value, nil
+}
+
+func (t Type) appendMsgpack(buf []byte, value interface{}) ([]byte, error) {
+	switch t.kind {
+	case Uint, Ufixed:
+		encoded, err := encodeInt(value, t.bitSize)
+		if err != nil {
+			return nil, err
+		}
+		if t.bitSize > 64 {
+			return appendMsgpackBin(buf, encoded), nil
+		}
+		return appendMsgpackUint(buf, new(big.Int).SetBytes(encoded).Uint64()), nil
+	case Bool:
+		boolValue, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("cannot infer to bool for marshal to MessagePack")
+		}
+		if boolValue {
+			return append(buf, 0xc3), nil
+		}
+		return append(buf, 0xc2), nil
+	case Byte:
+		byteValue, ok := value.(byte)
+		if !ok {
+			return nil, fmt.Errorf("cannot infer to byte for marshal to MessagePack")
+		}
+		return appendMsgpackUint(buf, uint64(byteValue)), nil
+	case Address:
+		switch valueCasted := value.(type) {
+		case []byte:
+			if len(valueCasted) != address.BytesSize {
+				return nil, fmt.Errorf("address byte slice length not equal to 32 byte")
+			}
+			return appendMsgpackBin(buf, valueCasted), nil
+		case [address.BytesSize]byte:
+			return appendMsgpackBin(buf, valueCasted[:]), nil
+		default:
+			return nil, fmt.Errorf("cannot infer to byte slice/array for marshal to MessagePack")
+		}
+	case String:
+		stringValue, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot infer to string for marshal to MessagePack")
+		}
+		buf = appendMsgpackHeader(buf, len(stringValue), 0xa0, 32, 0xd9, 0xda, 0xdb)
+		return append(buf, stringValue...), nil
+	case ArrayStatic, ArrayDynamic:
+		values, err := inferToSlice(value)
+		if err != nil {
+			return nil, err
+		}
+		if t.kind == ArrayStatic && int(t.staticLength) != len(values) {
+			return nil, fmt.Errorf("length of slice %d != type specific length %d", len(values), t.staticLength)
+		}
+		if t.childTypes[0].kind == Byte {
+			byteArr := make([]byte, len(values))
+			for i := range values {
+				byteValue, ok := values[i].(byte)
+				if !ok {
+					return nil, fmt.Errorf("cannot infer byte element from slice")
+				}
+				byteArr[i] = byteValue
+			}
+			return appendMsgpackBin(buf, byteArr), nil
+		}
+		buf = appendMsgpackHeader(buf, len(values), 0x90, 16, 0, 0xdc, 0xdd)
+		for _, elem := range values {
+			buf, err = t.childTypes[0].appendMsgpack(buf, elem)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case Tuple:
+		values, err := t.inferTupleValues(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != len(t.childTypes) {
+			return nil, fmt.Errorf("tuple element number != value slice length")
+		}
+		if len(t.fieldNames) > 0 {
+			buf = appendMsgpackHeader(buf, len(values), 0x80, 16, 0, 0xde, 0xdf)
+		} else {
+			buf = appendMsgpackHeader(buf, len(values), 0x90, 16, 0, 0xdc, 0xdd)
+		}
+		for i, elem := range values {
+			if len(t.fieldNames) > 0 {
+				buf = appendMsgpackHeader(buf, len(t.fieldNames[i]), 0xa0, 32, 0xd9, 0xda, 0xdb)
+				buf = append(buf, t.fieldNames[i]...)
+			}
+			buf, err = t.childTypes[i].appendMsgpack(buf, elem)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	default:
+		return nil, fmt.Errorf("cannot infer ABI type for marshalling value to MessagePack")
+	}
+}
+
+func (t Type) readMsgpack(reader *msgpackReader) (interface{}, error) {
+	switch t.kind {
+	case Uint, Ufixed:
+		var encoded []byte
+		if reader.isBin() {
+			bin, err := reader.readBin()
+			if err != nil {
+				return nil, err
+			}
+			if len(bin) > int(t.bitSize/8) {
+				return nil, fmt.Errorf("cannot cast MessagePack bin of length %d to %s", len(bin), t.String())
+			}
+			encoded = make([]byte, t.bitSize/8)
+			copy(encoded[len(encoded)-len(bin):], bin)
+		} else {
+			num, err := reader.readUint()
+			if err != nil {
+				return nil, err
+			}
+			if t.bitSize < 64 && num>>t.bitSize != 0 {
+				return nil, fmt.Errorf("cannot cast MessagePack integer %d to %s", num, t.String())
+			}
+			encoded = new(big.Int).SetUint64(num).FillBytes(make([]byte, t.bitSize/8))
+		}
+		return decodeUint(encoded, t.bitSize)
+	case Bool:
+		return reader.readBool()
+	case Byte:
+		num, err := reader.readUint()
+		if err != nil {
+			return nil, err
+		}
+		if num > math.MaxUint8 {
+			return nil, fmt.Errorf("cannot cast MessagePack integer %d to byte", num)
+		}
+		return byte(num), nil
+	case Address:
+		bin, err := reader.readBin()
+		if err != nil {
+			return nil, err
+		}
+		if len(bin) != address.BytesSize {
+			return nil, fmt.Errorf("address byte slice length not equal to 32 byte")
+		}
+		return append([]byte(nil), bin...), nil
+	case String:
+		str, err := reader.readStr()
+		if err != nil {
+			return nil, err
+		}
+		return string(str), nil
+	case ArrayStatic, ArrayDynamic:
+		var values []interface{}
+		if t.childTypes[0].kind == Byte && reader.isBin() {
+			bin, err := reader.readBin()
+			if err != nil {
+				return nil, err
+			}
+			values = make([]interface{}, len(bin))
+			for i, b := range bin {
+				values[i] = b
+			}
+		} else {
+			length, err := reader.readArrayHeader()
+			if err != nil {
+				return nil, err
+			}
+			values = make([]interface{}, 0, reader.capacityHint(length))
+			for i := 0; i < length; i++ {
+				elem, err := t.childTypes[0].readMsgpack(reader)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, elem)
+			}
+		}
+		if t.kind == ArrayStatic && len(values) != int(t.staticLength) {
+			return nil, fmt.Errorf("MessagePack array element number != ABI array elem number")
+		}
+		return values, nil
+	case Tuple:
+		if len(t.fieldNames) > 0 && reader.isMap() {
+			return t.readMsgpackMap(reader)
+		}
+		length, err := reader.readArrayHeader()
+		if err != nil {
+			return nil, err
+		}
+		if length != len(t.childTypes) {
+			return nil, fmt.Errorf("MessagePack array element number != ABI tuple elem number")
+		}
+		values := make([]interface{}, len(t.childTypes))
+		for i, childT := range t.childTypes {
+			values[i], err = childT.readMsgpack(reader)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("cannot infer ABI type for unmarshalling value from MessagePack")
+	}
+}
+
+// readMsgpackMap reads the value of a named tuple from a MessagePack map keyed by field name.
+func (t Type) readMsgpackMap(reader *msgpackReader) (interface{}, error) {
+	length, err := reader.readMapHeader()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(t.fieldNames))
+	present := make([]bool, len(t.fieldNames))
+	for i := 0; i < length; i++ {
+		name, err := reader.readStr()
+		if err != nil {
+			return nil, err
+		}
+		index := t.fieldIndex(string(name))
+		if index == -1 {
+			return nil, fmt.Errorf(`unknown field "%s" for named tuple %s`, name, t.String())
+		}
+		if present[index] {
+			return nil, fmt.Errorf(`duplicate field "%s" for named tuple %s`, name, t.String())
+		}
+		values[index], err = t.childTypes[index].readMsgpack(reader)
+		if err != nil {
+			return nil, err
+		}
+		present[index] = true
+	}
+	for i, name := range t.fieldNames {
+		if !present[i] {
+			return nil, fmt.Errorf(`missing field "%s" for named tuple %s`, name, t.String())
+		}
+	}
+	return values, nil
+}
+
+// appendMsgpackUint appends the smallest MessagePack representation of an unsigned integer.
+func appendMsgpackUint(buf []byte, num uint64) []byte {
+	switch {
+	case num <= 0x7f:
+		return append(buf, byte(num))
+	case num <= math.MaxUint8:
+		return append(buf, 0xcc, byte(num))
+	case num <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, 0xcd), uint16(num))
+	case num <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buf, 0xce), uint32(num))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, 0xcf), num)
+	}
+}
+
+// appendMsgpackBin appends a MessagePack bin value.
+func appendMsgpackBin(buf []byte, bin []byte) []byte {
+	buf = appendMsgpackHeader(buf, len(bin), 0, 0, 0xc4, 0xc5, 0xc6)
+	return append(buf, bin...)
+}
+
+// appendMsgpackHeader appends the smallest MessagePack header for a value of the given length. The
+// fix format, which holds lengths below fixLimit in the low bits of fixPrefix, is used if fixLimit
+// is positive; the 8-bit format is used if prefix8 is not zero.
+func appendMsgpackHeader(buf []byte, length int, fixPrefix byte, fixLimit int, prefix8, prefix16, prefix32 byte) []byte {
+	switch {
+	case length < fixLimit:
+		return append(buf, fixPrefix|byte(length))
+	case prefix8 != 0 && length <= math.MaxUint8:
+		return append(buf, prefix8, byte(length))
+	case length <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, prefix16), uint16(length))
+	default:
+		return binary.BigEndian.AppendUint32(append(buf, prefix32), uint32(length))
+	}
+}
+
+// msgpackReader reads MessagePack values from a byte slice.
+type msgpackReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *msgpackReader) peek() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, fmt.Errorf("unexpected end of MessagePack data")
+	}
+	return r.data[r.pos], nil
+}
+
+func (r *msgpackReader) next(n int) ([]byte, error) {
+	if n < 0 || n > len(r.data)-r.pos {
+		return nil, fmt.Errorf("unexpected end of MessagePack data")
+	}
+	value := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return value, nil
+}
+
+// capacityHint bounds the capacity allocated for a collection of length elements by the remaining
+// data, since each element takes at least one byte.
+func (r *msgpackReader) capacityHint(length int) int {
+	if remaining := len(r.data) - r.pos; length > remaining {
+		return remaining
+	}
+	return length
+}
+
+func (r *msgpackReader) isBin() bool {
+	prefix, err := r.peek()
+	return err == nil && prefix >= 0xc4 && prefix <= 0xc6
+}
+
+func (r *msgpackReader) isMap() bool {
+	prefix, err := r.peek()
+	return err == nil && (prefix&0xf0 == 0x80 || prefix == 0xde || prefix == 0xdf)
+}
+
+// readLength reads a big-endian length of size bytes.
+func (r *msgpackReader) readLength(size int) (int, error) {
+	encoded, err := r.next(size)
+	if err != nil {
+		return 0, err
+	}
+	var length uint64
+	for _, b := range encoded {
+		length = length<<8 | uint64(b)
+	}
+	if length > uint64(len(r.data)) {
+		return 0, fmt.Errorf("MessagePack length %d exceeds data size", length)
+	}
+	return int(length), nil
+}
+
+func (r *msgpackReader) readUint() (uint64, error) {
+	prefix, err := r.peek()
+	if err != nil {
+		return 0, err
+	}
+	r.pos++
+	switch {
+	case prefix <= 0x7f:
+		return uint64(prefix), nil
+	case prefix >= 0xcc && prefix <= 0xcf:
+		encoded, err := r.next(1 << (prefix - 0xcc))
+		if err != nil {
+			return 0, err
+		}
+		var num uint64
+		for _, b := range encoded {
+			num = num<<8 | uint64(b)
+		}
+		return num, nil
+	case prefix >= 0xd0 && prefix <= 0xd3:
+		encoded, err := r.next(1 << (prefix - 0xd0))
+		if err != nil {
+			return 0, err
+		}
+		if encoded[0]&0x80 != 0 {
+			return 0, fmt.Errorf("cannot cast negative MessagePack integer to uint")
+		}
+		var num uint64
+		for _, b := range encoded {
+			num = num<<8 | uint64(b)
+		}
+		return num, nil
+	case prefix >= 0xe0:
+		return 0, fmt.Errorf("cannot cast negative MessagePack integer to uint")
+	default:
+		return 0, fmt.Errorf("expected MessagePack integer, got format 0x%02x", prefix)
+	}
+}
+
+func (r *msgpackReader) readBool() (bool, error) {
+	prefix, err := r.peek()
+	if err != nil {
+		return false, err
+	}
+	switch prefix {
+	case 0xc2:
+		r.pos++
+		return false, nil
+	case 0xc3:
+		r.pos++
+		return true, nil
+	default:
+		return false, fmt.Errorf("expected MessagePack bool, got format 0x%02x", prefix)
+	}
+}
+
+func (r *msgpackReader) readBin() ([]byte, error) {
+	prefix, err := r.peek()
+	if err != nil {
+		return nil, err
+	}
+	if prefix < 0xc4 || prefix > 0xc6 {
+		return nil, fmt.Errorf("expected MessagePack bin, got format 0x%02x", prefix)
+	}
+	r.pos++
+	length, err := r.readLength(1 << (prefix - 0xc4))
+	if err != nil {
+		return nil, err
+	}
+	return r.next(length)
+}
+
+func (r *msgpackReader) readStr() ([]byte, error) {
+	prefix, err := r.peek()
+	if err != nil {
+		return nil, err
+	}
+	var length int
+	switch {
+	case prefix&0xe0 == 0xa0:
+		r.pos++
+		length = int(prefix & 0x1f)
+	case prefix >= 0xd9 && prefix <= 0xdb:
+		r.pos++
+		length, err = r.readLength(1 << (prefix - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("expected MessagePack str, got format 0x%02x", prefix)
+	}
+	return r.next(length)
+}
+
+func (r *msgpackReader) readArrayHeader() (int, error) {
+	prefix, err := r.peek()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case prefix&0xf0 == 0x90:
+		r.pos++
+		return int(prefix & 0x0f), nil
+	case prefix == 0xdc || prefix == 0xdd:
+		r.pos++
+		return r.readLength(2 << (prefix - 0xdc))
+	default:
+		return 0, fmt.Errorf("expected MessagePack array, got format 0x%02x", prefix)
+	}
+}
+
+func (r *msgpackReader) readMapHeader() (int, error) {
+	prefix, err := r.peek()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case prefix&0xf0 == 0x80:
+		r.pos++
+		return int(prefix & 0x0f), nil
+	case prefix == 0xde || prefix == 0xdf:
+		r.pos++
+		return r.readLength(2 << (prefix - 0xde))
+	default:
+		return 0, fmt.Errorf("expected MessagePack map, got format 0x%02x", prefix)
+	}
+}
@@ -0,0 +1,187 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalToMsgpack(t *testing.T) {
+	t.Parallel()
+
+	largeUint, ok := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	require.True(t, ok)
+
+	testCases := []struct {
+		typeStr  string
+		value    interface{}
+		expected string
+	}{
+		{typeStr: "bool", value: true, expected: "c3"},
+		{typeStr: "bool", value: false, expected: "c2"},
+		{typeStr: "byte", value: byte(200), expected: "ccc8"},
+		{typeStr: "uint8", value: uint8(127), expected: "7f"},
+		{typeStr: "uint16", value: uint16(256), expected: "cd0100"},
+		{typeStr: "uint32", value: uint32(65536), expected: "ce00010000"},
+		{typeStr: "uint64", value: uint64(1 << 32), expected: "cf0000000100000000"},
+		{typeStr: "ufixed64x2", value: uint64(1234), expected: "cd04d2"},
+		{typeStr: "uint128", value: largeUint, expected: "c410ffffffffffffffffffffffffffffffff"},
+		{typeStr: "uint128", value: big.NewInt(1), expected: "c41000000000000000000000000000000001"},
+		{typeStr: "string", value: "abc", expected: "a3616263"},
+		{typeStr: "string", value: strings.Repeat("a", 32), expected: "d920" + strings.Repeat("61", 32)},
+		{typeStr: "address", value: make([]byte, 32), expected: "c420" + strings.Repeat("00", 32)},
+		{typeStr: "byte[]", value: []byte{1, 2, 3}, expected: "c403010203"},
+		{typeStr: "byte[2]", value: [2]byte{1, 2}, expected: "c4020102"},
+		{typeStr: "uint8[]", value: []uint8{1, 2, 3}, expected: "93010203"},
+		{typeStr: "bool[]", value: make([]bool, 16), expected: "dc0010" + strings.Repeat("c2", 16)},
+		{typeStr: "(bool,string,uint64[])", value: []interface{}{true, "a", []uint64{1}}, expected: "93c3a1619101"},
+		{typeStr: "()", value: []interface{}{}, expected: "90"},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(fmt.Sprintf("i=%d", i), func(t *testing.T) {
+			t.Parallel()
+			abiT, err := TypeOf(testCase.typeStr)
+			require.NoError(t, err)
+			actual, err := abiT.MarshalToMsgpack(testCase.value)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, hex.EncodeToString(actual))
+
+			// the result must match Decode(Encode(value))
+			encoded, err := abiT.Encode(testCase.value)
+			require.NoError(t, err)
+			expected, err := abiT.Decode(encoded)
+			require.NoError(t, err)
+			decoded, err := abiT.UnmarshalFromMsgpack(actual)
+			require.NoError(t, err)
+			require.Equal(t, expected, decoded)
+		})
+	}
+}
+
+func TestMsgpackNamedTuple(t *testing.T) {
+	t.Parallel()
+
+	abiT, err := MakeNamedTupleType([]Type{mustTypeOf(t, "uint64"), mustTypeOf(t, "bool")}, []string{"b", "a"})
+	require.NoError(t, err)
+
+	actual, err := abiT.MarshalToMsgpack(map[string]interface{}{"a": true, "b": uint64(5)})
+	require.NoError(t, err)
+	require.Equal(t, "82a16205a161c3", hex.EncodeToString(actual))
+
+	decoded, err := abiT.UnmarshalFromMsgpack(actual)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{uint64(5), true}, decoded)
+
+	// field order in the input does not matter, and arrays are also accepted
+	for _, input := range []string{"82a161c3a16205", "9205c3"} {
+		encoded, err := hex.DecodeString(input)
+		require.NoError(t, err)
+		decoded, err := abiT.UnmarshalFromMsgpack(encoded)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{uint64(5), true}, decoded)
+	}
+}
+
+func TestUnmarshalFromMsgpack(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		typeStr  string
+		input    string
+		expected interface{}
+	}{
+		{typeStr: "uint64", input: "d3000000000000000a", expected: uint64(10)},
+		{typeStr: "uint64", input: "cc0a", expected: uint64(10)},
+		{typeStr: "uint16", input: "c40101", expected: uint16(1)},
+		{typeStr: "uint256", input: "05", expected: big.NewInt(5)},
+		{typeStr: "byte[]", input: "920102", expected: []interface{}{byte(1), byte(2)}},
+		{typeStr: "string", input: "da0001" + "61", expected: "a"},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(fmt.Sprintf("i=%d", i), func(t *testing.T) {
+			t.Parallel()
+			abiT, err := TypeOf(testCase.typeStr)
+			require.NoError(t, err)
+			encoded, err := hex.DecodeString(testCase.input)
+			require.NoError(t, err)
+			decoded, err := abiT.UnmarshalFromMsgpack(encoded)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, decoded)
+		})
+	}
+
+	errorCases := []struct {
+		typeStr string
+		input   string
+		err     string
+	}{
+		{typeStr: "uint8", input: "cd0100", err: "cannot cast MessagePack integer 256 to uint8"},
+		{typeStr: "uint64", input: "ff", err: "negative"},
+		{typeStr: "uint64", input: "d0ff", err: "negative"},
+		{typeStr: "uint64", input: "c3", err: "expected MessagePack integer"},
+		{typeStr: "uint8", input: "c4020001", err: "cannot cast MessagePack bin of length 2 to uint8"},
+		{typeStr: "byte", input: "cd0100", err: "cannot cast MessagePack integer 256 to byte"},
+		{typeStr: "bool", input: "00", err: "expected MessagePack bool"},
+		{typeStr: "address", input: "c40100", err: "address byte slice length not equal to 32 byte"},
+		{typeStr: "string", input: "a561", err: "unexpected end of MessagePack data"},
+		{typeStr: "uint8[2]", input: "910a", err: "MessagePack array element number != ABI array elem number"},
+		{typeStr: "(bool,bool)", input: "91c3", err: "MessagePack array element number != ABI tuple elem number"},
+		{typeStr: "uint8[]", input: "ddffffffff", err: "exceeds data size"},
+		{typeStr: "uint8[]", input: "dd0000000500", err: "unexpected end of MessagePack data"},
+		{typeStr: "bool", input: "c3c3", err: "unexpected data after MessagePack encoded bool value"},
+		{typeStr: "bool", input: "", err: "unexpected end of MessagePack data"},
+	}
+
+	for i, errorCase := range errorCases {
+		t.Run(fmt.Sprintf("error i=%d", i), func(t *testing.T) {
+			t.Parallel()
+			abiT, err := TypeOf(errorCase.typeStr)
+			require.NoError(t, err)
+			encoded, err := hex.DecodeString(errorCase.input)
+			require.NoError(t, err)
+			_, err = abiT.UnmarshalFromMsgpack(encoded)
+			require.ErrorContains(t, err, errorCase.err)
+		})
+	}
+
+	abiT, err := MakeNamedTupleType([]Type{mustTypeOf(t, "bool")}, []string{"a"})
+	require.NoError(t, err)
+	for input, expectedErr := range map[string]string{
+		"81a162c3":       `unknown field "b"`,
+		"82a161c3a161c3": `duplicate field "a"`,
+		"80":             `missing field "a"`,
+	} {
+		encoded, err := hex.DecodeString(input)
+		require.NoError(t, err)
+		_, err = abiT.UnmarshalFromMsgpack(encoded)
+		require.ErrorContains(t, err, expectedErr)
+	}
+}
+
+func TestRandomMsgpackRoundTrip(t *testing.T) {
+	t.Parallel()
+	testValuePool := make(map[TypeKind][]testUnit)
+	addPrimitiveRandomValues(t, &testValuePool)
+	addArrayRandomValues(t, &testValuePool)
+	addTupleRandomValues(t, String, &testValuePool)
+	addTupleRandomValues(t, Tuple, &testValuePool)
+	for _, testUnit := range testValuePool[Tuple] {
+		abiT, err := TypeOf(testUnit.serializedType)
+		require.NoError(t, err)
+		encoded, err := abiT.MarshalToMsgpack(testUnit.value)
+		require.NoError(t, err)
+		decoded, err := abiT.UnmarshalFromMsgpack(encoded)
+		require.NoError(t, err)
+		abiEncoded, err := abiT.Encode(testUnit.value)
+		require.NoError(t, err)
+		expected, err := abiT.Decode(abiEncoded)
+		require.NoError(t, err)
+		require.Equal(t, expected, decoded)
+	}
+}
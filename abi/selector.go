@@ -0,0 +1,96 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Selector is a method, event, or interface selector: the first 4 bytes of the SHA-512/256 hash of
+// a signature.
+type Selector [MethodSelectorLength]byte
+
+// String returns the selector as a hex string, such as 8aa3b61f.
+func (s Selector) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// Hex returns the selector as a 0x-prefixed hex string, such as 0x8aa3b61f, the form of byte
+// constants in TEAL.
+func (s Selector) Hex() string {
+	return "0x" + s.String()
+}
+
+// Base64 returns the selector as a standard base64 string, such as iqO2Hw==, the form of
+// application arguments in the algod REST API.
+func (s Selector) Base64() string {
+	return base64.StdEncoding.EncodeToString(s[:])
+}
+
+// Bytes returns a copy of the selector bytes.
+func (s Selector) Bytes() []byte {
+	return append([]byte(nil), s[:]...)
+}
+
+// SelectorFromHex parses a selector from a hex string, such as 8aa3b61f, optionally prefixed with
+// 0x as in TEAL byte constants.
+func SelectorFromHex(hexString string) (Selector, error) {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(hexString, "0x"), "0X")
+	decoded, err := hex.DecodeString(trimmed)
+	if err != nil {
+		return Selector{}, fmt.Errorf("cannot decode selector %q: %w", hexString, err)
+	}
+	return SelectorFromBytes(decoded)
+}
+
+// SelectorFromBase64 parses a selector from a standard base64 string, such as iqO2Hw==.
+func SelectorFromBase64(base64String string) (Selector, error) {
+	decoded, err := base64.StdEncoding.DecodeString(base64String)
+	if err != nil {
+		return Selector{}, fmt.Errorf("cannot decode selector %q: %w", base64String, err)
+	}
+	return SelectorFromBytes(decoded)
+}
+
+// SelectorFromBytes returns the selector made of the given bytes, which must be exactly
+// MethodSelectorLength long. Use `Selector.Matches` to check the selector of longer data such as
+// application arguments.
+func SelectorFromBytes(selectorBytes []byte) (Selector, error) {
+	if len(selectorBytes) != MethodSelectorLength {
+		return Selector{}, fmt.Errorf("selector should be length %d, got %d", MethodSelectorLength, len(selectorBytes))
+	}
+	return Selector(selectorBytes), nil
+}
+
+// ComputeSelectors returns the selector of each method signature, keyed by the signature as given.
+// Signatures are validated and normalized as by `NormalizeMethodSignature`, so equivalent
+// signatures have the same selector. The returned error joins an error for every invalid
+// signature, and the selectors of the valid signatures are returned regardless.
+func ComputeSelectors(signatures []string) (map[string]Selector, error) {
+	selectors := make(map[string]Selector, len(signatures))
+	var errs []error
+	for i, signature := range signatures {
+		normalized, err := NormalizeMethodSignature(signature)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid signature at index %d: %w", i, err))
+			continue
+		}
+		selectors[signature] = computeSelector(normalized)
+	}
+	return selectors, errors.Join(errs...)
+}
+
+// Compare returns -1, 0, or 1 if the selector is respectively less than, equal to, or greater than
+// other, comparing bytes in order.
+func (s Selector) Compare(other Selector) int {
+	return bytes.Compare(s[:], other[:])
+}
+
+// Matches reports whether data, such as an application argument or a log, starts with the
+// selector.
+func (s Selector) Matches(data []byte) bool {
+	return bytes.HasPrefix(data, s[:])
+}
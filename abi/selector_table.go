@@ -0,0 +1,46 @@
+package abi
+
+// SelectorTableEntry is a method selector and the signature of the method it selects.
+type SelectorTableEntry struct {
+	// Selector is the selector of the method.
+	Selector Selector
+	// Signature is the signature of the method, as returned by `Method.GetSignature`.
+	Signature string
+}
+
+// Hex returns the selector as a 0x-prefixed hex string, such as 0x8aa3b61f, the form of byte
+// constants in TEAL.
+func (e SelectorTableEntry) Hex() string {
+	return e.Selector.Hex()
+}
+
+// Base64 returns the selector as a standard base64 string, the form of application arguments in
+// the algod REST API.
+func (e SelectorTableEntry) Base64() string {
+	return e.Selector.Base64()
+}
+
+// SelectorTable is an ordered list of method selectors, as returned by `Contract.SelectorTable`.
+type SelectorTable []SelectorTableEntry
+
+// Signature returns the signature of the method with the given selector, and whether the table
+// contains the selector.
+func (t SelectorTable) Signature(selector Selector) (string, bool) {
+	for _, entry := range t {
+		if entry.Selector == selector {
+			return entry.Signature, true
+		}
+	}
+	return "", false
+}
+
+// SelectorTable returns the selector and signature of each method of the contract, in the order
+// the methods are declared. It is intended for generating the method routers of applications and
+// for checking deployed routers against the contract.
+func (c Contract) SelectorTable() SelectorTable {
+	table := make(SelectorTable, len(c.Methods))
+	for i, method := range c.Methods {
+		table[i] = SelectorTableEntry{Selector: method.GetSelector(), Signature: method.GetSignature()}
+	}
+	return table
+}
@@ -0,0 +1,35 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractSelectorTable(t *testing.T) {
+	t.Parallel()
+
+	contract := Contract{
+		Name: "Calculator",
+		Methods: []Method{
+			{Name: "sub", Args: []MethodArg{{Type: "uint64"}, {Type: "uint64"}}, Returns: MethodReturn{Type: "uint64"}},
+			{Name: "add", Args: []MethodArg{{Type: "uint64"}, {Type: "uint64"}}, Returns: MethodReturn{Type: "uint128"}},
+		},
+	}
+	table := contract.SelectorTable()
+	require.Len(t, table, 2)
+	require.Equal(t, "sub(uint64,uint64)uint64", table[0].Signature)
+	require.Equal(t, contract.Methods[0].GetSelector(), table[0].Selector)
+	require.Equal(t, SelectorTableEntry{Selector: [4]byte{0x8a, 0xa3, 0xb6, 0x1f}, Signature: "add(uint64,uint64)uint128"}, table[1])
+
+	require.Equal(t, "0x8aa3b61f", table[1].Hex())
+	require.Equal(t, "iqO2Hw==", table[1].Base64())
+
+	signature, ok := table.Signature([4]byte{0x8a, 0xa3, 0xb6, 0x1f})
+	require.True(t, ok)
+	require.Equal(t, "add(uint64,uint64)uint128", signature)
+	_, ok = table.Signature([4]byte{})
+	require.False(t, ok)
+
+	require.Empty(t, Contract{Name: "Empty"}.SelectorTable())
+}
@@ -0,0 +1,83 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelector(t *testing.T) {
+	t.Parallel()
+
+	selector := Selector{0x8a, 0xa3, 0xb6, 0x1f}
+	require.Equal(t, "8aa3b61f", selector.String())
+
+	parsed, err := SelectorFromHex("8aa3b61f")
+	require.NoError(t, err)
+	require.Equal(t, selector, parsed)
+	parsed, err = SelectorFromHex("8AA3B61F")
+	require.NoError(t, err)
+	require.Equal(t, selector, parsed)
+
+	_, err = SelectorFromHex("8aa3b6")
+	require.EqualError(t, err, "selector should be length 4, got 3")
+	_, err = SelectorFromHex("8aa3b61g")
+	require.ErrorContains(t, err, `cannot decode selector "8aa3b61g"`)
+
+	parsed, err = SelectorFromHex("0x8aa3b61f")
+	require.NoError(t, err)
+	require.Equal(t, selector, parsed)
+	_, err = SelectorFromHex("0x")
+	require.EqualError(t, err, "selector should be length 4, got 0")
+
+	require.Equal(t, "0x8aa3b61f", selector.Hex())
+	require.Equal(t, "iqO2Hw==", selector.Base64())
+	parsed, err = SelectorFromBase64(selector.Base64())
+	require.NoError(t, err)
+	require.Equal(t, selector, parsed)
+	_, err = SelectorFromBase64("iqO2Hw")
+	require.ErrorContains(t, err, `cannot decode selector "iqO2Hw"`)
+	_, err = SelectorFromBase64("iqO2Hwo=")
+	require.EqualError(t, err, "selector should be length 4, got 5")
+
+	selectorBytes := selector.Bytes()
+	require.Equal(t, []byte{0x8a, 0xa3, 0xb6, 0x1f}, selectorBytes)
+	selectorBytes[0] = 0
+	require.Equal(t, byte(0x8a), selector[0])
+	parsed, err = SelectorFromBytes([]byte{0x8a, 0xa3, 0xb6, 0x1f})
+	require.NoError(t, err)
+	require.Equal(t, selector, parsed)
+	_, err = SelectorFromBytes([]byte{0x8a, 0xa3, 0xb6, 0x1f, 0x00})
+	require.EqualError(t, err, "selector should be length 4, got 5")
+
+	require.Equal(t, 0, selector.Compare(parsed))
+	require.Equal(t, -1, Selector{0x8a, 0xa3, 0xb6, 0x1e}.Compare(selector))
+	require.Equal(t, 1, selector.Compare(Selector{0x01, 0xff, 0xff, 0xff}))
+
+	require.True(t, selector.Matches([]byte{0x8a, 0xa3, 0xb6, 0x1f}))
+	require.True(t, selector.Matches([]byte{0x8a, 0xa3, 0xb6, 0x1f, 0x00}))
+	require.False(t, selector.Matches([]byte{0x8a, 0xa3, 0xb6}))
+	require.False(t, selector.Matches([]byte{0x8a, 0xa3, 0xb6, 0x1e}))
+}
+
+func TestComputeSelectors(t *testing.T) {
+	t.Parallel()
+
+	selectors, err := ComputeSelectors([]string{"add(uint64,uint64)uint128", "add(uint064, uint64)uint128", "optIn(pay,account)void"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]Selector{
+		"add(uint64,uint64)uint128":   {0x8a, 0xa3, 0xb6, 0x1f},
+		"add(uint064, uint64)uint128": {0x8a, 0xa3, 0xb6, 0x1f},
+		"optIn(pay,account)void":      computeSelector("optIn(pay,account)void"),
+	}, selectors)
+
+	selectors, err = ComputeSelectors([]string{"bad(uint7)void", "add(uint64,uint64)uint128", "nope"})
+	require.Equal(t, map[string]Selector{"add(uint64,uint64)uint128": {0x8a, 0xa3, 0xb6, 0x1f}}, selectors)
+	require.ErrorContains(t, err, "invalid signature at index 0: ")
+	require.ErrorContains(t, err, `invalid signature at index 2: No parenthesis in method signature: "nope"`)
+	require.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 2)
+
+	selectors, err = ComputeSelectors(nil)
+	require.NoError(t, err)
+	require.Empty(t, selectors)
+}
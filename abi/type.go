@@ -64,6 +64,9 @@ type Type struct {
 	*/
 	// NOTE may want to change back to uint32/uint64
 	staticLength uint16
+
+	// only can be applied to `tuple`, optional names of the tuple elements
+	fieldNames []string
 }
 
 // String serialize an ABI Type to a string in ABI encoding.
@@ -96,12 +99,18 @@ func (t Type) String() string {
 	}
 }
 
-var staticArrayRegexp = regexp.MustCompile(`^([a-z\d\[\](),]+)\[(0|[1-9][\d]*)]$`)
+var staticArrayRegexp = regexp.MustCompile(`^([a-zA-Z\d_ \[\](),]+)\[(0|[1-9][\d]*)]$`)
 var ufixedRegexp = regexp.MustCompile(`^ufixed([1-9][\d]*)x([1-9][\d]*)$`)
+var fieldNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z\d_]*$`)
 
 // TypeOf parses an ABI type string.
 // For example: `TypeOf("(uint64,byte[])")`
 //
+// The elements of a tuple may be followed by a space and a field name, as in
+// `TypeOf("(address owner,uint64 amount)")`, to make a named tuple type as MakeNamedTupleType does.
+// Either all or none of the elements of a tuple must be named. Field names are not part of the ABI
+// type, so String omits them.
+//
 // Note: this function only supports "basic" ABI types. Reference types and transaction types are
 // not supported and will produce an error.
 func TypeOf(str string) (Type, error) {
@@ -167,19 +176,40 @@ func TypeOf(str string) (Type, error) {
 			return Type{}, err
 		}
 		tupleTypes := make([]Type, len(tupleContent))
+		var fieldNames []string
 		for i := 0; i < len(tupleContent); i++ {
-			ti, err := TypeOf(tupleContent[i])
+			typeStr, fieldName := splitFieldName(tupleContent[i])
+			if i > 0 && (fieldName != "") != (len(fieldNames) != 0) {
+				return Type{}, fmt.Errorf(`tuple elements must be either all named or all unnamed: "%s"`, str)
+			}
+			if fieldName != "" {
+				fieldNames = append(fieldNames, fieldName)
+			}
+			ti, err := TypeOf(typeStr)
 			if err != nil {
 				return Type{}, err
 			}
 			tupleTypes[i] = ti
 		}
+		if fieldNames != nil {
+			return MakeNamedTupleType(tupleTypes, fieldNames)
+		}
 		return MakeTupleType(tupleTypes)
 	default:
 		return Type{}, fmt.Errorf(`cannot convert the string "%s" to an ABI type`, str)
 	}
 }
 
+// splitFieldName splits a tuple element of the form "<type> <name>" into its type and field name.
+// The field name is empty if the element is not named.
+func splitFieldName(str string) (string, string) {
+	space := strings.LastIndexByte(str, ' ')
+	if space < 0 || !fieldNameRegexp.MatchString(str[space+1:]) {
+		return str, ""
+	}
+	return str[:space], str[space+1:]
+}
+
 // segment keeps track of the start and end of a segment in a string.
 type segment struct{ left, right int }
 
@@ -296,6 +326,12 @@ var (
 
 	// stringType is ABI type constant for string
 	stringType = Type{kind: String}
+
+	// uint8Type is ABI type constant for uint8
+	uint8Type = Type{kind: Uint, bitSize: 8}
+
+	// uint64Type is ABI type constant for uint64
+	uint64Type = Type{kind: Uint, bitSize: 64}
 )
 
 // makeUfixedType makes `UFixed` ABI type by taking type bitSize and type precision as arguments.
@@ -345,7 +381,53 @@ func MakeTupleType(argumentTypes []Type) (Type, error) {
 	}, nil
 }
 
+// MakeNamedTupleType makes a tuple ABI type whose elements carry field names. The names do not
+// change the ABI encoding of the tuple, but they allow JSON values of the tuple to be represented
+// as objects keyed by field name.
+func MakeNamedTupleType(argumentTypes []Type, fieldNames []string) (Type, error) {
+	if len(argumentTypes) != len(fieldNames) {
+		return Type{}, fmt.Errorf("named tuple type has %d child types but %d field names", len(argumentTypes), len(fieldNames))
+	}
+	seen := make(map[string]bool, len(fieldNames))
+	for i, name := range fieldNames {
+		if name == "" {
+			return Type{}, fmt.Errorf("named tuple field name at index %d is empty", i)
+		}
+		if seen[name] {
+			return Type{}, fmt.Errorf(`named tuple field name "%s" is duplicated`, name)
+		}
+		seen[name] = true
+	}
+	tupleType, err := MakeTupleType(argumentTypes)
+	if err != nil {
+		return Type{}, err
+	}
+	tupleType.fieldNames = append([]string(nil), fieldNames...)
+	return tupleType, nil
+}
+
+// FieldNames returns the element names of a named tuple type, or nil if the type is not a named
+// tuple.
+func (t Type) FieldNames() []string {
+	if len(t.fieldNames) == 0 {
+		return nil
+	}
+	return append([]string(nil), t.fieldNames...)
+}
+
+// fieldIndex returns the position of the named tuple element called name, or -1 if there is none.
+func (t Type) fieldIndex(name string) int {
+	for i, fieldName := range t.fieldNames {
+		if fieldName == name {
+			return i
+		}
+	}
+	return -1
+}
+
 // Equal method decides the equality of two types: t == t0.
+//
+// Tuple field names are not part of the ABI type, so they are ignored by this method.
 func (t Type) Equal(t0 Type) bool {
 	if t.kind != t0.kind {
 		return false
@@ -514,5 +596,47 @@ func IsReferenceType(s string) bool {
 	}
 }
 
+// ArgKind classifies the type of a method argument.
+type ArgKind int
+
+const (
+	// ValueArg is the kind of arguments with an ABI type, whose values are encoded in the
+	// application arguments of a method call.
+	ValueArg ArgKind = iota
+	// ReferenceArg is the kind of reference type arguments, such as "account", whose values are
+	// indexes into a foreign array of the method call.
+	ReferenceArg
+	// TransactionArg is the kind of transaction type arguments, such as "pay", whose values are
+	// transactions preceding the method call in its group.
+	TransactionArg
+)
+
+// String returns the name of the argument kind.
+func (k ArgKind) String() string {
+	switch k {
+	case ValueArg:
+		return "value"
+	case ReferenceArg:
+		return "reference"
+	case TransactionArg:
+		return "transaction"
+	default:
+		return fmt.Sprintf("ArgKind(%d)", int(k))
+	}
+}
+
+// ArgKindOf returns the kind of a method argument type string. Strings which are neither reference
+// nor transaction types are classified as ValueArg, whether or not they are valid ABI types.
+func ArgKindOf(argType string) ArgKind {
+	switch {
+	case IsReferenceType(argType):
+		return ReferenceArg
+	case IsTransactionType(argType):
+		return TransactionArg
+	default:
+		return ValueArg
+	}
+}
+
 // VoidReturnType is the ABI return type string for a method that does not return any value
 const VoidReturnType = "void"
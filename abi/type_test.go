@@ -721,3 +721,82 @@ func TestTypeMISC(t *testing.T) {
 		byteLenTestCount++
 	}
 }
+
+func TestMakeNamedTupleType(t *testing.T) {
+	t.Parallel()
+
+	uint64Type, err := TypeOf("uint64")
+	require.NoError(t, err)
+
+	named, err := MakeNamedTupleType([]Type{addressType, uint64Type}, []string{"owner", "amount"})
+	require.NoError(t, err)
+	require.Equal(t, "(address,uint64)", named.String())
+	require.Equal(t, []string{"owner", "amount"}, named.FieldNames())
+
+	unnamed, err := TypeOf("(address,uint64)")
+	require.NoError(t, err)
+	require.Nil(t, unnamed.FieldNames())
+	require.True(t, named.Equal(unnamed), "field names should not affect type equality")
+
+	_, err = MakeNamedTupleType([]Type{addressType}, []string{"owner", "amount"})
+	require.ErrorContains(t, err, "1 child types but 2 field names")
+
+	_, err = MakeNamedTupleType([]Type{addressType, uint64Type}, []string{"owner", ""})
+	require.ErrorContains(t, err, "field name at index 1 is empty")
+
+	_, err = MakeNamedTupleType([]Type{addressType, uint64Type}, []string{"owner", "owner"})
+	require.ErrorContains(t, err, `field name "owner" is duplicated`)
+}
+
+func TestTypeOfNamedTuple(t *testing.T) {
+	t.Parallel()
+
+	named, err := TypeOf("(address owner,uint64 amount)")
+	require.NoError(t, err)
+	require.Equal(t, "(address,uint64)", named.String())
+	require.Equal(t, []string{"owner", "amount"}, named.FieldNames())
+	uint64Type, err := TypeOf("uint64")
+	require.NoError(t, err)
+	expected, err := MakeNamedTupleType([]Type{addressType, uint64Type}, []string{"owner", "amount"})
+	require.NoError(t, err)
+	require.Equal(t, expected, named)
+
+	nested, err := TypeOf("(uint8[] ids,(bool is_set,byte Flag_2) inner,(uint16,string)[2] pairs)[]")
+	require.NoError(t, err)
+	require.Equal(t, "(uint8[],(bool,byte),(uint16,string)[2])[]", nested.String())
+	tuple := nested.childTypes[0]
+	require.Equal(t, []string{"ids", "inner", "pairs"}, tuple.FieldNames())
+	require.Equal(t, []string{"is_set", "Flag_2"}, tuple.childTypes[1].FieldNames())
+	require.Nil(t, tuple.childTypes[2].childTypes[0].FieldNames())
+
+	staticArray, err := TypeOf("(address owner,uint64 amount)[3]")
+	require.NoError(t, err)
+	require.Equal(t, []string{"owner", "amount"}, staticArray.childTypes[0].FieldNames())
+
+	// named tuples decode from JSON objects
+	flags, err := TypeOf("(bool set,uint64 count)")
+	require.NoError(t, err)
+	value, err := flags.UnmarshalFromJSON([]byte(`{"count": 5, "set": true}`))
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{true, uint64(5)}, value)
+	_, err = flags.UnmarshalFromJSON([]byte(`{"count": 5, "set": true, "other": 1}`))
+	require.Error(t, err)
+
+	invalid := []string{
+		"(address owner,uint64)",
+		"(address,uint64 amount)",
+		"(address owner,uint64 owner)",
+		"(address 1owner,uint64 amount)",
+		"(address owner-id,uint64 amount)",
+		"(address  owner,uint64 amount)",
+		"(address owner ,uint64 amount)",
+		"(address owner)[-1]",
+		"(address owner) named",
+	}
+	for _, typeStr := range invalid {
+		_, err := TypeOf(typeStr)
+		require.Error(t, err, typeStr)
+	}
+	_, err = TypeOf("(address owner,uint64)")
+	require.EqualError(t, err, `tuple elements must be either all named or all unnamed: "(address owner,uint64)"`)
+}
@@ -0,0 +1,41 @@
+package abi
+
+import (
+	"fmt"
+)
+
+// Value is a Go value bound to the ABI type it represents.
+//
+// Value implements json.Marshaler and json.Unmarshaler using its bound type, so it can be embedded in
+// larger structs which are converted with the encoding/json package. Type must be set before a Value
+// is unmarshaled.
+type Value struct {
+	// Type is the ABI type of the value.
+	Type Type
+	// Value is the Go value, in any form accepted by Type's `Encode` method.
+	Value interface{}
+	// Options controls the JSON representation of the value.
+	Options JSONOptions
+}
+
+// MarshalJSON implements json.Marshaler for Value.
+func (v Value) MarshalJSON() ([]byte, error) {
+	if v.Type.kind == InvalidType {
+		return nil, fmt.Errorf("cannot marshal ABI value to JSON: type is not set")
+	}
+	return v.Type.MarshalToJSONWithOptions(v.Value, v.Options)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Value. The decoded value has the form documented by
+// Type's `Decode` method.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	if v.Type.kind == InvalidType {
+		return fmt.Errorf("cannot unmarshal ABI value from JSON: type is not set")
+	}
+	value, err := v.Type.UnmarshalFromJSONWithOptions(data, v.Options)
+	if err != nil {
+		return err
+	}
+	v.Value = value
+	return nil
+}
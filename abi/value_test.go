@@ -0,0 +1,46 @@
+package abi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueJSON(t *testing.T) {
+	t.Parallel()
+
+	abiT, err := TypeOf("(uint64,string)")
+	require.NoError(t, err)
+	uint64Type, err := TypeOf("uint64")
+	require.NoError(t, err)
+
+	type response struct {
+		Round  uint64 `json:"round"`
+		Result Value  `json:"result"`
+		Big    Value  `json:"big"`
+	}
+
+	resp := response{
+		Round:  10,
+		Result: Value{Type: abiT, Value: []interface{}{uint64(7), "seven"}},
+		Big:    Value{Type: uint64Type, Value: uint64(1 << 60), Options: JSONOptions{JSSafeIntegers: true}},
+	}
+	encoded, err := json.Marshal(resp)
+	require.NoError(t, err)
+	require.Equal(t, `{"round":10,"result":[7,"seven"],"big":"1152921504606846976"}`, string(encoded))
+
+	decoded := response{
+		Result: Value{Type: abiT},
+		Big:    Value{Type: uint64Type, Options: JSONOptions{JSSafeIntegers: true}},
+	}
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, resp, decoded)
+
+	var unbound response
+	err = json.Unmarshal(encoded, &unbound)
+	require.ErrorContains(t, err, "type is not set")
+
+	_, err = json.Marshal(unbound)
+	require.ErrorContains(t, err, "type is not set")
+}
@@ -1,14 +1,22 @@
 /*
 Package address provides the ability to convert between 32 byte Algorand addresses and their base32
-string form.
+string form, and to derive the addresses of applications, multisig accounts, and logic signatures.
 */
 package address
 
 import (
 	"bytes"
+	"crypto/rand"
 	"crypto/sha512"
+	"crypto/subtle"
+	"database/sql/driver"
 	"encoding/base32"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 )
 
 // BytesSize is the size of an Algorand address in bytes. This is NOT the size of the base32 string
@@ -18,6 +26,271 @@ const checksumBytesSize = 4
 
 var base32Encoder = base32.StdEncoding.WithPadding(base32.NoPadding)
 
+// Errors returned, wrapped with context, for invalid addresses. Use errors.Is to tell them apart.
+var (
+	// ErrWrongLength is returned for addresses, or strings, which are not the length of an address.
+	ErrWrongLength = errors.New("address has the wrong length")
+	// ErrNotBase32 is returned for address strings with characters outside of the base32 alphabet.
+	ErrNotBase32 = errors.New("address string is not base32")
+	// ErrBadChecksum is returned for address strings whose checksum does not match the address.
+	ErrBadChecksum = errors.New("address checksum mismatch")
+	// ErrNotCanonical is returned by FromStringStrict for address strings which decode to a valid
+	// address but are not the string form of that address.
+	ErrNotCanonical = errors.New("address string is not canonical")
+)
+
+// stringSize is the length of the base32 string form of an address and its checksum.
+const stringSize = ((BytesSize+checksumBytesSize)*8 + 4) / 5
+
+// base32Value returns the 5 bit value of a character of the standard base32 alphabet, and whether
+// the character is in the alphabet.
+func base32Value(c byte) (byte, bool) {
+	switch {
+	case c >= 'A' && c <= 'Z':
+		return c - 'A', true
+	case c >= '2' && c <= '7':
+		return c - '2' + 26, true
+	default:
+		return 0, false
+	}
+}
+
+// stringProblem is the reason a string is not a valid address, as found by checkString.
+type stringProblem int
+
+const (
+	noProblem stringProblem = iota
+	wrongStringLength
+	notBase32
+	badChecksum
+)
+
+// checkString checks that addressString is the string form of an address with a valid checksum,
+// without allocating. For strings which are not base32, it also returns the index of the first
+// invalid character.
+func checkString(addressString string) (stringProblem, int) {
+	if len(addressString) != stringSize {
+		return wrongStringLength, 0
+	}
+	var decoded [BytesSize + checksumBytesSize]byte
+	var buffer uint16
+	bits, index := 0, 0
+	for i := 0; i < len(addressString); i++ {
+		value, ok := base32Value(addressString[i])
+		if !ok {
+			return notBase32, i
+		}
+		buffer = buffer<<5 | uint16(value)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			decoded[index] = byte(buffer >> bits)
+			index++
+		}
+	}
+	hashed := sha512.Sum512_256(decoded[:BytesSize])
+	if !bytes.Equal(hashed[BytesSize-checksumBytesSize:], decoded[BytesSize:]) {
+		return badChecksum, 0
+	}
+	return noProblem, 0
+}
+
+// IsValid reports whether addressString is the string form of an address with a valid checksum.
+// It accepts the same strings as FromString, except those containing line endings, which the
+// base32 decoder of FromString skips, but does not allocate, so it suits hot validation paths which
+// do not need the address bytes. Like FromString, it ignores the unused bits of the last character;
+// use FromStringStrict to only accept canonical strings.
+func IsValid(addressString string) bool {
+	problem, _ := checkString(addressString)
+	return problem == noProblem
+}
+
+// VerifyChecksum checks that addressString is the string form of an address with a valid
+// checksum, without decoding the address. It accepts the same strings as IsValid, so strings
+// containing line endings are rejected with ErrWrongLength or ErrNotBase32, and returns an error
+// describing why other strings are invalid.
+func VerifyChecksum(addressString string) error {
+	switch problem, index := checkString(addressString); problem {
+	case wrongStringLength:
+		return fmt.Errorf("%w: address string should be %d characters long, got %d", ErrWrongLength, stringSize, len(addressString))
+	case notBase32:
+		return fmt.Errorf("%w: non-base32 character %q at index %d", ErrNotBase32, addressString[index], index)
+	case badChecksum:
+		return fmt.Errorf("%w in address string (%s)", ErrBadChecksum, addressString)
+	default:
+		return nil
+	}
+}
+
+// Address is a 32 byte Algorand address.
+type Address [BytesSize]byte
+
+// ZeroAddress is the all-zero address, which transaction fields such as the close-to and rekey-to
+// addresses use to mean that they are not set.
+var ZeroAddress Address
+
+// ZeroAddressString is the string form of ZeroAddress.
+const ZeroAddressString = "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAY5HFKQ"
+
+// FromBytes returns the address made of the given bytes, which must be exactly BytesSize long.
+func FromBytes(addressBytes []byte) (Address, error) {
+	if len(addressBytes) != BytesSize {
+		return Address{}, fmt.Errorf("%w: should be %d bytes long, got %d", ErrWrongLength, BytesSize, len(addressBytes))
+	}
+	return Address(addressBytes), nil
+}
+
+// Random returns an address made of BytesSize bytes read from r, or from crypto/rand.Reader if r
+// is nil. The address is valid but has no known private key, so it suits tests and fixtures which
+// need distinct addresses, not accounts which can sign transactions.
+func Random(r io.Reader) (Address, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	var addr Address
+	if _, err := io.ReadFull(r, addr[:]); err != nil {
+		return Address{}, fmt.Errorf("cannot read random address: %w", err)
+	}
+	return addr, nil
+}
+
+// IsZero reports whether the address is the zero address.
+func (a Address) IsZero() bool {
+	return a == ZeroAddress
+}
+
+// Compare returns -1, 0, or 1 if a is respectively less than, equal to, or greater than b,
+// comparing bytes in order. It can be used with slices.SortFunc and slices.BinarySearchFunc.
+func Compare(a, b Address) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+// Less reports whether a is less than b, comparing bytes in order as Compare.
+func Less(a, b Address) bool {
+	return Compare(a, b) < 0
+}
+
+// EqualConstantTime reports whether a and b are equal, in a time which does not depend on their
+// bytes, unlike the == operator. Use it to compare an address which an attacker controls with a
+// secret or authorizing address.
+func EqualConstantTime(a, b Address) bool {
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
+
+// String returns the checksummed base32 string form of the address.
+func (a Address) String() string {
+	return ToString(a)
+}
+
+// ellipsis separates the head and tail of addresses shortened by Short.
+const ellipsis = "\u2026"
+
+// Short returns a display form of the address made of the first headLen and last tailLen
+// characters of its string form separated by an ellipsis, such as CAFFDS…74JAFM. The full string
+// is returned if it is not longer than headLen + tailLen characters.
+func Short(addressBytes [BytesSize]byte, headLen, tailLen int) string {
+	addressString := ToString(addressBytes)
+	headLen, tailLen = max(headLen, 0), max(tailLen, 0)
+	if headLen+tailLen >= len(addressString) {
+		return addressString
+	}
+	return addressString[:headLen] + ellipsis + addressString[len(addressString)-tailLen:]
+}
+
+// Short returns a display form of the address, as returned by the Short function.
+func (a Address) Short(headLen, tailLen int) string {
+	return Short(a, headLen, tailLen)
+}
+
+// Format implements fmt.Formatter. The %s and %v verbs print the string form of the address, and
+// %q prints it quoted. A precision, as in %.12s, prints the short display form with that many
+// characters of the string form, taking the extra character from the start if the precision is
+// odd. Widths and flags apply as for strings. The %x and %X verbs print the address bytes in hex,
+// as for byte slices, and %#v prints the address as a Go value.
+func (a Address) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, "address.Address(%#v)", [BytesSize]byte(a))
+	case verb == 's' || verb == 'v' || verb == 'q':
+		addressString := a.String()
+		if precision, ok := f.Precision(); ok {
+			addressString = a.Short(precision-precision/2, precision/2)
+		}
+		if verb == 'v' {
+			verb = 's'
+		}
+		fmt.Fprintf(f, formatWithoutPrecision(f, verb), addressString)
+	case verb == 'x' || verb == 'X':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), a[:])
+	default:
+		fmt.Fprintf(f, "%%!%c(address.Address=%s)", verb, a.String())
+	}
+}
+
+// formatWithoutPrecision returns the format directive of the flags and width of f, and verb.
+func formatWithoutPrecision(f fmt.State, verb rune) string {
+	var b strings.Builder
+	b.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			b.WriteRune(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		b.WriteString(strconv.Itoa(width))
+	}
+	b.WriteRune(verb)
+	return b.String()
+}
+
+// MarshalText encodes the address in its checksummed base32 string form.
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText parses an address from its checksummed base32 string form.
+func (a *Address) UnmarshalText(text []byte) error {
+	addressBytes, err := FromString(string(text))
+	if err != nil {
+		return err
+	}
+	*a = addressBytes
+	return nil
+}
+
+// MarshalBinary encodes the address as its raw BytesSize bytes.
+func (a Address) MarshalBinary() ([]byte, error) {
+	return a[:], nil
+}
+
+// UnmarshalBinary decodes an address from its raw BytesSize bytes.
+func (a *Address) UnmarshalBinary(data []byte) error {
+	addr, err := FromBytes(data)
+	if err != nil {
+		return err
+	}
+	*a = addr
+	return nil
+}
+
+// MarshalJSON encodes the address as a JSON string of its checksummed base32 form.
+func (a Address) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON parses an address from a JSON string of its checksummed base32 form. A JSON null
+// leaves the address unchanged, as for other types.
+func (a *Address) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var addressString string
+	if err := json.Unmarshal(data, &addressString); err != nil {
+		return fmt.Errorf("address must be a JSON string: %w", err)
+	}
+	return a.UnmarshalText([]byte(addressString))
+}
+
 // Checksum computes the address checksum
 func Checksum(addressBytes [BytesSize]byte) []byte {
 	hashed := sha512.Sum512_256(addressBytes[:])
@@ -26,13 +299,31 @@ func Checksum(addressBytes [BytesSize]byte) []byte {
 
 // ToString converts a 32 byte Algorand address to a string
 func ToString(addressBytes [BytesSize]byte) string {
-	checksum := Checksum(addressBytes)
+	var buffer [stringSize]byte
+	return string(AppendString(buffer[:0], addressBytes))
+}
 
+// AppendString appends the string form of a 32 byte Algorand address to dst and returns the
+// extended buffer. It does not allocate if dst has room for the string form, so encoding many
+// addresses into a reused buffer is allocation free.
+func AppendString(dst []byte, addressBytes [BytesSize]byte) []byte {
+	hashed := sha512.Sum512_256(addressBytes[:])
+	return appendStringWithChecksum(dst, addressBytes, hashed[BytesSize-checksumBytesSize:])
+}
+
+// appendStringWithChecksum appends the string form of an address to dst, given the checksum of the
+// address.
+func appendStringWithChecksum(dst []byte, addressBytes [BytesSize]byte, checksum []byte) []byte {
 	var addressBytesAndChecksum [BytesSize + checksumBytesSize]byte
 	copy(addressBytesAndChecksum[:], addressBytes[:])
 	copy(addressBytesAndChecksum[BytesSize:], checksum)
 
-	return base32Encoder.EncodeToString(addressBytesAndChecksum[:])
+	return base32Encoder.AppendEncode(dst, addressBytesAndChecksum[:])
+}
+
+// AppendString appends the string form of the address to dst, as the AppendString function.
+func (a Address) AppendString(dst []byte) []byte {
+	return AppendString(dst, a)
 }
 
 // FromString converts a string to a 32 byte Algorand address
@@ -40,14 +331,14 @@ func FromString(addressString string) ([BytesSize]byte, error) {
 	decoded, err := base32Encoder.DecodeString(addressString)
 	if err != nil {
 		return [BytesSize]byte{},
-			fmt.Errorf("cannot cast encoded address string (%s) to address: base32 decode error: %w", addressString, err)
+			fmt.Errorf("cannot cast encoded address string (%s) to address: %w: %w", addressString, ErrNotBase32, err)
 	}
 	if len(decoded) != BytesSize+checksumBytesSize {
 		return [BytesSize]byte{},
 			fmt.Errorf(
 				"cannot cast encoded address string (%s) to address: "+
-					"decoded byte length should equal %d with address and checksum",
-				addressString, BytesSize+checksumBytesSize,
+					"%w: decoded byte length should equal %d with address and checksum",
+				addressString, ErrWrongLength, BytesSize+checksumBytesSize,
 			)
 	}
 	var addressBytes [BytesSize]byte
@@ -56,10 +347,112 @@ func FromString(addressString string) ([BytesSize]byte, error) {
 	checksum := Checksum(addressBytes)
 	if !bytes.Equal(checksum, decoded[BytesSize:]) {
 		return [BytesSize]byte{}, fmt.Errorf(
-			"cannot cast encoded address string (%s) to address: decoded checksum mismatch, %v != %v",
-			addressString, checksum, decoded[BytesSize:],
+			"cannot cast encoded address string (%s) to address: %w, %v != %v",
+			addressString, ErrBadChecksum, checksum, decoded[BytesSize:],
 		)
 	}
 
 	return addressBytes, nil
 }
+
+// FromStringStrict converts a string to a 32 byte Algorand address like FromString, but only
+// accepts the canonical string form of the address, as returned by ToString. The last character of
+// an address string has 2 unused low bits, and the base32 decoder skips line endings, which
+// FromString both ignores, so many strings decode to each address. Use FromStringStrict where
+// different strings must never be the same address, such as to deduplicate addresses by their
+// string form.
+func FromStringStrict(addressString string) ([BytesSize]byte, error) {
+	addressBytes, err := FromString(addressString)
+	if err != nil {
+		return [BytesSize]byte{}, err
+	}
+	if len(addressString) != stringSize {
+		return [BytesSize]byte{}, fmt.Errorf(
+			"cannot cast encoded address string (%q) to address: %w, it is %d characters long instead of %d",
+			addressString, ErrNotCanonical, len(addressString), stringSize,
+		)
+	}
+	if last := addressString[stringSize-1]; strings.IndexByte(lastCharacters, last) < 0 {
+		return [BytesSize]byte{}, fmt.Errorf(
+			"cannot cast encoded address string (%s) to address: %w, its last character %q has unused bits set",
+			addressString, ErrNotCanonical, last,
+		)
+	}
+	if ToString(addressBytes) != addressString {
+		return [BytesSize]byte{}, fmt.Errorf("cannot cast encoded address string (%q) to address: %w", addressString, ErrNotCanonical)
+	}
+	return addressBytes, nil
+}
+
+// Value stores the address in a database column in its checksummed base32 string form.
+func (a Address) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// Scan reads the address from a database column holding either its string form, or its 32 raw
+// bytes. Use a *Address or sql.Null[Address] destination for nullable columns.
+func (a *Address) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		return a.UnmarshalText([]byte(src))
+	case []byte:
+		// drivers may return text columns as bytes, which are never as short as raw addresses
+		if len(src) == BytesSize {
+			*a, _ = FromBytes(src)
+			return nil
+		}
+		return a.UnmarshalText(src)
+	case nil:
+		return fmt.Errorf("cannot scan NULL into an address")
+	default:
+		return fmt.Errorf("cannot scan %T into an address", src)
+	}
+}
+
+// FromStringLenient converts a string to a 32 byte Algorand address like FromString, but also
+// accepts lowercase letters and trailing "=" padding, which addresses copied from other tools
+// often have. The checksum is verified as by FromString.
+func FromStringLenient(addressString string) ([BytesSize]byte, error) {
+	return FromString(strings.ToUpper(strings.TrimRight(addressString, "=")))
+}
+
+// FromStrings converts strings to addresses. The returned error joins an error for every invalid
+// string, giving its index, and the addresses of invalid strings are left zero, so valid addresses
+// can still be used.
+func FromStrings(addressStrings []string) ([]Address, error) {
+	addresses := make([]Address, len(addressStrings))
+	var errs []error
+	for i, addressString := range addressStrings {
+		addressBytes, err := FromString(addressString)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid address at index %d: %w", i, err))
+			continue
+		}
+		addresses[i] = addressBytes
+	}
+	return addresses, errors.Join(errs...)
+}
+
+// ToStrings converts addresses to their string forms. It suits encoding many addresses, as in
+// exports: a single hasher computes all the checksums, and all the strings share a single
+// allocation, so retaining any of them retains the memory of all of them.
+func ToStrings(addresses []Address) []string {
+	var builder strings.Builder
+	builder.Grow(len(addresses) * stringSize)
+	hasher := sha512.New512_256()
+	checksum := make([]byte, 0, sha512.Size256)
+	var buffer [stringSize]byte
+	for i := range addresses {
+		hasher.Reset()
+		hasher.Write(addresses[i][:])
+		checksum = hasher.Sum(checksum[:0])
+		builder.Write(appendStringWithChecksum(buffer[:0], addresses[i], checksum[BytesSize-checksumBytesSize:]))
+	}
+	encoded := builder.String()
+
+	addressStrings := make([]string, len(addresses))
+	for i := range addressStrings {
+		addressStrings[i] = encoded[i*stringSize : (i+1)*stringSize]
+	}
+	return addressStrings
+}
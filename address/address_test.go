@@ -1,6 +1,12 @@
 package address
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -50,26 +56,31 @@ func TestAddress(t *testing.T) {
 		testCases := []struct {
 			addressString string
 			expectedError string
+			expectedIs    error
 		}{
 			{
 				// incorrect checksum
 				addressString: "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAQM",
-				expectedError: "decoded checksum mismatch",
+				expectedError: "address checksum mismatch",
+				expectedIs:    ErrBadChecksum,
 			},
 			{
 				// too many bytes
 				addressString: "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFMFM",
 				expectedError: "decoded byte length should equal 36 with address and checksum",
+				expectedIs:    ErrWrongLength,
 			},
 			{
 				// too few bytes
 				addressString: "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JA",
 				expectedError: "decoded byte length should equal 36 with address and checksum",
+				expectedIs:    ErrWrongLength,
 			},
 			{
 				// not base32
 				addressString: "!!!",
-				expectedError: "base32 decode error",
+				expectedError: "address string is not base32: illegal base32 data",
+				expectedIs:    ErrNotBase32,
 			},
 		}
 
@@ -77,7 +88,448 @@ func TestAddress(t *testing.T) {
 			t.Run(testCase.addressString, func(t *testing.T) {
 				_, err := FromString(testCase.addressString)
 				require.ErrorContains(t, err, testCase.expectedError)
+				require.ErrorIs(t, err, testCase.expectedIs)
 			})
 		}
 	})
 }
+
+func TestZeroAddress(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, ZeroAddress.IsZero())
+	require.True(t, Address{}.IsZero())
+	require.False(t, Address{31: 1}.IsZero())
+	require.Equal(t, ZeroAddressString, ZeroAddress.String())
+	require.Equal(t, ZeroAddressString, ToString(ZeroAddress))
+
+	parsed, err := FromString(ZeroAddressString)
+	require.NoError(t, err)
+	require.True(t, Address(parsed).IsZero())
+}
+
+func TestAddressMarshaling(t *testing.T) {
+	t.Parallel()
+
+	const addressString = "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM"
+	addr := Address{16, 10, 81, 202, 158, 158, 46, 209, 139, 213, 244, 123, 112, 56, 225, 176, 71, 198, 31, 126, 155, 105, 97, 91, 131, 241, 213, 95, 145, 71, 126, 247}
+
+	text, err := addr.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, addressString, string(text))
+	var fromText Address
+	require.NoError(t, fromText.UnmarshalText(text))
+	require.Equal(t, addr, fromText)
+	require.ErrorContains(t, fromText.UnmarshalText([]byte("!!!")), "address string is not base32")
+
+	type account struct {
+		Addr     Address            `json:"addr"`
+		Optional *Address           `json:"optional"`
+		ByAddr   map[Address]uint64 `json:"byAddr"`
+	}
+	encoded, err := json.Marshal(account{Addr: addr, ByAddr: map[Address]uint64{addr: 5}})
+	require.NoError(t, err)
+	require.Equal(t, `{"addr":"`+addressString+`","optional":null,"byAddr":{"`+addressString+`":5}}`, string(encoded))
+
+	var decoded account
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, account{Addr: addr, ByAddr: map[Address]uint64{addr: 5}}, decoded)
+
+	errorCases := []struct {
+		input string
+		err   string
+	}{
+		{input: `{"addr": 5}`, err: "address must be a JSON string"},
+		{input: `{"addr": "DAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM"}`, err: "address checksum mismatch"},
+		{input: `{"byAddr": {"!!!": 5}}`, err: "address string is not base32"},
+	}
+	for _, errorCase := range errorCases {
+		var decoded account
+		err := json.Unmarshal([]byte(errorCase.input), &decoded)
+		require.ErrorContains(t, err, errorCase.err, errorCase.input)
+	}
+}
+
+func TestAddressBinary(t *testing.T) {
+	t.Parallel()
+
+	addr := ForApplication(1)
+	data, err := addr.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, addr[:], data)
+
+	var decoded Address
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, addr, decoded)
+
+	// the decoded address does not share memory with the input
+	data[0]++
+	require.Equal(t, addr, decoded)
+
+	err = decoded.UnmarshalBinary(data[:BytesSize-1])
+	require.ErrorIs(t, err, ErrWrongLength)
+	require.Equal(t, addr, decoded)
+
+	type account struct {
+		Addr   Address
+		ByAddr map[Address]uint64
+	}
+	original := account{Addr: addr, ByAddr: map[Address]uint64{ZeroAddress: 5, addr: 6}}
+	var buffer bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buffer).Encode(original))
+	var fromGob account
+	require.NoError(t, gob.NewDecoder(&buffer).Decode(&fromGob))
+	require.Equal(t, original, fromGob)
+}
+
+func TestAddressSQL(t *testing.T) {
+	t.Parallel()
+
+	const addressString = "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM"
+	addr := Address{16, 10, 81, 202, 158, 158, 46, 209, 139, 213, 244, 123, 112, 56, 225, 176, 71, 198, 31, 126, 155, 105, 97, 91, 131, 241, 213, 95, 145, 71, 126, 247}
+
+	value, err := addr.Value()
+	require.NoError(t, err)
+	require.Equal(t, addressString, value)
+
+	for _, src := range []interface{}{addressString, []byte(addressString), addr[:]} {
+		var scanned Address
+		require.NoError(t, scanned.Scan(src))
+		require.Equal(t, addr, scanned)
+	}
+
+	var scanned Address
+	require.EqualError(t, scanned.Scan(nil), "cannot scan NULL into an address")
+	require.EqualError(t, scanned.Scan(int64(5)), "cannot scan int64 into an address")
+	require.ErrorContains(t, scanned.Scan(addr[:31]), "address string is not base32")
+	require.ErrorContains(t, scanned.Scan("!!!"), "address string is not base32")
+}
+
+func TestRandom(t *testing.T) {
+	t.Parallel()
+
+	addr, err := Random(bytes.NewReader(bytes.Repeat([]byte{7}, BytesSize+1)))
+	require.NoError(t, err)
+	require.Equal(t, Address(bytes.Repeat([]byte{7}, BytesSize)), addr)
+	require.True(t, IsValid(addr.String()))
+
+	_, err = Random(bytes.NewReader(make([]byte, BytesSize-1)))
+	require.EqualError(t, err, "cannot read random address: unexpected EOF")
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	_, err = Random(bytes.NewReader(nil))
+	require.ErrorIs(t, err, io.EOF)
+
+	first, err := Random(nil)
+	require.NoError(t, err)
+	second, err := Random(nil)
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+}
+
+func TestFromBytes(t *testing.T) {
+	t.Parallel()
+
+	addressBytes := make([]byte, BytesSize)
+	addressBytes[0] = 7
+	addr, err := FromBytes(addressBytes)
+	require.NoError(t, err)
+	require.Equal(t, Address{0: 7}, addr)
+
+	// the address does not share memory with the input
+	addressBytes[0] = 8
+	require.Equal(t, byte(7), addr[0])
+
+	_, err = FromBytes(addressBytes[:31])
+	require.EqualError(t, err, "address has the wrong length: should be 32 bytes long, got 31")
+	require.ErrorIs(t, err, ErrWrongLength)
+	_, err = FromBytes(append(addressBytes, 0))
+	require.EqualError(t, err, "address has the wrong length: should be 32 bytes long, got 33")
+	require.ErrorIs(t, err, ErrWrongLength)
+	_, err = FromBytes(nil)
+	require.EqualError(t, err, "address has the wrong length: should be 32 bytes long, got 0")
+	require.ErrorIs(t, err, ErrWrongLength)
+}
+
+func TestIsValid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []string{
+		"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM",
+		"OXV2VEY7QJUXGOHEVFSL7LTBMOTYI4VORBJ37CGCHKBPJSH6IZQMHDPFRA",
+		ZeroAddressString,
+		// the unused low bits of the last character are ignored, as by FromString
+		"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFN",
+		"DAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM",
+		"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JA",
+		"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFMFM",
+		"caffdsu6tyxndc6v6r5xaohbwbd4mh36tnuwcw4d6hkv7ekhp33q74jafm",
+		"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAF1",
+		"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAF=",
+		"",
+	}
+	for _, addressString := range testCases {
+		_, err := FromString(addressString)
+		require.Equal(t, err == nil, IsValid(addressString), addressString)
+	}
+
+	// unlike FromString, IsValid does not skip line endings
+	const canonical = "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM"
+	for _, addressString := range []string{"\n" + canonical, canonical + "\r\n", canonical[:20] + "\n" + canonical[20:]} {
+		_, err := FromString(addressString)
+		require.NoError(t, err)
+		require.False(t, IsValid(addressString), "%q", addressString)
+	}
+}
+
+// TestIsValidAllocations is not parallel, as testing.AllocsPerRun cannot measure parallel tests.
+func TestIsValidAllocations(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		IsValid("CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM")
+	})
+	require.Zero(t, allocs)
+}
+
+func TestFromStringLenient(t *testing.T) {
+	t.Parallel()
+
+	expected, err := FromString("CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM")
+	require.NoError(t, err)
+
+	for _, addressString := range []string{
+		"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM",
+		"caffdsu6tyxndc6v6r5xaohbwbd4mh36tnuwcw4d6hkv7ekhp33q74jafm",
+		"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM======",
+		"CaffDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM=",
+	} {
+		actual, err := FromStringLenient(addressString)
+		require.NoError(t, err, addressString)
+		require.Equal(t, expected, actual, addressString)
+	}
+
+	_, err = FromStringLenient("daffdsu6tyxndc6v6r5xaohbwbd4mh36tnuwcw4d6hkv7ekhp33q74jafm")
+	require.ErrorContains(t, err, "address checksum mismatch")
+	_, err = FromStringLenient("CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM=A")
+	require.ErrorContains(t, err, "address string is not base32")
+	_, err = FromStringLenient("======")
+	require.ErrorContains(t, err, "decoded byte length should equal 36")
+}
+
+func TestFromStringStrict(t *testing.T) {
+	t.Parallel()
+
+	const canonical = "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM"
+	expected, err := FromString(canonical)
+	require.NoError(t, err)
+	actual, err := FromStringStrict(canonical)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	// the last character only differs in its unused bits, which FromString ignores
+	for _, last := range "NOP" {
+		addressString := canonical[:stringSize-1] + string(last)
+		actual, err := FromString(addressString)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+
+		_, err = FromStringStrict(addressString)
+		require.ErrorIs(t, err, ErrNotCanonical)
+		require.ErrorContains(t, err, fmt.Sprintf("address string is not canonical, its last character '%c' has unused bits set", last))
+	}
+
+	// line endings are skipped by the base32 decoder of FromString
+	for _, addressString := range []string{
+		"\n" + canonical,
+		canonical + "\n",
+		canonical[:20] + "\r" + canonical[20:],
+		canonical[:20] + "\r\n" + canonical[20:stringSize-1] + "N",
+	} {
+		actual, err := FromString(addressString)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+
+		_, err = FromStringStrict(addressString)
+		require.ErrorIs(t, err, ErrNotCanonical, "%q", addressString)
+		require.ErrorContains(t, err, fmt.Sprintf("it is %d characters long instead of %d", len(addressString), stringSize))
+	}
+
+	_, err = FromStringStrict("DAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM")
+	require.ErrorIs(t, err, ErrBadChecksum)
+	_, err = FromStringStrict("")
+	require.ErrorIs(t, err, ErrWrongLength)
+	_, err = FromStringStrict("!!!")
+	require.ErrorIs(t, err, ErrNotBase32)
+
+	for appID := uint64(0); appID < 64; appID++ {
+		addr := ForApplication(appID)
+		actual, err := FromStringStrict(addr.String())
+		require.NoError(t, err)
+		require.Equal(t, addr, Address(actual))
+	}
+}
+
+func TestShort(t *testing.T) {
+	t.Parallel()
+
+	addr, err := FromString("CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM")
+	require.NoError(t, err)
+
+	require.Equal(t, "CAFFDS\u202674JAFM", Short(addr, 6, 6))
+	require.Equal(t, "CAFF\u2026", Short(addr, 4, 0))
+	require.Equal(t, "\u2026JAFM", Short(addr, -1, 4))
+	require.Equal(t, "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM", Short(addr, 29, 29))
+	require.Equal(t, "CAFFDS\u202674JAFM", Address(addr).Short(6, 6))
+
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{format: "%s", expected: "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM"},
+		{format: "%v", expected: "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM"},
+		{format: "%.12s", expected: "CAFFDS\u202674JAFM"},
+		{format: "%.5v", expected: "CAF\u2026FM"},
+		{format: "%-16.12s|", expected: "CAFFDS\u202674JAFM   |"},
+		{format: "%.60s", expected: "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM"},
+		{format: "%.8s", expected: "CAFF\u2026JAFM"},
+		{format: "%q", expected: `"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM"`},
+		{format: "%#q", expected: "`CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM`"},
+		{format: "%.8q", expected: "\"CAFF\u2026JAFM\""},
+		{format: "%x", expected: "100a51ca9e9e2ed18bd5f47b7038e1b047c61f7e9b69615b83f1d55f91477ef7"},
+		{format: "%X", expected: "100A51CA9E9E2ED18BD5F47B7038E1B047C61F7E9B69615B83F1D55F91477EF7"},
+		{format: "%#.4x", expected: "0x100a51ca"},
+		{format: "%d", expected: "%!d(address.Address=CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM)"},
+	}
+	for _, testCase := range testCases {
+		require.Equal(t, testCase.expected, fmt.Sprintf(testCase.format, Address(addr)), testCase.format)
+	}
+	require.Equal(t, "address.Address([32]uint8{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1})", fmt.Sprintf("%#v", Address{31: 1}))
+}
+
+func TestFromStrings(t *testing.T) {
+	t.Parallel()
+
+	addressStrings := []string{
+		"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM",
+		"OXV2VEY7QJUXGOHEVFSL7LTBMOTYI4VORBJ37CGCHKBPJSH6IZQMHDPFRA",
+		ZeroAddressString,
+	}
+	addresses, err := FromStrings(addressStrings)
+	require.NoError(t, err)
+	require.Len(t, addresses, 3)
+	for i, addressString := range addressStrings {
+		require.Equal(t, addressString, addresses[i].String())
+	}
+	require.Equal(t, addressStrings, ToStrings(addresses))
+
+	addresses, err = FromStrings([]string{"!!!", addressStrings[0], "DAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM"})
+	require.ErrorContains(t, err, "invalid address at index 0: ")
+	require.ErrorContains(t, err, "invalid address at index 2: ")
+	require.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 2)
+	require.Equal(t, []Address{{}, addresses[1], {}}, addresses)
+	require.Equal(t, addressStrings[0], addresses[1].String())
+
+	addresses, err = FromStrings(nil)
+	require.NoError(t, err)
+	require.Empty(t, addresses)
+	require.Empty(t, ToStrings(nil))
+}
+
+// TestToStringsAllocations is not parallel, as testing.AllocsPerRun cannot measure parallel tests.
+func TestToStringsAllocations(t *testing.T) {
+	addresses := make([]Address, 100)
+	for i := range addresses {
+		addresses[i] = ForApplication(uint64(i))
+	}
+	var addressStrings []string
+	allocs := testing.AllocsPerRun(10, func() {
+		addressStrings = ToStrings(addresses)
+	})
+	// one allocation for the slice and one for the strings
+	require.Equal(t, 2.0, allocs)
+	for i, addr := range addresses {
+		require.Equal(t, addr.String(), addressStrings[i])
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, VerifyChecksum("CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM"))
+	require.NoError(t, VerifyChecksum(ZeroAddressString))
+
+	errorCases := []struct {
+		addressString string
+		err           string
+		is            error
+	}{
+		{addressString: "DAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM", err: "address checksum mismatch in address string (DAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM)", is: ErrBadChecksum},
+		{addressString: "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JA", err: "address has the wrong length: address string should be 58 characters long, got 56", is: ErrWrongLength},
+		{addressString: "", err: "address has the wrong length: address string should be 58 characters long, got 0", is: ErrWrongLength},
+		{addressString: "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAF1", err: "address string is not base32: non-base32 character '1' at index 57", is: ErrNotBase32},
+		{addressString: "caffdsu6tyxndc6v6r5xaohbwbd4mh36tnuwcw4d6hkv7ekhp33q74jafm", err: "address string is not base32: non-base32 character 'c' at index 0", is: ErrNotBase32},
+		{addressString: "\nCAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM", err: "address has the wrong length: address string should be 58 characters long, got 59", is: ErrWrongLength},
+	}
+	for _, errorCase := range errorCases {
+		err := VerifyChecksum(errorCase.addressString)
+		require.EqualError(t, err, errorCase.err)
+		require.ErrorIs(t, err, errorCase.is)
+		require.False(t, IsValid(errorCase.addressString))
+	}
+}
+
+func TestAppendString(t *testing.T) {
+	t.Parallel()
+
+	addr, err := FromString("CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM")
+	require.NoError(t, err)
+
+	require.Equal(t, "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM", string(AppendString(nil, addr)))
+	buffer := AppendString([]byte("addr="), addr)
+	buffer = ZeroAddress.AppendString(append(buffer, ','))
+	require.Equal(t, "addr=CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM,"+ZeroAddressString, string(buffer))
+}
+
+// TestAppendStringAllocations is not parallel, as testing.AllocsPerRun cannot measure parallel
+// tests.
+func TestAppendStringAllocations(t *testing.T) {
+	buffer := make([]byte, 0, 2*stringSize)
+	addr := Address{1, 2, 3}
+	allocs := testing.AllocsPerRun(100, func() {
+		buffer = addr.AppendString(buffer[:0])
+		buffer = AppendString(buffer, addr)
+	})
+	require.Zero(t, allocs)
+	require.Len(t, buffer, 2*stringSize)
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	low, middle, high := Address{0: 1}, Address{0: 1, 31: 1}, Address{0: 2}
+	require.Equal(t, 0, Compare(middle, middle))
+	require.Equal(t, -1, Compare(low, middle))
+	require.Equal(t, 1, Compare(high, middle))
+	require.True(t, Less(low, middle))
+	require.False(t, Less(middle, middle))
+	require.False(t, Less(high, low))
+
+	addresses := []Address{high, ZeroAddress, middle, low}
+	slices.SortFunc(addresses, Compare)
+	require.Equal(t, []Address{ZeroAddress, low, middle, high}, addresses)
+	index, found := slices.BinarySearchFunc(addresses, middle, Compare)
+	require.True(t, found)
+	require.Equal(t, 2, index)
+}
+
+func TestEqualConstantTime(t *testing.T) {
+	t.Parallel()
+
+	addr := ForApplication(1)
+	require.True(t, EqualConstantTime(addr, addr))
+	require.True(t, EqualConstantTime(ZeroAddress, Address{}))
+	require.False(t, EqualConstantTime(addr, ZeroAddress))
+	for i := 0; i < BytesSize; i++ {
+		other := addr
+		other[i] ^= 1
+		require.False(t, EqualConstantTime(addr, other), i)
+	}
+}
@@ -0,0 +1,61 @@
+package address
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+)
+
+// Domain separation prefixes of the hashes of derived addresses.
+const (
+	appIDPrefix    = "appID"
+	multisigPrefix = "MultisigAddr"
+	programPrefix  = "Program"
+)
+
+// MultisigVersion is the only version of multisig accounts.
+const MultisigVersion = 1
+
+// ForApplication returns the address of the application with the given ID, which holds the
+// application's assets and pays for its boxes and inner transactions.
+func ForApplication(appID uint64) Address {
+	var preimage [len(appIDPrefix) + 8]byte
+	copy(preimage[:], appIDPrefix)
+	binary.BigEndian.PutUint64(preimage[len(appIDPrefix):], appID)
+	return sha512.Sum512_256(preimage[:])
+}
+
+// Multisig returns the address of the multisig account of the given version, which must be
+// MultisigVersion, whose transactions must be signed by threshold of the keys pubkeys. The order
+// of the keys matters, so different orders give different addresses.
+func Multisig(version, threshold uint8, pubkeys [][32]byte) (Address, error) {
+	if version != MultisigVersion {
+		return Address{}, fmt.Errorf("unsupported multisig version %d", version)
+	}
+	if len(pubkeys) == 0 || len(pubkeys) > 255 {
+		return Address{}, fmt.Errorf("multisig account should have between 1 and 255 keys, got %d", len(pubkeys))
+	}
+	if threshold == 0 || int(threshold) > len(pubkeys) {
+		return Address{}, fmt.Errorf("multisig threshold should be between 1 and the number of keys %d, got %d", len(pubkeys), threshold)
+	}
+	hasher := sha512.New512_256()
+	hasher.Write([]byte(multisigPrefix))
+	hasher.Write([]byte{version, threshold})
+	for _, pubkey := range pubkeys {
+		hasher.Write(pubkey[:])
+	}
+	var addr Address
+	hasher.Sum(addr[:0])
+	return addr, nil
+}
+
+// ForLogicProgram returns the address of the logic signature escrow account of a compiled
+// program, which approves the transactions the program approves.
+func ForLogicProgram(program []byte) Address {
+	hasher := sha512.New512_256()
+	hasher.Write([]byte(programPrefix))
+	hasher.Write(program)
+	var addr Address
+	hasher.Sum(addr[:0])
+	return addr
+}
@@ -0,0 +1,60 @@
+package address
+
+import (
+	"crypto/sha512"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestForApplication(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, Address(sha512.Sum512_256([]byte("appID\x00\x00\x00\x00\x00\x00\x04\xd2"))), ForApplication(1234))
+	require.NotEqual(t, ForApplication(1), ForApplication(2))
+	require.False(t, ForApplication(0).IsZero())
+}
+
+func TestMultisig(t *testing.T) {
+	t.Parallel()
+
+	var pubkeys [][32]byte
+	for _, addressString := range []string{
+		"DN7MBMCL5JQ3PFUQS7TMX5AH4EEKOBJVDUF4TCV6WERATKFLQF4MQUPZTA",
+		"BFRTECKTOOE7A5LHCF3TTEOH2A7BW46IYT2SX5VP6ANKEXHZYJY77SJTVM",
+		"47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU",
+	} {
+		pubkey, err := FromString(addressString)
+		require.NoError(t, err)
+		pubkeys = append(pubkeys, pubkey)
+	}
+
+	addr, err := Multisig(1, 2, pubkeys)
+	require.NoError(t, err)
+	preimage := append([]byte("MultisigAddr\x01\x02"), pubkeys[0][:]...)
+	preimage = append(preimage, pubkeys[1][:]...)
+	preimage = append(preimage, pubkeys[2][:]...)
+	require.Equal(t, Address(sha512.Sum512_256(preimage)), addr)
+
+	reordered, err := Multisig(1, 2, [][32]byte{pubkeys[1], pubkeys[0], pubkeys[2]})
+	require.NoError(t, err)
+	require.NotEqual(t, addr, reordered)
+
+	_, err = Multisig(2, 2, pubkeys)
+	require.EqualError(t, err, "unsupported multisig version 2")
+	_, err = Multisig(1, 0, pubkeys)
+	require.EqualError(t, err, "multisig threshold should be between 1 and the number of keys 3, got 0")
+	_, err = Multisig(1, 4, pubkeys)
+	require.EqualError(t, err, "multisig threshold should be between 1 and the number of keys 3, got 4")
+	_, err = Multisig(1, 1, nil)
+	require.EqualError(t, err, "multisig account should have between 1 and 255 keys, got 0")
+}
+
+func TestForLogicProgram(t *testing.T) {
+	t.Parallel()
+
+	// #pragma version 1; int 1
+	program := []byte{0x01, 0x20, 0x01, 0x01, 0x22}
+	require.Equal(t, "6Z3C3LDVWGMX23BMSYMANACQOSINPFIRF77H7N3AWJZYV6OH6GWTJKVMXY", ForLogicProgram(program).String())
+	require.Equal(t, Address(sha512.Sum512_256([]byte("Program"))), ForLogicProgram(nil))
+}
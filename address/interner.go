@@ -0,0 +1,85 @@
+package address
+
+import (
+	"math"
+	"sync"
+)
+
+// internerChunkSize is the number of addresses of each chunk of an Interner. Chunks are never
+// reallocated, so pointers to their addresses stay valid as the interner grows.
+const internerChunkSize = 4096
+
+// Interner maps equal addresses to a single shared instance and to a compact ID, so services which
+// hold many references to the same addresses, such as indexers, can store a pointer or a uint32
+// instead of a copy of each address. IDs are assigned in the order addresses are first interned,
+// starting from 0, and an interner never forgets an address. An Interner is safe for concurrent use,
+// and the zero Interner is empty and ready to use.
+type Interner struct {
+	mu  sync.RWMutex
+	ids map[Address]uint32
+	// chunks hold the interned addresses, indexed by ID
+	chunks [][]Address
+}
+
+// Intern returns the shared instance of the address, interning it if it is new. The returned
+// address must not be modified.
+func (in *Interner) Intern(addr Address) *Address {
+	id := in.ID(addr)
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.at(id)
+}
+
+// ID returns the ID of the address, interning it if it is new. It panics if the interner already
+// holds math.MaxUint32+1 addresses, which is more than there are IDs.
+func (in *Interner) ID(addr Address) uint32 {
+	in.mu.RLock()
+	id, ok := in.ids[addr]
+	in.mu.RUnlock()
+	if ok {
+		return id
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if id, ok := in.ids[addr]; ok {
+		return id
+	}
+	count := len(in.ids)
+	if uint64(count) > math.MaxUint32 {
+		panic("address: interner has no more IDs")
+	}
+	if count%internerChunkSize == 0 {
+		in.chunks = append(in.chunks, make([]Address, 0, internerChunkSize))
+	}
+	lastChunk := &in.chunks[len(in.chunks)-1]
+	*lastChunk = append(*lastChunk, addr)
+	if in.ids == nil {
+		in.ids = make(map[Address]uint32)
+	}
+	in.ids[addr] = uint32(count)
+	return uint32(count)
+}
+
+// Lookup returns the shared instance of the address with the given ID, and whether an address has
+// that ID.
+func (in *Interner) Lookup(id uint32) (*Address, bool) {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	if uint64(id) >= uint64(len(in.ids)) {
+		return nil, false
+	}
+	return in.at(id), true
+}
+
+// Len returns the number of interned addresses.
+func (in *Interner) Len() int {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return len(in.ids)
+}
+
+// at returns the interned address with the given ID, which must exist. The caller must hold a lock.
+func (in *Interner) at(id uint32) *Address {
+	return &in.chunks[id/internerChunkSize][id%internerChunkSize]
+}
@@ -0,0 +1,75 @@
+package address
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterner(t *testing.T) {
+	t.Parallel()
+
+	var interner Interner
+	require.Zero(t, interner.Len())
+	_, ok := interner.Lookup(0)
+	require.False(t, ok)
+
+	first, err := FromString("CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM")
+	require.NoError(t, err)
+	firstCopy := Address(first)
+	second := ForApplication(1)
+
+	require.Equal(t, uint32(0), interner.ID(first))
+	require.Equal(t, uint32(1), interner.ID(second))
+	require.Equal(t, uint32(0), interner.ID(firstCopy))
+	require.Equal(t, 2, interner.Len())
+
+	shared := interner.Intern(first)
+	require.Equal(t, Address(first), *shared)
+	require.Same(t, shared, interner.Intern(firstCopy))
+	require.NotSame(t, shared, interner.Intern(second))
+	require.Equal(t, 2, interner.Len())
+
+	looked, ok := interner.Lookup(1)
+	require.True(t, ok)
+	require.Same(t, interner.Intern(second), looked)
+	_, ok = interner.Lookup(2)
+	require.False(t, ok)
+
+	// interning new addresses does not move the shared instances
+	for appID := uint64(2); appID < 3*internerChunkSize; appID++ {
+		interner.Intern(ForApplication(appID))
+	}
+	require.Equal(t, 3*internerChunkSize, interner.Len())
+	require.Same(t, shared, interner.Intern(first))
+	last, ok := interner.Lookup(3*internerChunkSize - 1)
+	require.True(t, ok)
+	require.Equal(t, ForApplication(3*internerChunkSize-1), *last)
+}
+
+func TestInternerConcurrency(t *testing.T) {
+	t.Parallel()
+
+	const count = 1000
+	var interner Interner
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for appID := uint64(0); appID < count; appID++ {
+				addr := ForApplication(appID)
+				require.Equal(t, addr, *interner.Intern(addr))
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, count, interner.Len())
+	for appID := uint64(0); appID < count; appID++ {
+		addr, ok := interner.Lookup(interner.ID(ForApplication(appID)))
+		require.True(t, ok)
+		require.Equal(t, ForApplication(appID), *addr)
+	}
+}
@@ -0,0 +1,71 @@
+package address
+
+// Genesis IDs of the public Algorand networks.
+const (
+	MainNetGenesisID = "mainnet-v1.0"
+	TestNetGenesisID = "testnet-v1.0"
+	BetaNetGenesisID = "betanet-v1.0"
+)
+
+// String forms of the well-known addresses of the public Algorand networks. TestNet and BetaNet
+// share their well-known addresses.
+const (
+	MainNetFeeSinkString     = "Y76M3MSY6DKBRHBL7C3NNDXGS5IIMQVQVUAB6MP4XEMMGVF2QWNPL226CA"
+	MainNetRewardsPoolString = "737777777777777777777777777777777777777777777777777UFEJ2CI"
+	TestNetFeeSinkString     = "A7NMWS3NT3IUDMLVO26ULGXGIIOUQ3ND2TXSER6EBGRZNOBOUIQXHIBGDE"
+	TestNetRewardsPoolString = "7777777777777777777777777777777777777777777777777774MSJUVU"
+	BetaNetFeeSinkString     = TestNetFeeSinkString
+	BetaNetRewardsPoolString = TestNetRewardsPoolString
+)
+
+// WellKnownAddresses are the special addresses of a network, which are set in its genesis.
+type WellKnownAddresses struct {
+	// FeeSink is the address which receives transaction fees.
+	FeeSink Address
+	// RewardsPool is the address which pays participation rewards.
+	RewardsPool Address
+}
+
+// wellKnownAddresses maps the genesis ID of each public network to its well-known addresses.
+var wellKnownAddresses = map[string]WellKnownAddresses{
+	MainNetGenesisID: {
+		FeeSink:     mustFromString(MainNetFeeSinkString),
+		RewardsPool: mustFromString(MainNetRewardsPoolString),
+	},
+	TestNetGenesisID: {
+		FeeSink:     mustFromString(TestNetFeeSinkString),
+		RewardsPool: mustFromString(TestNetRewardsPoolString),
+	},
+	BetaNetGenesisID: {
+		FeeSink:     mustFromString(BetaNetFeeSinkString),
+		RewardsPool: mustFromString(BetaNetRewardsPoolString),
+	},
+}
+
+func mustFromString(addressString string) Address {
+	addr, err := FromString(addressString)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
+// WellKnown returns the well-known addresses of the public network with the given genesis ID,
+// such as MainNetGenesisID, and whether the network is known.
+func WellKnown(genesisID string) (WellKnownAddresses, bool) {
+	addresses, ok := wellKnownAddresses[genesisID]
+	return addresses, ok
+}
+
+// Label returns a label for the address if it is one of the well-known addresses, "fee sink" or
+// "rewards pool", or an empty string otherwise.
+func (w WellKnownAddresses) Label(addr Address) string {
+	switch addr {
+	case w.FeeSink:
+		return "fee sink"
+	case w.RewardsPool:
+		return "rewards pool"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,39 @@
+package address
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWellKnown(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		genesisID   string
+		feeSink     string
+		rewardsPool string
+	}{
+		{genesisID: MainNetGenesisID, feeSink: MainNetFeeSinkString, rewardsPool: MainNetRewardsPoolString},
+		{genesisID: TestNetGenesisID, feeSink: TestNetFeeSinkString, rewardsPool: TestNetRewardsPoolString},
+		{genesisID: BetaNetGenesisID, feeSink: BetaNetFeeSinkString, rewardsPool: BetaNetRewardsPoolString},
+	}
+	for _, testCase := range testCases {
+		addresses, ok := WellKnown(testCase.genesisID)
+		require.True(t, ok, testCase.genesisID)
+		require.Equal(t, testCase.feeSink, addresses.FeeSink.String())
+		require.Equal(t, testCase.rewardsPool, addresses.RewardsPool.String())
+		require.Equal(t, "fee sink", addresses.Label(addresses.FeeSink))
+		require.Equal(t, "rewards pool", addresses.Label(addresses.RewardsPool))
+		require.Empty(t, addresses.Label(ZeroAddress))
+	}
+
+	mainNet, _ := WellKnown(MainNetGenesisID)
+	testNet, _ := WellKnown(TestNetGenesisID)
+	require.Empty(t, mainNet.Label(testNet.FeeSink))
+
+	_, ok := WellKnown("devnet-v1")
+	require.False(t, ok)
+	_, ok = WellKnown("")
+	require.False(t, ok)
+}
@@ -0,0 +1,44 @@
+package address
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxLineLen is the maximum length of the lines read by ReadAll, in bytes, which fits over 16000
+// address strings separated by commas.
+const maxLineLen = 1 << 20
+
+// ReadAll reads and decodes the address strings of r, one per line or separated by commas as in CSV
+// files. Spaces and double quotes around each address string are ignored, and so are empty lines
+// and fields; unlike CSV, quoted fields cannot contain commas or line breaks. Invalid address
+// strings do not stop reading: the returned errors report each of them with its line number,
+// counting from 1, and the valid addresses are returned in order. An error reading r, or a line
+// longer than 1 MiB, stops reading and is the last of the returned errors.
+func ReadAll(r io.Reader) ([]Address, []error) {
+	var addresses []Address
+	var errs []error
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineLen)
+	line := 1
+	for ; scanner.Scan(); line++ {
+		for _, field := range strings.Split(scanner.Text(), ",") {
+			addressString := strings.Trim(strings.TrimSpace(field), `"`)
+			if addressString == "" {
+				continue
+			}
+			addr, err := FromString(addressString)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+				continue
+			}
+			addresses = append(addresses, addr)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("cannot read addresses: line %d: %w", line, err))
+	}
+	return addresses, errs
+}
@@ -0,0 +1,117 @@
+package address
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAll(t *testing.T) {
+	t.Parallel()
+
+	first, second, third := ForApplication(1), ForApplication(2), ForApplication(3)
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected []Address
+	}{
+		{name: "empty", input: "", expected: nil},
+		{name: "blank lines", input: "\n \n\r\n", expected: nil},
+		{
+			name:     "lines",
+			input:    first.String() + "\n" + second.String() + "\r\n\n" + third.String(),
+			expected: []Address{first, second, third},
+		},
+		{
+			name:     "csv",
+			input:    first.String() + ", \"" + second.String() + "\",\n,\n" + third.String() + ",\n",
+			expected: []Address{first, second, third},
+		},
+		{
+			name:     "duplicates",
+			input:    first.String() + "\n" + first.String() + "\n",
+			expected: []Address{first, first},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			addresses, errs := ReadAll(strings.NewReader(testCase.input))
+			require.Empty(t, errs)
+			require.Equal(t, testCase.expected, addresses)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		input := "address,amount\n" +
+			first.String() + ",5\n" +
+			"DAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM\n" +
+			second.String() + "\n"
+		addresses, errs := ReadAll(strings.NewReader(input))
+		require.Equal(t, []Address{first, second}, addresses)
+		require.Len(t, errs, 4)
+		require.ErrorContains(t, errs[0], "line 1: cannot cast encoded address string (address) to address")
+		require.ErrorContains(t, errs[1], "line 1: cannot cast encoded address string (amount) to address")
+		require.ErrorContains(t, errs[2], "line 2: cannot cast encoded address string (5) to address")
+		require.ErrorContains(t, errs[3], "line 3: ")
+		require.ErrorIs(t, errs[3], ErrBadChecksum)
+	})
+
+	t.Run("long line", func(t *testing.T) {
+		t.Parallel()
+		// the line is longer than the default token size of bufio.Scanner
+		const count = 2000
+		addresses, errs := ReadAll(strings.NewReader(strings.Repeat(first.String()+",", count)))
+		require.Empty(t, errs)
+		require.Len(t, addresses, count)
+	})
+
+	t.Run("stray quotes", func(t *testing.T) {
+		t.Parallel()
+		// quotes are trimmed from each field, so an unterminated quote does not swallow the
+		// following lines
+		input := `"` + first.String() + "\n" +
+			`"name, with comma",` + second.String() + "\n" +
+			third.String() + `"` + "\n"
+		addresses, errs := ReadAll(strings.NewReader(input))
+		require.Equal(t, []Address{first, second, third}, addresses)
+		require.Len(t, errs, 2)
+		require.ErrorContains(t, errs[0], "line 2: cannot cast encoded address string (name) to address")
+		require.ErrorContains(t, errs[1], "line 2: cannot cast encoded address string (with comma) to address")
+	})
+
+	t.Run("too long line", func(t *testing.T) {
+		t.Parallel()
+		input := first.String() + "\n" + strings.Repeat(",", maxLineLen+1) + "\n" + second.String() + "\n"
+		addresses, errs := ReadAll(strings.NewReader(input))
+		require.Equal(t, []Address{first}, addresses)
+		require.Len(t, errs, 1)
+		require.EqualError(t, errs[0], "cannot read addresses: line 2: bufio.Scanner: token too long")
+		require.ErrorIs(t, errs[0], bufio.ErrTooLong)
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		t.Parallel()
+		readErr := errors.New("connection reset")
+		r := io.MultiReader(strings.NewReader(first.String()+"\n"), &errorReader{err: readErr})
+		addresses, errs := ReadAll(r)
+		require.Equal(t, []Address{first}, addresses)
+		require.Len(t, errs, 1)
+		require.EqualError(t, errs[0], "cannot read addresses: line 2: connection reset")
+		require.ErrorIs(t, errs[0], readErr)
+	})
+}
+
+type errorReader struct {
+	err error
+}
+
+func (r *errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
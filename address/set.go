@@ -0,0 +1,72 @@
+package address
+
+// Set is a set of addresses which remembers the order the addresses were added in, so iterating
+// over it is deterministic, as needed to deduplicate the foreign accounts of application calls.
+// The zero Set is empty and ready to use.
+type Set struct {
+	// indexes maps each address of the set to its index in addresses
+	indexes   map[Address]int
+	addresses []Address
+}
+
+// NewSet returns a set of the given addresses, ignoring duplicates.
+func NewSet(addresses ...Address) *Set {
+	s := &Set{}
+	for _, addr := range addresses {
+		s.Add(addr)
+	}
+	return s
+}
+
+// Add adds the address to the set, after the addresses already in it, and reports whether it was
+// not already in the set.
+func (s *Set) Add(addr Address) bool {
+	if _, ok := s.indexes[addr]; ok {
+		return false
+	}
+	if s.indexes == nil {
+		s.indexes = make(map[Address]int)
+	}
+	s.indexes[addr] = len(s.addresses)
+	s.addresses = append(s.addresses, addr)
+	return true
+}
+
+// Contains reports whether the address is in the set.
+func (s *Set) Contains(addr Address) bool {
+	_, ok := s.indexes[addr]
+	return ok
+}
+
+// Index returns the position of the address in the set, counting from 0 in the order addresses
+// were added, and whether it is in the set.
+func (s *Set) Index(addr Address) (int, bool) {
+	index, ok := s.indexes[addr]
+	return index, ok
+}
+
+// Len returns the number of addresses in the set.
+func (s *Set) Len() int {
+	return len(s.addresses)
+}
+
+// Addresses returns the addresses of the set in the order they were added in. The returned slice
+// is a copy, so modifying it does not modify the set.
+func (s *Set) Addresses() []Address {
+	return append([]Address(nil), s.addresses...)
+}
+
+// Union returns a new set with the addresses of s followed by the addresses of other which are not
+// in s. Neither s nor other is modified, and a nil set is treated as an empty set.
+func (s *Set) Union(other *Set) *Set {
+	union := &Set{}
+	for _, set := range []*Set{s, other} {
+		if set == nil {
+			continue
+		}
+		for _, addr := range set.addresses {
+			union.Add(addr)
+		}
+	}
+	return union
+}
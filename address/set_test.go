@@ -0,0 +1,70 @@
+package address
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	first, second, third := ForApplication(1), ForApplication(2), ForApplication(3)
+
+	var empty Set
+	require.Zero(t, empty.Len())
+	require.False(t, empty.Contains(first))
+	require.Empty(t, empty.Addresses())
+	_, ok := empty.Index(first)
+	require.False(t, ok)
+
+	s := NewSet(second, first, second)
+	require.Equal(t, 2, s.Len())
+	require.Equal(t, []Address{second, first}, s.Addresses())
+	require.True(t, s.Contains(first))
+	require.False(t, s.Contains(third))
+
+	require.False(t, s.Add(first))
+	require.True(t, s.Add(third))
+	require.Equal(t, []Address{second, first, third}, s.Addresses())
+	for i, addr := range s.Addresses() {
+		index, ok := s.Index(addr)
+		require.True(t, ok)
+		require.Equal(t, i, index)
+	}
+
+	// modifying the returned addresses does not modify the set
+	addresses := s.Addresses()
+	addresses[0] = ZeroAddress
+	require.Equal(t, second, s.Addresses()[0])
+	require.False(t, s.Contains(ZeroAddress))
+
+	var zeroValue Set
+	require.True(t, zeroValue.Add(ZeroAddress))
+	require.True(t, zeroValue.Contains(ZeroAddress))
+}
+
+func TestSetUnion(t *testing.T) {
+	t.Parallel()
+
+	first, second, third := ForApplication(1), ForApplication(2), ForApplication(3)
+	left := NewSet(first, second)
+	right := NewSet(third, first)
+
+	union := left.Union(right)
+	require.Equal(t, []Address{first, second, third}, union.Addresses())
+	require.Equal(t, []Address{third, first, second}, right.Union(left).Addresses())
+
+	// the union is a new set
+	require.Equal(t, []Address{first, second}, left.Addresses())
+	require.Equal(t, []Address{third, first}, right.Addresses())
+	union.Add(ZeroAddress)
+	require.False(t, left.Contains(ZeroAddress))
+
+	require.Equal(t, left.Addresses(), left.Union(&Set{}).Addresses())
+	require.Equal(t, left.Addresses(), (&Set{}).Union(left).Addresses())
+	require.Equal(t, left.Addresses(), left.Union(nil).Addresses())
+	var empty *Set
+	require.Equal(t, left.Addresses(), empty.Union(left).Addresses())
+	require.Zero(t, empty.Union(nil).Len())
+}
@@ -0,0 +1,82 @@
+package address
+
+import (
+	"fmt"
+	"strings"
+)
+
+// lastCharacters are the characters which can end the string form of an address. The last
+// character only encodes 3 bits of the checksum, and its 2 low bits are always zero.
+const lastCharacters = "AEIMQUY4"
+
+// validatePattern checks that pattern is made of base32 characters and is no longer than an
+// address string. kind names the pattern in errors.
+func validatePattern(kind, pattern string) error {
+	if len(pattern) > stringSize {
+		return fmt.Errorf("%w: %s should be at most %d characters long, got %d", ErrWrongLength, kind, stringSize, len(pattern))
+	}
+	for i := 0; i < len(pattern); i++ {
+		if _, ok := base32Value(pattern[i]); !ok {
+			return fmt.Errorf("%w: %s has a non-base32 character %q at index %d", ErrNotBase32, kind, pattern[i], i)
+		}
+	}
+	return nil
+}
+
+// ValidatePrefix checks that some address string starts with prefix, so a vanity address search
+// for it can succeed. The prefix must be upper case base32, as address strings are, and a prefix
+// as long as an address string must end with a character which can end one. Whether such a
+// prefix has a valid checksum is not checked.
+func ValidatePrefix(prefix string) error {
+	if err := validatePattern("prefix", prefix); err != nil {
+		return err
+	}
+	if len(prefix) == stringSize {
+		if last := prefix[stringSize-1]; strings.IndexByte(lastCharacters, last) < 0 {
+			return fmt.Errorf("prefix ends with %q, but address strings end with one of %s", last, lastCharacters)
+		}
+	}
+	return nil
+}
+
+// ValidateSuffix checks that some address string ends with suffix, so a vanity address search for
+// it can succeed. The suffix must be upper case base32, as address strings are, and end with a
+// character which can end an address string.
+func ValidateSuffix(suffix string) error {
+	if err := validatePattern("suffix", suffix); err != nil {
+		return err
+	}
+	if len(suffix) > 0 {
+		if last := suffix[len(suffix)-1]; strings.IndexByte(lastCharacters, last) < 0 {
+			return fmt.Errorf("suffix ends with %q, but address strings end with one of %s", last, lastCharacters)
+		}
+	}
+	return nil
+}
+
+// HasPrefix reports whether the string form of the address starts with prefix. Prefixes of up to
+// 51 characters only depend on the address bytes, so the checksum is not computed for them, which
+// suits vanity address searches testing many addresses.
+func HasPrefix(addr Address, prefix string) bool {
+	if len(prefix) > stringSize {
+		return false
+	}
+	var encoded [stringSize]byte
+	// the first n characters encode the first 5n bits of the address and checksum
+	if byteCount := (len(prefix)*5 + 7) / 8; byteCount <= BytesSize {
+		base32Encoder.Encode(encoded[:], addr[:byteCount])
+	} else {
+		AppendString(encoded[:0], addr)
+	}
+	return string(encoded[:len(prefix)]) == prefix
+}
+
+// HasSuffix reports whether the string form of the address ends with suffix.
+func HasSuffix(addr Address, suffix string) bool {
+	if len(suffix) > stringSize {
+		return false
+	}
+	var encoded [stringSize]byte
+	AppendString(encoded[:0], addr)
+	return string(encoded[stringSize-len(suffix):]) == suffix
+}
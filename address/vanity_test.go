@@ -0,0 +1,85 @@
+package address
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePrefix(t *testing.T) {
+	t.Parallel()
+
+	for _, prefix := range []string{
+		"",
+		"CAFF",
+		"7",
+		"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM",
+		strings.Repeat("Z", stringSize-1) + "Y",
+	} {
+		require.NoError(t, ValidatePrefix(prefix), prefix)
+	}
+
+	err := ValidatePrefix(strings.Repeat("A", stringSize+1))
+	require.EqualError(t, err, "address has the wrong length: prefix should be at most 58 characters long, got 59")
+	require.ErrorIs(t, err, ErrWrongLength)
+
+	err = ValidatePrefix("CAFf")
+	require.EqualError(t, err, "address string is not base32: prefix has a non-base32 character 'f' at index 3")
+	require.ErrorIs(t, err, ErrNotBase32)
+	require.ErrorIs(t, ValidatePrefix("0"), ErrNotBase32)
+
+	require.EqualError(t, ValidatePrefix(strings.Repeat("A", stringSize-1)+"B"),
+		`prefix ends with 'B', but address strings end with one of AEIMQUY4`)
+}
+
+func TestValidateSuffix(t *testing.T) {
+	t.Parallel()
+
+	for _, suffix := range []string{
+		"",
+		"JAFM",
+		"4",
+		"CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM",
+	} {
+		require.NoError(t, ValidateSuffix(suffix), suffix)
+	}
+
+	err := ValidateSuffix(strings.Repeat("A", stringSize+1))
+	require.EqualError(t, err, "address has the wrong length: suffix should be at most 58 characters long, got 59")
+	require.ErrorIs(t, err, ErrWrongLength)
+	require.ErrorIs(t, ValidateSuffix("jafm"), ErrNotBase32)
+
+	require.EqualError(t, ValidateSuffix("JAFN"), `suffix ends with 'N', but address strings end with one of AEIMQUY4`)
+	require.Error(t, ValidateSuffix("7"))
+}
+
+func TestHasPrefixAndSuffix(t *testing.T) {
+	t.Parallel()
+
+	addressString := "CAFFDSU6TYXNDC6V6R5XAOHBWBD4MH36TNUWCW4D6HKV7EKHP33Q74JAFM"
+	addr, err := FromString(addressString)
+	require.NoError(t, err)
+
+	for i := 0; i <= len(addressString); i++ {
+		require.True(t, HasPrefix(addr, addressString[:i]), i)
+		require.True(t, HasSuffix(addr, addressString[i:]), i)
+		require.NoError(t, ValidatePrefix(addressString[:i]), i)
+		require.NoError(t, ValidateSuffix(addressString[i:]), i)
+	}
+
+	require.False(t, HasPrefix(addr, "CAFG"))
+	require.False(t, HasPrefix(addr, "caff"))
+	require.False(t, HasPrefix(addr, addressString[:50]+"Q"))
+	require.False(t, HasPrefix(addr, addressString[:stringSize-1]+"A"))
+	require.False(t, HasPrefix(addr, addressString+"A"))
+	require.False(t, HasSuffix(addr, "JAFA"))
+	require.False(t, HasSuffix(addr, "A"+addressString))
+
+	// every address string ends with one of the last characters
+	for appID := uint64(0); appID < 256; appID++ {
+		appAddress := ForApplication(appID)
+		require.True(t, HasSuffix(appAddress, appAddress.String()[stringSize-1:]))
+		require.NoError(t, ValidateSuffix(appAddress.String()), appID)
+	}
+}
@@ -1,11 +1,13 @@
 /*
-Package apps provides parsing utilities related to application arguments and box keys.
+Package apps provides parsing utilities related to application arguments, box and state keys, and
+ARC-2 transaction notes.
 */
 package apps
 
 import (
 	"encoding/binary"
 	"fmt"
+	"math/bits"
 )
 
 const boxPrefix = "bx:"
@@ -28,6 +30,21 @@ func MakeBoxKey(appIdx uint64, name string) string {
 	return string(key)
 }
 
+// MakeBoxKeyBytes creates the key that a box named `name` under app `appIdx` should use, like
+// MakeBoxKey, but as a byte slice, without converting the name and the key from and to strings.
+func MakeBoxKeyBytes(appIdx uint64, name []byte) []byte {
+	return AppendBoxKey(make([]byte, 0, boxNameIndex+len(name)), appIdx, name)
+}
+
+// AppendBoxKey appends the key that a box named `name` under app `appIdx` should use to dst, and
+// returns the extended buffer. It does not allocate if dst has enough capacity, so a buffer can be
+// reused to make many keys.
+func AppendBoxKey(dst []byte, appIdx uint64, name []byte) []byte {
+	dst = append(dst, boxPrefix...)
+	dst = binary.BigEndian.AppendUint64(dst, appIdx)
+	return append(dst, name...)
+}
+
 // SplitBoxKey extracts an appid and box name from a string that was created by MakeBoxKey()
 func SplitBoxKey(key string) (uint64, string, error) {
 	if len(key) < boxNameIndex {
@@ -40,3 +57,200 @@ func SplitBoxKey(key string) (uint64, string, error) {
 	app := binary.BigEndian.Uint64(keyBytes[boxPrefixLength:boxNameIndex])
 	return app, key[boxNameIndex:], nil
 }
+
+// SplitBoxKeyBytes extracts an appid and box name from a key created by MakeBoxKey, like
+// SplitBoxKey, but without converting the key to a string. The returned name is a subslice of key,
+// so it is not copied, and modifying key modifies it.
+func SplitBoxKeyBytes(key []byte) (uint64, []byte, error) {
+	if len(key) < boxNameIndex {
+		return 0, nil, fmt.Errorf("SplitBoxKeyBytes() cannot extract AppIndex as key (%s) too short (length=%d)", key, len(key))
+	}
+	if string(key[:boxPrefixLength]) != boxPrefix {
+		return 0, nil, fmt.Errorf("SplitBoxKeyBytes() illegal app box prefix in key (%s). Expected prefix '%s'", key, boxPrefix)
+	}
+	app := binary.BigEndian.Uint64(key[boxPrefixLength:boxNameIndex])
+	return app, key[boxNameIndex:], nil
+}
+
+// BoxKeyRange returns the range of the keys of all the boxes of app appIdx, as made by MakeBoxKey:
+// the keys which are lexicographically greater than or equal to start and less than end, so that
+// key-value stores can iterate over them.
+func BoxKeyRange(appIdx uint64) (start, end string) {
+	return BoxKeyRangeWithPrefix(appIdx, "")
+}
+
+// BoxKeyRangeWithPrefix returns the range of the keys of the boxes of app appIdx whose names start
+// with namePrefix, as BoxKeyRange.
+func BoxKeyRangeWithPrefix(appIdx uint64, namePrefix string) (start, end string) {
+	start = MakeBoxKey(appIdx, namePrefix)
+	// the smallest key greater than all the keys starting with start increments the last byte
+	// which is not 0xff, and drops the bytes after it. start begins with boxPrefix, so it has one.
+	endBytes := []byte(start)
+	i := len(endBytes) - 1
+	for endBytes[i] == 0xff {
+		i--
+	}
+	endBytes[i]++
+	return start, string(endBytes[:i+1])
+}
+
+// BoxName is a box name given in the "encoding:value" form of app call arguments, such as
+// "str:balances" or "addr:<address>", so tools parse box names and app call arguments uniformly.
+type BoxName struct {
+	Encoding string `codec:"encoding"`
+	Value    string `codec:"value"`
+}
+
+// NewBoxName parses a box name of the form "encoding:value". It supports the encodings of
+// AppCallBytes, such as str, b64, int, addr, and abi, except for the file, binfile, and stdin
+// encodings, and checks that the value is valid for its encoding.
+func NewBoxName(spec string) (BoxName, error) {
+	arg, err := NewAppCallBytes(spec)
+	if err != nil {
+		return BoxName{}, err
+	}
+	switch arg.Encoding {
+	case "file", "binfile", "stdin":
+		return BoxName{}, fmt.Errorf("box names cannot use the %s encoding", arg.Encoding)
+	}
+	if err := arg.Validate(); err != nil {
+		return BoxName{}, err
+	}
+	return BoxName(arg), nil
+}
+
+// String returns the box name in the "encoding:value" form parsed by NewBoxName.
+func (name BoxName) String() string {
+	return AppCallBytes(name).String()
+}
+
+// Raw converts the box name to the bytes of the name, as AppCallBytes.Raw.
+func (name BoxName) Raw() ([]byte, error) {
+	return AppCallBytes(name).Raw()
+}
+
+// Consensus limits of boxes, in bytes.
+const (
+	// DefaultMaxBoxNameLen is the maximum length of the name of a box.
+	DefaultMaxBoxNameLen = 64
+	// DefaultMaxBoxSize is the maximum size of the contents of a box.
+	DefaultMaxBoxSize = 32768
+)
+
+// BoxLimits are the consensus limits of boxes, in bytes. Zero fields take the default consensus
+// values, so the zero BoxLimits are the current consensus limits.
+type BoxLimits struct {
+	// MaxBoxNameLen is the maximum length of the name of a box, DefaultMaxBoxNameLen if zero.
+	MaxBoxNameLen uint64
+	// MaxBoxSize is the maximum size of the contents of a box, DefaultMaxBoxSize if zero.
+	MaxBoxSize uint64
+}
+
+// ValidateBoxName checks that a box can have the given name: names must not be empty, nor longer
+// than MaxBoxNameLen bytes.
+func ValidateBoxName(name []byte, limits BoxLimits) error {
+	maxLen := limits.MaxBoxNameLen
+	if maxLen == 0 {
+		maxLen = DefaultMaxBoxNameLen
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("box names cannot be empty")
+	}
+	if uint64(len(name)) > maxLen {
+		return fmt.Errorf("box name is %d bytes long, more than the maximum of %d bytes", len(name), maxLen)
+	}
+	return nil
+}
+
+// ValidateBoxSize checks that a box can have contents of the given size, which must not be larger
+// than MaxBoxSize bytes.
+func ValidateBoxSize(size uint64, limits BoxLimits) error {
+	maxSize := limits.MaxBoxSize
+	if maxSize == 0 {
+		maxSize = DefaultMaxBoxSize
+	}
+	if size > maxSize {
+		return fmt.Errorf("box size is %d bytes, more than the maximum of %d bytes", size, maxSize)
+	}
+	return nil
+}
+
+// Consensus values of the minimum balance requirement of boxes, in microAlgos.
+const (
+	// DefaultBoxFlatMinBalance is the minimum balance requirement of each box.
+	DefaultBoxFlatMinBalance = 2500
+	// DefaultBoxByteMinBalance is the minimum balance requirement of each byte of the name and
+	// contents of a box.
+	DefaultBoxByteMinBalance = 400
+)
+
+// MBRParams are the consensus parameters of the minimum balance requirement of boxes, in
+// microAlgos. Zero fields take the default consensus values, so the zero MBRParams are the current
+// consensus parameters.
+type MBRParams struct {
+	// BoxFlatMinBalance is the minimum balance requirement of each box, DefaultBoxFlatMinBalance
+	// if zero.
+	BoxFlatMinBalance uint64
+	// BoxByteMinBalance is the minimum balance requirement of each byte of the name and contents
+	// of a box, DefaultBoxByteMinBalance if zero.
+	BoxByteMinBalance uint64
+}
+
+// DefaultMBRParams returns the current consensus parameters of the minimum balance requirement of
+// boxes, DefaultBoxFlatMinBalance and DefaultBoxByteMinBalance.
+func DefaultMBRParams() MBRParams {
+	return MBRParams{BoxFlatMinBalance: DefaultBoxFlatMinBalance, BoxByteMinBalance: DefaultBoxByteMinBalance}
+}
+
+// Box is the size of a box, as needed to compute its minimum balance requirement.
+type Box struct {
+	// NameLen is the length of the name of the box, in bytes.
+	NameLen uint64
+	// Size is the size of the contents of the box, in bytes.
+	Size uint64
+}
+
+// BoxMBR returns the minimum balance requirement, in microAlgos, which the application account
+// must hold for a box with a name of nameLen bytes and contents of size bytes:
+// BoxFlatMinBalance + BoxByteMinBalance * (nameLen + size). It fails if the requirement does not
+// fit in a uint64.
+func BoxMBR(nameLen, size uint64, params MBRParams) (uint64, error) {
+	if params.BoxFlatMinBalance == 0 {
+		params.BoxFlatMinBalance = DefaultBoxFlatMinBalance
+	}
+	if params.BoxByteMinBalance == 0 {
+		params.BoxByteMinBalance = DefaultBoxByteMinBalance
+	}
+	byteLen, carry := bits.Add64(nameLen, size, 0)
+	if carry != 0 {
+		return 0, fmt.Errorf("box minimum balance requirement overflows: box name and contents are %d and %d bytes long", nameLen, size)
+	}
+	hi, bytesMBR := bits.Mul64(params.BoxByteMinBalance, byteLen)
+	if hi != 0 {
+		return 0, fmt.Errorf("box minimum balance requirement overflows: %d bytes of %d microAlgos each", byteLen, params.BoxByteMinBalance)
+	}
+	total, carry := bits.Add64(params.BoxFlatMinBalance, bytesMBR, 0)
+	if carry != 0 {
+		return 0, fmt.Errorf("box minimum balance requirement overflows: %d microAlgos for the box and %d for its bytes", params.BoxFlatMinBalance, bytesMBR)
+	}
+	return total, nil
+}
+
+// BoxesMBR returns the sum of the minimum balance requirements of the boxes, as returned by
+// BoxMBR, which is the funding an application account needs to create them all. It fails if the
+// sum does not fit in a uint64.
+func BoxesMBR(boxes []Box, params MBRParams) (uint64, error) {
+	var total uint64
+	for i, box := range boxes {
+		mbr, err := BoxMBR(box.NameLen, box.Size, params)
+		if err != nil {
+			return 0, fmt.Errorf("box %d: %w", i, err)
+		}
+		var carry uint64
+		total, carry = bits.Add64(total, mbr, 0)
+		if carry != 0 {
+			return 0, fmt.Errorf("minimum balance requirement of boxes overflows after box %d", i)
+		}
+	}
+	return total, nil
+}
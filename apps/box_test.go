@@ -3,6 +3,8 @@ package apps
 import (
 	"fmt"
 	"github.com/stretchr/testify/require"
+	"math"
+	"strings"
 	"testing"
 )
 
@@ -44,5 +46,214 @@ func TestMakeBoxKey(t *testing.T) {
 		} else {
 			require.EqualError(t, err, tc.err, pp(tc))
 		}
+
+		appFromBytes, nameFromBytes, err := SplitBoxKeyBytes([]byte(tc.key))
+		if tc.err == "" {
+			require.Equal(t, uint64(tc.app), appFromBytes, pp(tc))
+			require.Equal(t, []byte(tc.name), nameFromBytes, pp(tc))
+		} else {
+			require.EqualError(t, err, strings.Replace(tc.err, "SplitBoxKey()", "SplitBoxKeyBytes()", 1), pp(tc))
+		}
+	}
+}
+
+func TestMakeBoxKeyBytes(t *testing.T) {
+	t.Parallel()
+
+	for _, app := range []uint64{0, 42, 131231, math.MaxUint64} {
+		for _, name := range []string{"", "stranger", "{\xbb\x04\a\xd1\xe2\xc6I\x81{"} {
+			key := MakeBoxKeyBytes(app, []byte(name))
+			require.Equal(t, MakeBoxKey(app, name), string(key))
+			require.Equal(t, len(key), cap(key))
+
+			appended := AppendBoxKey([]byte("prefix"), app, []byte(name))
+			require.Equal(t, "prefix"+MakeBoxKey(app, name), string(appended))
+		}
+	}
+}
+
+// TestAppendBoxKeyAllocations is not parallel, as testing.AllocsPerRun cannot measure parallel
+// tests.
+func TestAppendBoxKeyAllocations(t *testing.T) {
+	buffer := make([]byte, 0, 64)
+	name := []byte("name")
+	allocs := testing.AllocsPerRun(100, func() {
+		buffer = AppendBoxKey(buffer[:0], 7, name)
+	})
+	require.Zero(t, allocs)
+	require.Equal(t, MakeBoxKey(7, "name"), string(buffer))
+}
+
+func TestSplitBoxKeyBytesAliasing(t *testing.T) {
+	t.Parallel()
+
+	key := []byte(MakeBoxKey(7, "name"))
+	app, name, err := SplitBoxKeyBytes(key)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), app)
+	require.Equal(t, []byte("name"), name)
+
+	// the name is not copied
+	key[len(key)-1] = 'a'
+	require.Equal(t, []byte("nama"), name)
+}
+
+func TestNewBoxName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		spec     string
+		expected []byte
+	}{
+		{spec: "str:balances", expected: []byte("balances")},
+		{spec: "string:a:b", expected: []byte("a:b")},
+		{spec: "b64:AAEC", expected: []byte{0, 1, 2}},
+		{spec: "int:1", expected: []byte{0, 0, 0, 0, 0, 0, 0, 1}},
+		{spec: "uint16:1", expected: []byte{0, 1}},
+		{spec: "hex:0102", expected: []byte{1, 2}},
+		{spec: "0x0102", expected: []byte{1, 2}},
+		{spec: "addr:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAY5HFKQ", expected: make([]byte, 32)},
+		{spec: `abi:(uint8,string):[1,"x"]`, expected: []byte{1, 0, 3, 0, 1, 'x'}},
+	}
+	for _, testCase := range testCases {
+		name, err := NewBoxName(testCase.spec)
+		require.NoError(t, err, testCase.spec)
+		raw, err := name.Raw()
+		require.NoError(t, err, testCase.spec)
+		require.Equal(t, testCase.expected, raw, testCase.spec)
+
+		if testCase.spec != "0x0102" {
+			require.Equal(t, testCase.spec, name.String())
+		}
+	}
+
+	errorCases := []struct {
+		spec string
+		err  string
+	}{
+		{spec: "balances", err: "all arguments and box names should be of the form 'encoding:value'"},
+		{spec: "file:/tmp/name", err: "box names cannot use the file encoding"},
+		{spec: "binfile:/tmp/name", err: "box names cannot use the binfile encoding"},
+		{spec: "-", err: "box names cannot use the stdin encoding"},
+		{spec: "int:x", err: "Could not parse uint64 from string (x)"},
+		{spec: "b64:!", err: "Could not decode base64-encoded string (!)"},
+		{spec: "unknown:x", err: "Unknown encoding: unknown"},
+	}
+	for _, errorCase := range errorCases {
+		_, err := NewBoxName(errorCase.spec)
+		require.ErrorContains(t, err, errorCase.err, errorCase.spec)
+	}
+}
+
+func TestValidateBoxName(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateBoxName([]byte("a"), BoxLimits{}))
+	require.NoError(t, ValidateBoxName(make([]byte, 64), BoxLimits{}))
+	require.EqualError(t, ValidateBoxName(nil, BoxLimits{}), "box names cannot be empty")
+	require.EqualError(t, ValidateBoxName([]byte{}, BoxLimits{}), "box names cannot be empty")
+	require.EqualError(t, ValidateBoxName(make([]byte, 65), BoxLimits{}), "box name is 65 bytes long, more than the maximum of 64 bytes")
+
+	limits := BoxLimits{MaxBoxNameLen: 4}
+	require.NoError(t, ValidateBoxName([]byte("name"), limits))
+	require.EqualError(t, ValidateBoxName([]byte("names"), limits), "box name is 5 bytes long, more than the maximum of 4 bytes")
+	require.EqualError(t, ValidateBoxName(nil, limits), "box names cannot be empty")
+}
+
+func TestValidateBoxSize(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateBoxSize(0, BoxLimits{}))
+	require.NoError(t, ValidateBoxSize(32768, BoxLimits{}))
+	require.EqualError(t, ValidateBoxSize(32769, BoxLimits{}), "box size is 32769 bytes, more than the maximum of 32768 bytes")
+
+	limits := BoxLimits{MaxBoxSize: 1024}
+	require.NoError(t, ValidateBoxSize(1024, limits))
+	require.EqualError(t, ValidateBoxSize(1025, limits), "box size is 1025 bytes, more than the maximum of 1024 bytes")
+}
+
+func TestBoxMBR(t *testing.T) {
+	t.Parallel()
+
+	params := DefaultMBRParams()
+	testCases := []struct {
+		nameLen, size uint64
+		params        MBRParams
+		expected      uint64
+	}{
+		{nameLen: 0, size: 0, params: params, expected: 2500},
+		{nameLen: 8, size: 1024, params: params, expected: 2500 + 400*(8+1024)},
+		{nameLen: 64, size: 32768, params: params, expected: 2500 + 400*(64+32768)},
+		{nameLen: 4, size: 6, params: MBRParams{BoxFlatMinBalance: 1, BoxByteMinBalance: 2}, expected: 1 + 2*10},
+		// zero parameters take the default values
+		{nameLen: 4, size: 6, params: MBRParams{BoxByteMinBalance: 10}, expected: 2500 + 10*10},
+		{nameLen: 4, size: 6, params: MBRParams{BoxFlatMinBalance: 1000}, expected: 1000 + 400*10},
+		{nameLen: 4, size: 6, params: MBRParams{}, expected: 2500 + 400*10},
+		{nameLen: math.MaxUint64 - 1, size: 0, params: MBRParams{BoxFlatMinBalance: 1, BoxByteMinBalance: 1}, expected: math.MaxUint64},
+	}
+	for _, testCase := range testCases {
+		mbr, err := BoxMBR(testCase.nameLen, testCase.size, testCase.params)
+		require.NoError(t, err)
+		require.Equal(t, testCase.expected, mbr, testCase)
+	}
+
+	_, err := BoxMBR(math.MaxUint64, 1, MBRParams{})
+	require.ErrorContains(t, err, "box minimum balance requirement overflows")
+	_, err = BoxMBR(math.MaxUint64/400+1, 0, MBRParams{})
+	require.ErrorContains(t, err, "box minimum balance requirement overflows")
+	_, err = BoxMBR(math.MaxUint64, 0, MBRParams{BoxFlatMinBalance: 1, BoxByteMinBalance: 1})
+	require.ErrorContains(t, err, "box minimum balance requirement overflows")
+
+	total, err := BoxesMBR(nil, params)
+	require.NoError(t, err)
+	require.Zero(t, total)
+	boxes := []Box{{NameLen: 8, Size: 1024}, {NameLen: 4, Size: 0}}
+	total, err = BoxesMBR(boxes, params)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2*2500+400*(8+1024+4)), total)
+	total, err = BoxesMBR(boxes, MBRParams{BoxFlatMinBalance: 1, BoxByteMinBalance: 2})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2*1+2*(8+1024+4)), total)
+
+	_, err = BoxesMBR([]Box{{NameLen: 8}, {NameLen: math.MaxUint64, Size: 1}}, params)
+	require.ErrorContains(t, err, "box 1: box minimum balance requirement overflows")
+	_, err = BoxesMBR([]Box{{NameLen: math.MaxUint64 - 1}, {NameLen: 1}}, MBRParams{BoxFlatMinBalance: 1, BoxByteMinBalance: 1})
+	require.EqualError(t, err, "minimum balance requirement of boxes overflows after box 1")
+}
+
+func TestBoxKeyRange(t *testing.T) {
+	t.Parallel()
+
+	start, end := BoxKeyRange(1)
+	require.Equal(t, "bx:\x00\x00\x00\x00\x00\x00\x00\x01", start)
+	require.Equal(t, "bx:\x00\x00\x00\x00\x00\x00\x00\x02", end)
+
+	start, end = BoxKeyRange(0xff)
+	require.Equal(t, "bx:\x00\x00\x00\x00\x00\x00\x00\xff", start)
+	require.Equal(t, "bx:\x00\x00\x00\x00\x00\x00\x01", end)
+
+	start, end = BoxKeyRange(math.MaxUint64)
+	require.Equal(t, "bx:\xff\xff\xff\xff\xff\xff\xff\xff", start)
+	require.Equal(t, "bx;", end)
+
+	start, end = BoxKeyRangeWithPrefix(1, "ab")
+	require.Equal(t, "bx:\x00\x00\x00\x00\x00\x00\x00\x01ab", start)
+	require.Equal(t, "bx:\x00\x00\x00\x00\x00\x00\x00\x01ac", end)
+
+	start, end = BoxKeyRangeWithPrefix(1, "a\xff")
+	require.Equal(t, "bx:\x00\x00\x00\x00\x00\x00\x00\x01a\xff", start)
+	require.Equal(t, "bx:\x00\x00\x00\x00\x00\x00\x00\x01b", end)
+
+	inRange := func(key, start, end string) bool {
+		return key >= start && key < end
+	}
+	start, end = BoxKeyRange(7)
+	startPrefix, endPrefix := BoxKeyRangeWithPrefix(7, "ab")
+	for _, app := range []uint64{6, 7, 8, 7 << 8, math.MaxUint64} {
+		for _, name := range []string{"", "a", "ab", "ab\x00", "ab\xff\xff", "ac", "\xff"} {
+			key := MakeBoxKey(app, name)
+			require.Equal(t, app == 7, inRange(key, start, end), "%d %q", app, name)
+			require.Equal(t, app == 7 && strings.HasPrefix(name, "ab"), inRange(key, startPrefix, endPrefix), "%d %q", app, name)
+		}
 	}
 }
@@ -0,0 +1,120 @@
+package apps
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/algorand/avm-abi/abi"
+	"github.com/algorand/avm-abi/address"
+)
+
+// Hint is an encoding which FormatAppCallBytes prefers for some bytes. Hints are the encodings of
+// AppCallBytes which can be computed from the bytes: "str", "int", "uint8", "uint16", "uint32",
+// "uint64", "addr", "b32", "b64", and "hex", or their longer names, and "abi:<type>" to format the
+// bytes as a value of an ABI type, such as "abi:(uint64,string)".
+type Hint string
+
+// FormatAppCallBytes renders raw as an "encoding:value" argument which NewAppCallBytes parses back
+// to the same bytes. The first hint which can represent raw is used, and hints which cannot, or
+// which are unknown, are skipped. Without a usable hint, the most readable encoding is guessed:
+// printable UTF-8 text is formatted as str, 32 bytes as addr, 8 bytes as int, and other bytes as
+// b64.
+func FormatAppCallBytes(raw []byte, hints ...Hint) string {
+	for _, hint := range hints {
+		if formatted, ok := formatAs(string(hint), raw); ok {
+			return formatted
+		}
+	}
+	switch {
+	case isPrintable(raw):
+		formatted, _ := formatAs("str", raw)
+		return formatted
+	case len(raw) == address.BytesSize:
+		formatted, _ := formatAs("addr", raw)
+		return formatted
+	case len(raw) == 8:
+		formatted, _ := formatAs("int", raw)
+		return formatted
+	default:
+		formatted, _ := formatAs("b64", raw)
+		return formatted
+	}
+}
+
+// formatAs renders raw in the given encoding, and reports whether the encoding can represent raw.
+func formatAs(encoding string, raw []byte) (string, bool) {
+	switch encoding {
+	case "str", "string":
+		if !utf8.Valid(raw) {
+			return "", false
+		}
+		return "str:" + string(raw), true
+	case "int", "integer":
+		if len(raw) != 8 {
+			return "", false
+		}
+		return "int:" + strconv.FormatUint(binary.BigEndian.Uint64(raw), 10), true
+	case "uint8", "uint16", "uint32", "uint64":
+		bitSize, _ := strconv.Atoi(strings.TrimPrefix(encoding, "uint"))
+		if len(raw) != bitSize/8 {
+			return "", false
+		}
+		var ibytes [8]byte
+		copy(ibytes[8-len(raw):], raw)
+		return encoding + ":" + strconv.FormatUint(binary.BigEndian.Uint64(ibytes[:]), 10), true
+	case "addr", "address":
+		addr, err := address.FromBytes(raw)
+		if err != nil {
+			return "", false
+		}
+		return "addr:" + addr.String(), true
+	case "b32", "base32", "byte base32":
+		return "b32:" + base32.StdEncoding.EncodeToString(raw), true
+	case "b64", "base64", "byte base64":
+		return "b64:" + base64.StdEncoding.EncodeToString(raw), true
+	case "hex":
+		return "hex:" + hex.EncodeToString(raw), true
+	}
+
+	typeString, ok := strings.CutPrefix(encoding, "abi:")
+	if !ok {
+		return "", false
+	}
+	abiType, err := abi.TypeOf(typeString)
+	if err != nil {
+		return "", false
+	}
+	value, err := abiType.Decode(raw)
+	if err != nil {
+		return "", false
+	}
+	// values which are not canonically encoded would not be parsed back to raw
+	if encoded, err := abiType.Encode(value); err != nil || !bytes.Equal(encoded, raw) {
+		return "", false
+	}
+	jsonValue, err := abiType.MarshalToJSON(value)
+	if err != nil {
+		return "", false
+	}
+	return "abi:" + abiType.String() + ":" + string(jsonValue), true
+}
+
+// isPrintable reports whether raw is UTF-8 text made of printable characters and spaces.
+func isPrintable(raw []byte) bool {
+	if !utf8.Valid(raw) {
+		return false
+	}
+	for _, r := range string(raw) {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/algorand/avm-abi/address"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatAppCallBytes(t *testing.T) {
+	t.Parallel()
+
+	addr := address.ForApplication(1)
+
+	testCases := []struct {
+		raw      []byte
+		hints    []Hint
+		expected string
+	}{
+		{raw: nil, expected: "str:"},
+		{raw: []byte("hello world"), expected: "str:hello world"},
+		{raw: []byte("key:value"), expected: "str:key:value"},
+		{raw: []byte("0x12"), expected: "str:0x12"},
+		{raw: []byte("line\n"), expected: "b64:bGluZQo="},
+		{raw: addr[:], expected: "addr:" + addr.String()},
+		{raw: []byte("abcdefghijklmnopqrstuvwxyz012345"), expected: "str:abcdefghijklmnopqrstuvwxyz012345"},
+		{raw: []byte{0, 0, 0, 0, 0, 0, 1, 0}, expected: "int:256"},
+		{raw: []byte("abcdefgh"), expected: "str:abcdefgh"},
+		{raw: []byte{0xde, 0xad, 0xbe, 0xef}, expected: "b64:3q2+7w=="},
+
+		{raw: []byte("abcdefgh"), hints: []Hint{"int"}, expected: "int:7017280452245743464"},
+		{raw: []byte{0xde, 0xad, 0xbe, 0xef}, hints: []Hint{"hex"}, expected: "hex:deadbeef"},
+		{raw: []byte{0xde, 0xad, 0xbe, 0xef}, hints: []Hint{"base32"}, expected: "b32:32W353Y="},
+		{raw: []byte("hello"), hints: []Hint{"b64"}, expected: "b64:aGVsbG8="},
+		{raw: []byte("line\n"), hints: []Hint{"string"}, expected: "str:line\n"},
+		{raw: []byte{0xff}, hints: []Hint{"str", "addr", "int", "hex"}, expected: "hex:ff"},
+		{raw: []byte{0xff}, hints: []Hint{"uint16", "uint8"}, expected: "uint8:255"},
+		{raw: []byte{1, 2, 3, 4}, hints: []Hint{"uint32"}, expected: "uint32:16909060"},
+		{raw: []byte{0xff}, hints: []Hint{"unknown", "abi:uint7"}, expected: "b64:/w=="},
+		{raw: []byte("abcdefgh"), hints: []Hint{"address"}, expected: "str:abcdefgh"},
+		{raw: make([]byte, 32), hints: []Hint{"addr"}, expected: "addr:" + address.ZeroAddressString},
+		{
+			raw:      []byte{0, 0, 0, 0, 0, 0, 1, 143, 0, 10, 0, 2, 104, 105},
+			hints:    []Hint{"abi:(uint64,string)"},
+			expected: `abi:(uint64,string):[399,"hi"]`,
+		},
+		{raw: []byte{0, 0, 0, 0, 0, 0, 1, 143}, hints: []Hint{"abi:(uint64,string)", "abi:uint64"}, expected: "abi:uint64:399"},
+		// a boolean must be 0x00 or 0x80
+		{raw: []byte{1}, hints: []Hint{"abi:bool"}, expected: "b64:AQ=="},
+	}
+	for _, testCase := range testCases {
+		formatted := FormatAppCallBytes(testCase.raw, testCase.hints...)
+		require.Equal(t, testCase.expected, formatted, "%x %v", testCase.raw, testCase.hints)
+
+		acb, err := NewAppCallBytes(formatted)
+		require.NoError(t, err, formatted)
+		raw, err := acb.Raw()
+		require.NoError(t, err, formatted)
+		require.Equal(t, string(testCase.raw), string(raw), formatted)
+	}
+}
@@ -0,0 +1,118 @@
+package apps
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/algorand/avm-abi/abi"
+)
+
+// NoteFormat is the format of the data of an ARC-2 transaction note.
+type NoteFormat byte
+
+const (
+	// NoteFormatMsgpack is msgpack encoded data.
+	NoteFormatMsgpack NoteFormat = 'm'
+	// NoteFormatJSON is JSON encoded data.
+	NoteFormatJSON NoteFormat = 'j'
+	// NoteFormatBytes is arbitrary bytes.
+	NoteFormatBytes NoteFormat = 'b'
+	// NoteFormatUTF8 is a UTF-8 string.
+	NoteFormatUTF8 NoteFormat = 'u'
+)
+
+// MaxNoteSize is the maximum size in bytes of a transaction note.
+const MaxNoteSize = 1024
+
+// dAppNamePattern matches the dApp names allowed by ARC-2.
+var dAppNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_/@.-]{4,31}$`)
+
+// Note is an ARC-2 transaction note, of the form "<dapp-name>:<format><data>", which lets indexers
+// and explorers attribute transactions to the dApp which made them.
+type Note struct {
+	// DAppName is the name of the dApp, 5 to 32 characters long.
+	DAppName string
+	// Format is the format of Data.
+	Format NoteFormat
+	// Data is the content of the note.
+	Data []byte
+}
+
+// NewJSONNote creates a note in the JSON format whose data is value, of type abiType, as encoded by
+// `abi.Type.MarshalToJSON`.
+func NewJSONNote(dAppName string, abiType abi.Type, value interface{}) (Note, error) {
+	data, err := abiType.MarshalToJSON(value)
+	if err != nil {
+		return Note{}, err
+	}
+	note := Note{DAppName: dAppName, Format: NoteFormatJSON, Data: data}
+	if err := note.Validate(); err != nil {
+		return Note{}, err
+	}
+	return note, nil
+}
+
+// ParseNote parses an ARC-2 transaction note, and verifies that it is valid.
+func ParseNote(note []byte) (Note, error) {
+	separator := bytes.IndexByte(note, ':')
+	if separator < 0 || separator+1 >= len(note) {
+		return Note{}, fmt.Errorf("note should be of the form '<dapp-name>:<format><data>'")
+	}
+	parsed := Note{
+		DAppName: string(note[:separator]),
+		Format:   NoteFormat(note[separator+1]),
+		Data:     append([]byte(nil), note[separator+2:]...),
+	}
+	if err := parsed.Validate(); err != nil {
+		return Note{}, err
+	}
+	return parsed, nil
+}
+
+// Validate checks that the dApp name and format of the note are allowed by ARC-2, that the data of
+// UTF-8 and JSON notes is valid, and that the encoded note fits in a transaction.
+func (n Note) Validate() error {
+	if !dAppNamePattern.MatchString(n.DAppName) {
+		return fmt.Errorf("invalid dApp name %q", n.DAppName)
+	}
+	switch n.Format {
+	case NoteFormatMsgpack, NoteFormatBytes:
+	case NoteFormatUTF8:
+		if !utf8.Valid(n.Data) {
+			return fmt.Errorf("data of note in format %q is not valid UTF-8", n.Format)
+		}
+	case NoteFormatJSON:
+		if !json.Valid(n.Data) {
+			return fmt.Errorf("data of note in format %q is not valid JSON", n.Format)
+		}
+	default:
+		return fmt.Errorf("unknown note format %q", n.Format)
+	}
+	if size := len(n.DAppName) + 2 + len(n.Data); size > MaxNoteSize {
+		return fmt.Errorf("note is %d bytes long, more than the maximum of %d", size, MaxNoteSize)
+	}
+	return nil
+}
+
+// Encode returns the note as the bytes of a transaction note field.
+func (n Note) Encode() ([]byte, error) {
+	if err := n.Validate(); err != nil {
+		return nil, err
+	}
+	encoded := make([]byte, 0, len(n.DAppName)+2+len(n.Data))
+	encoded = append(encoded, n.DAppName...)
+	encoded = append(encoded, ':', byte(n.Format))
+	return append(encoded, n.Data...), nil
+}
+
+// DecodeJSON decodes the data of a JSON note as a value of type abiType, with
+// `abi.Type.UnmarshalFromJSON`.
+func (n Note) DecodeJSON(abiType abi.Type) (interface{}, error) {
+	if n.Format != NoteFormatJSON {
+		return nil, fmt.Errorf("note is in format %q, not %q", n.Format, NoteFormatJSON)
+	}
+	return abiType.UnmarshalFromJSON(n.Data)
+}
@@ -0,0 +1,84 @@
+package apps
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/avm-abi/abi"
+)
+
+func TestNote(t *testing.T) {
+	t.Parallel()
+
+	note, err := ParseNote([]byte("my-dapp:uhello"))
+	require.NoError(t, err)
+	require.Equal(t, Note{DAppName: "my-dapp", Format: NoteFormatUTF8, Data: []byte("hello")}, note)
+	encoded, err := note.Encode()
+	require.NoError(t, err)
+	require.Equal(t, []byte("my-dapp:uhello"), encoded)
+
+	// only the first colon separates the dApp name
+	note, err = ParseNote([]byte("my-dapp:b\x00:\xff"))
+	require.NoError(t, err)
+	require.Equal(t, Note{DAppName: "my-dapp", Format: NoteFormatBytes, Data: []byte("\x00:\xff")}, note)
+
+	note, err = ParseNote([]byte("my-dapp:m"))
+	require.NoError(t, err)
+	require.Equal(t, Note{DAppName: "my-dapp", Format: NoteFormatMsgpack}, note)
+
+	errorCases := []struct {
+		note string
+		err  string
+	}{
+		{note: "my-dapp", err: "note should be of the form '<dapp-name>:<format><data>'"},
+		{note: "my-dapp:", err: "note should be of the form '<dapp-name>:<format><data>'"},
+		{note: "dapp:uhello", err: `invalid dApp name "dapp"`},
+		{note: "-dapp:uhello", err: `invalid dApp name "-dapp"`},
+		{note: "my dapp:uhello", err: `invalid dApp name "my dapp"`},
+		{note: strings.Repeat("a", 33) + ":uhello", err: "invalid dApp name"},
+		{note: "my-dapp:xhello", err: `unknown note format 'x'`},
+		{note: "my-dapp:u\xff", err: `data of note in format 'u' is not valid UTF-8`},
+		{note: "my-dapp:j{", err: `data of note in format 'j' is not valid JSON`},
+		{note: "my-dapp:b" + strings.Repeat("a", 1016), err: "note is 1025 bytes long, more than the maximum of 1024"},
+	}
+	for _, errorCase := range errorCases {
+		_, err := ParseNote([]byte(errorCase.note))
+		require.ErrorContains(t, err, errorCase.err, errorCase.note)
+	}
+
+	_, err = Note{DAppName: "my-dapp", Format: 'x'}.Encode()
+	require.EqualError(t, err, `unknown note format 'x'`)
+}
+
+func TestJSONNote(t *testing.T) {
+	t.Parallel()
+
+	idType, err := abi.TypeOf("uint64")
+	require.NoError(t, err)
+	labelType, err := abi.TypeOf("string")
+	require.NoError(t, err)
+	pointType, err := abi.MakeNamedTupleType([]abi.Type{idType, labelType}, []string{"id", "label"})
+	require.NoError(t, err)
+
+	note, err := NewJSONNote("my-dapp", pointType, []interface{}{uint64(7), "start"})
+	require.NoError(t, err)
+	encoded, err := note.Encode()
+	require.NoError(t, err)
+	require.Equal(t, `my-dapp:j{"id":7,"label":"start"}`, string(encoded))
+
+	parsed, err := ParseNote(encoded)
+	require.NoError(t, err)
+	value, err := parsed.DecodeJSON(pointType)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{uint64(7), "start"}, value)
+
+	_, err = NewJSONNote("dapp", pointType, []interface{}{uint64(7), "start"})
+	require.EqualError(t, err, `invalid dApp name "dapp"`)
+	_, err = NewJSONNote("my-dapp", pointType, []interface{}{uint64(7)})
+	require.Error(t, err)
+
+	_, err = Note{DAppName: "my-dapp", Format: NoteFormatUTF8}.DecodeJSON(pointType)
+	require.EqualError(t, err, `note is in format 'u', not 'j'`)
+}
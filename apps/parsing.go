@@ -1,10 +1,15 @@
 package apps
 
 import (
+	"bytes"
 	"encoding/base32"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 
@@ -12,14 +17,52 @@ import (
 	"github.com/algorand/avm-abi/address"
 )
 
+// DefaultMaxFileSize is the maximum size, in bytes, of the files read by the file and binfile
+// encodings of AppCallBytes, and of the standard input read by the stdin encoding, unless
+// RawOptions.MaxFileSize is set.
+const DefaultMaxFileSize = 1 << 20
+
+// RawOptions configures how RawWithOptions and ValidateWithOptions read the values of the file,
+// binfile, and stdin encodings of AppCallBytes.
+type RawOptions struct {
+	// MaxFileSize is the maximum size, in bytes, of the files read by the file and binfile
+	// encodings, and of the standard input read by the stdin encoding. If it is 0,
+	// DefaultMaxFileSize is used.
+	MaxFileSize int64
+	// Stdin is read by the stdin encoding. If it is nil, os.Stdin is used.
+	Stdin io.Reader
+}
+
+func (opts RawOptions) maxFileSize() int64 {
+	if opts.MaxFileSize == 0 {
+		return DefaultMaxFileSize
+	}
+	return opts.MaxFileSize
+}
+
+func (opts RawOptions) stdin() io.Reader {
+	if opts.Stdin == nil {
+		return os.Stdin
+	}
+	return opts.Stdin
+}
+
 // AppCallBytes represents an encoding and a value of an app call argument.
 type AppCallBytes struct {
 	Encoding string `codec:"encoding"`
 	Value    string `codec:"value"`
 }
 
-// NewAppCallBytes parses an argument of the form "encoding:value" to AppCallBytes.
+// NewAppCallBytes parses an argument of the form "encoding:value" to AppCallBytes. A 0x-prefixed
+// argument, such as "0xDEADBEEF", is parsed as the value of the hex encoding, and the argument "-"
+// as the stdin encoding.
 func NewAppCallBytes(arg string) (AppCallBytes, error) {
+	if arg == "-" {
+		return AppCallBytes{Encoding: "stdin"}, nil
+	}
+	if strings.HasPrefix(arg, "0x") || strings.HasPrefix(arg, "0X") {
+		return AppCallBytes{Encoding: "hex", Value: arg}, nil
+	}
 	parts := strings.SplitN(arg, ":", 2)
 	if len(parts) != 2 {
 		return AppCallBytes{}, fmt.Errorf("all arguments and box names should be of the form 'encoding:value'")
@@ -30,8 +73,66 @@ func NewAppCallBytes(arg string) (AppCallBytes, error) {
 	}, nil
 }
 
+// String returns the argument in the "encoding:value" form parsed by NewAppCallBytes.
+func (arg AppCallBytes) String() string {
+	return arg.Encoding + ":" + arg.Value
+}
+
+// MarshalText encodes the argument in its "encoding:value" form.
+func (arg AppCallBytes) MarshalText() ([]byte, error) {
+	return []byte(arg.String()), nil
+}
+
+// UnmarshalText parses the argument from its "encoding:value" form, as NewAppCallBytes.
+func (arg *AppCallBytes) UnmarshalText(text []byte) error {
+	parsed, err := NewAppCallBytes(string(text))
+	if err != nil {
+		return err
+	}
+	*arg = parsed
+	return nil
+}
+
+// MarshalJSON encodes the argument as a JSON string of its "encoding:value" form.
+func (arg AppCallBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(arg.String())
+}
+
+// UnmarshalJSON parses the argument from a JSON string of its "encoding:value" form. It also
+// accepts a JSON object with encoding and value fields, the form of AppCallBytes before it
+// implemented json.Marshaler. A JSON null leaves the argument unchanged.
+func (arg *AppCallBytes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		return arg.UnmarshalText([]byte(text))
+	}
+	// appCallBytes has no methods, so decoding it does not call UnmarshalJSON again
+	type appCallBytes AppCallBytes
+	var fields appCallBytes
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("app call bytes must be a JSON string or object: %w", err)
+	}
+	*arg = AppCallBytes(fields)
+	return nil
+}
+
 // Raw converts an AppCallBytes arg to a byte array.
-func (arg AppCallBytes) Raw() (rawValue []byte, parseErr error) {
+//
+// The file and binfile encodings read the value from the file at the path given as value, of at
+// most DefaultMaxFileSize bytes, when Raw is called. The file encoding is meant for text files, and
+// drops a single trailing line ending from the contents, while the binfile encoding uses the
+// contents unchanged. The stdin encoding, which takes no value, similarly reads standard input until
+// its end, so only the first argument with the stdin encoding gets its contents.
+func (arg AppCallBytes) Raw() ([]byte, error) {
+	return arg.RawWithOptions(RawOptions{})
+}
+
+// RawWithOptions converts an AppCallBytes arg to a byte array as Raw, reading the values of the
+// file, binfile, and stdin encodings as configured by opts.
+func (arg AppCallBytes) RawWithOptions(opts RawOptions) (rawValue []byte, parseErr error) {
 	switch arg.Encoding {
 	case "str", "string":
 		rawValue = []byte(arg.Value)
@@ -44,6 +145,16 @@ func (arg AppCallBytes) Raw() (rawValue []byte, parseErr error) {
 		ibytes := make([]byte, 8)
 		binary.BigEndian.PutUint64(ibytes, num)
 		rawValue = ibytes
+	case "uint8", "uint16", "uint32", "uint64":
+		bitSize, _ := strconv.Atoi(strings.TrimPrefix(arg.Encoding, "uint"))
+		num, err := strconv.ParseUint(arg.Value, 10, bitSize)
+		if err != nil {
+			parseErr = fmt.Errorf("Could not parse %s from string (%s): %v", arg.Encoding, arg.Value, err)
+			return
+		}
+		ibytes := make([]byte, 8)
+		binary.BigEndian.PutUint64(ibytes, num)
+		rawValue = ibytes[8-bitSize/8:]
 	case "addr", "address":
 		addr, err := address.FromString(arg.Value)
 		if err != nil {
@@ -65,6 +176,35 @@ func (arg AppCallBytes) Raw() (rawValue []byte, parseErr error) {
 			return
 		}
 		rawValue = data
+	case "hex":
+		data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(arg.Value, "0x"), "0X"))
+		if err != nil {
+			parseErr = fmt.Errorf("Could not decode hex-encoded string (%s): %v", arg.Value, err)
+			return
+		}
+		rawValue = data
+	case "file", "binfile":
+		data, err := readFile(arg.Value, opts.maxFileSize())
+		if err != nil {
+			parseErr = err
+			return
+		}
+		if arg.Encoding == "file" {
+			data = trimLineEnding(data)
+		}
+		rawValue = data
+	case "stdin":
+		if parseErr = checkStdinValue(arg.Value); parseErr != nil {
+			return
+		}
+		rawValue, parseErr = readLimited(opts.stdin(), "standard input", opts.maxFileSize())
+	case "method":
+		method, err := abi.MethodFromSignature(arg.Value)
+		if err != nil {
+			parseErr = fmt.Errorf("Could not decode method signature (%s): %v", arg.Value, err)
+			return
+		}
+		rawValue = method.GetSelector().Bytes()
 	case "abi":
 		typeAndValue := strings.SplitN(arg.Value, ":", 2)
 		if len(typeAndValue) != 2 {
@@ -87,3 +227,79 @@ func (arg AppCallBytes) Raw() (rawValue []byte, parseErr error) {
 	}
 	return
 }
+
+// Validate checks that the value of the argument is valid for its encoding, returning the error
+// which Raw would return for it, so all invalid arguments can be reported before any is used.
+// Files of the file and binfile encodings are checked to exist and not to be larger than
+// DefaultMaxFileSize, but are not read, and standard input is not read.
+func (arg AppCallBytes) Validate() error {
+	return arg.ValidateWithOptions(RawOptions{})
+}
+
+// ValidateWithOptions checks the argument as Validate, returning the error which RawWithOptions
+// would return for it with opts.
+func (arg AppCallBytes) ValidateWithOptions(opts RawOptions) error {
+	switch arg.Encoding {
+	case "file", "binfile":
+		return checkFile(arg.Value, opts.maxFileSize())
+	case "stdin":
+		return checkStdinValue(arg.Value)
+	default:
+		_, err := arg.Raw()
+		return err
+	}
+}
+
+// checkStdinValue checks the value of an argument with the stdin encoding, which must be empty.
+func checkStdinValue(value string) error {
+	if value != "" {
+		return fmt.Errorf("Could not read standard input: the stdin encoding takes no value, got (%s)", value)
+	}
+	return nil
+}
+
+// checkFile checks that the file at path can be read by readFile, without reading it.
+func checkFile(path string, maxSize int64) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("Could not open file (%s): %v", path, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("Could not read file (%s): it is a directory", path)
+	}
+	if info.Size() > maxSize {
+		return fmt.Errorf("Could not read file (%s): it is larger than the maximum size of %d bytes", path, maxSize)
+	}
+	return nil
+}
+
+// readFile reads the file at path, which must not be larger than maxSize bytes.
+func readFile(path string, maxSize int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not open file (%s): %v", path, err)
+	}
+	defer f.Close()
+	return readLimited(f, fmt.Sprintf("file (%s)", path), maxSize)
+}
+
+// readLimited reads r until its end, failing if it has more than maxSize bytes. name describes r in
+// errors.
+func readLimited(r io.Reader, name string, maxSize int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("Could not read %s: %v", name, err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("Could not read %s: it is larger than the maximum size of %d bytes", name, maxSize)
+	}
+	return data, nil
+}
+
+// trimLineEnding removes a single trailing "\n" or "\r\n" from data.
+func trimLineEnding(data []byte) []byte {
+	if !bytes.HasSuffix(data, []byte("\n")) {
+		return data
+	}
+	return bytes.TrimSuffix(data[:len(data)-1], []byte("\r"))
+}
@@ -1,11 +1,15 @@
 package apps
 
 import (
+	"bytes"
 	"encoding/base32"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"math"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -22,7 +26,7 @@ func TestNewAppCallBytes(t *testing.T) {
 		_, err := NewAppCallBytes("hello")
 		require.Error(t, err)
 
-		for _, v := range []string{":x", "int:-1"} {
+		for _, v := range []string{":x", "int:-1", "uint8:256", "uint16:65536", "uint32:4294967296", "uint64:18446744073709551616", "uint8:-1", "uint8:", "hex:zz", "hex:abc", "0x1", "method:add", "method:add(uint64", "method:add(uint7)void"} {
 			acb, _ := NewAppCallBytes(v)
 			_, err = acb.Raw()
 			require.Error(t, err)
@@ -68,6 +72,55 @@ func TestNewAppCallBytes(t *testing.T) {
 		}
 	}
 
+	for _, v := range []string{"hex:DEADBEEF", "hex:deadbeef", "hex:0xDEADBEEF", "0xdeadbeef", "0XDEADBEEF"} {
+		v := v
+		t.Run(fmt.Sprintf("value=%v", v), func(t *testing.T) {
+			t.Parallel()
+			acb, err := NewAppCallBytes(v)
+			require.NoError(t, err)
+			require.Equal(t, "hex", acb.Encoding)
+			r, err := acb.Raw()
+			require.NoError(t, err)
+			require.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, r)
+		})
+	}
+
+	for _, v := range []string{"method:add(uint64,uint64)uint64", "method:add(uint64, uint64)uint64"} {
+		v := v
+		t.Run(fmt.Sprintf("value=%v", v), func(t *testing.T) {
+			t.Parallel()
+			acb, err := NewAppCallBytes(v)
+			require.NoError(t, err)
+			r, err := acb.Raw()
+			require.NoError(t, err)
+			require.Equal(t, []byte{0xfe, 0x6b, 0xdf, 0x69}, r)
+		})
+	}
+
+	fixedWidthCases := []struct {
+		arg      string
+		expected []byte
+	}{
+		{arg: "uint8:0", expected: []byte{0}},
+		{arg: "uint8:255", expected: []byte{0xff}},
+		{arg: "uint16:258", expected: []byte{1, 2}},
+		{arg: "uint32:16909060", expected: []byte{1, 2, 3, 4}},
+		{arg: "uint64:1", expected: []byte{0, 0, 0, 0, 0, 0, 0, 1}},
+		{arg: "uint64:18446744073709551615", expected: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+	}
+	for _, fixedWidthCase := range fixedWidthCases {
+		fixedWidthCase := fixedWidthCase
+		t.Run(fixedWidthCase.arg, func(t *testing.T) {
+			t.Parallel()
+			acb, err := NewAppCallBytes(fixedWidthCase.arg)
+			require.NoError(t, err)
+			r, err := acb.Raw()
+			require.NoError(t, err)
+			require.Equal(t, fixedWidthCase.expected, r)
+			require.Equal(t, fixedWidthCase.arg, FormatAppCallBytes(r, Hint(acb.Encoding)))
+		})
+	}
+
 	for _, v := range []uint64{1, 0, math.MaxUint64} {
 		for _, e := range []string{"int", "integer"} {
 			v, e := v, e
@@ -130,3 +183,187 @@ func TestNewAppCallBytes(t *testing.T) {
 		}
 	}
 }
+
+func TestAppCallBytesFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile := func(name string, contents []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, contents, 0o600))
+		return path
+	}
+
+	testCases := []struct {
+		contents string
+		file     string
+		binfile  string
+	}{
+		{contents: "", file: "", binfile: ""},
+		{contents: "hello", file: "hello", binfile: "hello"},
+		{contents: "hello\n", file: "hello", binfile: "hello\n"},
+		{contents: "hello\r\n", file: "hello", binfile: "hello\r\n"},
+		{contents: "hello\n\n", file: "hello\n", binfile: "hello\n\n"},
+		{contents: "hello\r", file: "hello\r", binfile: "hello\r"},
+		{contents: "\x00\xff\n", file: "\x00\xff", binfile: "\x00\xff\n"},
+	}
+	for i, testCase := range testCases {
+		path := writeFile(fmt.Sprintf("case%d", i), []byte(testCase.contents))
+
+		acb, err := NewAppCallBytes("file:" + path)
+		require.NoError(t, err)
+		r, err := acb.Raw()
+		require.NoError(t, err)
+		require.Equal(t, testCase.file, string(r), testCase.contents)
+
+		acb, err = NewAppCallBytes("binfile:" + path)
+		require.NoError(t, err)
+		r, err = acb.Raw()
+		require.NoError(t, err)
+		require.Equal(t, testCase.binfile, string(r), testCase.contents)
+	}
+
+	maxSize := writeFile("max", make([]byte, DefaultMaxFileSize))
+	r, err := AppCallBytes{Encoding: "binfile", Value: maxSize}.Raw()
+	require.NoError(t, err)
+	require.Len(t, r, DefaultMaxFileSize)
+
+	tooLarge := writeFile("large", make([]byte, DefaultMaxFileSize+1))
+	_, err = AppCallBytes{Encoding: "binfile", Value: tooLarge}.Raw()
+	require.EqualError(t, err, fmt.Sprintf("Could not read file (%s): it is larger than the maximum size of %d bytes", tooLarge, DefaultMaxFileSize))
+
+	small := writeFile("small", []byte("hello"))
+	opts := RawOptions{MaxFileSize: 5}
+	r, err = AppCallBytes{Encoding: "binfile", Value: small}.RawWithOptions(opts)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), r)
+	opts.MaxFileSize = 4
+	_, err = AppCallBytes{Encoding: "binfile", Value: small}.RawWithOptions(opts)
+	require.EqualError(t, err, fmt.Sprintf("Could not read file (%s): it is larger than the maximum size of 4 bytes", small))
+	require.EqualError(t, AppCallBytes{Encoding: "file", Value: small}.ValidateWithOptions(opts), fmt.Sprintf("Could not read file (%s): it is larger than the maximum size of 4 bytes", small))
+
+	_, err = AppCallBytes{Encoding: "file", Value: filepath.Join(dir, "missing")}.Raw()
+	require.ErrorContains(t, err, "Could not open file")
+	_, err = AppCallBytes{Encoding: "file", Value: dir}.Raw()
+	require.ErrorContains(t, err, "Could not read file")
+}
+
+func TestAppCallBytesStdin(t *testing.T) {
+	t.Parallel()
+
+	for _, arg := range []string{"stdin:", "-"} {
+		opts := RawOptions{Stdin: bytes.NewReader([]byte("payload\x00\n"))}
+		acb, err := NewAppCallBytes(arg)
+		require.NoError(t, err)
+		require.Equal(t, AppCallBytes{Encoding: "stdin"}, acb)
+		r, err := acb.RawWithOptions(opts)
+		require.NoError(t, err)
+		require.Equal(t, []byte("payload\x00\n"), r)
+
+		// standard input is consumed
+		r, err = acb.RawWithOptions(opts)
+		require.NoError(t, err)
+		require.Empty(t, r)
+	}
+
+	_, err := AppCallBytes{Encoding: "stdin"}.RawWithOptions(RawOptions{Stdin: bytes.NewReader(make([]byte, DefaultMaxFileSize+1))})
+	require.EqualError(t, err, fmt.Sprintf("Could not read standard input: it is larger than the maximum size of %d bytes", DefaultMaxFileSize))
+
+	_, err = AppCallBytes{Encoding: "stdin"}.RawWithOptions(RawOptions{Stdin: bytes.NewReader([]byte("hello")), MaxFileSize: 4})
+	require.EqualError(t, err, "Could not read standard input: it is larger than the maximum size of 4 bytes")
+
+	_, err = AppCallBytes{Encoding: "stdin", Value: "x"}.Raw()
+	require.EqualError(t, err, "Could not read standard input: the stdin encoding takes no value, got (x)")
+}
+
+func TestAppCallBytesJSON(t *testing.T) {
+	t.Parallel()
+
+	args := []AppCallBytes{
+		{Encoding: "str", Value: "hello:world"},
+		{Encoding: "int", Value: "5"},
+		{Encoding: "abi", Value: `(uint64,string):[1,"a\"b"]`},
+		{Encoding: "hex", Value: "0xdeadbeef"},
+		{Encoding: "stdin"},
+	}
+	encoded, err := json.Marshal(args)
+	require.NoError(t, err)
+	require.Equal(t, `["str:hello:world","int:5","abi:(uint64,string):[1,\"a\\\"b\"]","hex:0xdeadbeef","stdin:"]`, string(encoded))
+
+	var decoded []AppCallBytes
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, args, decoded)
+	for i, arg := range args {
+		text, err := arg.MarshalText()
+		require.NoError(t, err)
+		var fromText AppCallBytes
+		require.NoError(t, fromText.UnmarshalText(text))
+		require.Equal(t, arg, fromText)
+		require.Equal(t, string(text), decoded[i].String())
+	}
+
+	type manifest struct {
+		Args    []AppCallBytes `json:"args"`
+		BoxName *AppCallBytes  `json:"boxName"`
+	}
+	var m manifest
+	require.NoError(t, json.Unmarshal([]byte(`{"args": ["0x01", {"encoding": "int", "value": "1"}, {"Encoding": "str", "Value": "x"}], "boxName": null}`), &m))
+	require.Equal(t, manifest{Args: []AppCallBytes{{Encoding: "hex", Value: "0x01"}, {Encoding: "int", Value: "1"}, {Encoding: "str", Value: "x"}}}, m)
+
+	var arg AppCallBytes
+	require.EqualError(t, json.Unmarshal([]byte(`"hello"`), &arg), "all arguments and box names should be of the form 'encoding:value'")
+	require.ErrorContains(t, json.Unmarshal([]byte(`5`), &arg), "app call bytes must be a JSON string or object")
+}
+
+func TestAppCallBytesValidate(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "arg")
+	require.NoError(t, os.WriteFile(path, []byte("hello"), 0o600))
+	tooLarge := filepath.Join(dir, "large")
+	require.NoError(t, os.WriteFile(tooLarge, make([]byte, DefaultMaxFileSize+1), 0o600))
+
+	for _, arg := range []string{
+		"str:hello",
+		"int:5",
+		"uint16:65535",
+		"addr:737777777777777777777777777777777777777777777777777UFEJ2CI",
+		"b32:NBSWY3DP",
+		"b64:aGVsbG8=",
+		"hex:68656c6c6f",
+		"method:add(uint64,uint64)uint64",
+		`abi:(uint64,string):[1,"a"]`,
+		"file:" + path,
+		"binfile:" + path,
+		"stdin:",
+		"-",
+	} {
+		acb, err := NewAppCallBytes(arg)
+		require.NoError(t, err)
+		require.NoError(t, acb.Validate(), arg)
+	}
+
+	errorCases := []struct {
+		arg string
+		err string
+	}{
+		{arg: "int:x", err: "Could not parse uint64 from string (x)"},
+		{arg: "uint8:256", err: "Could not parse uint8 from string (256)"},
+		{arg: "addr:637777777777777777777777777777777777777777777777777UFEJ2CI", err: "Could not unmarshal checksummed address from string"},
+		{arg: "b64:!", err: "Could not decode base64-encoded string (!)"},
+		{arg: "method:add(", err: "Could not decode method signature (add()"},
+		{arg: "abi:uint8:256", err: "Could not decode abi value string (256)"},
+		{arg: "abi:uint7:1", err: "Could not decode abi type string (uint7)"},
+		{arg: "file:" + filepath.Join(dir, "missing"), err: "Could not open file"},
+		{arg: "binfile:" + dir, err: fmt.Sprintf("Could not read file (%s): it is a directory", dir)},
+		{arg: "file:" + tooLarge, err: fmt.Sprintf("Could not read file (%s): it is larger than the maximum size of %d bytes", tooLarge, DefaultMaxFileSize)},
+		{arg: "stdin:x", err: "Could not read standard input: the stdin encoding takes no value, got (x)"},
+		{arg: "unknown:x", err: "Unknown encoding: unknown"},
+	}
+	for _, errorCase := range errorCases {
+		acb, err := NewAppCallBytes(errorCase.arg)
+		require.NoError(t, err)
+		require.ErrorContains(t, acb.Validate(), errorCase.err, errorCase.arg)
+	}
+}
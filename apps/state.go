@@ -0,0 +1,72 @@
+package apps
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/algorand/avm-abi/address"
+)
+
+const globalStatePrefix = "gs:"
+const localStatePrefix = "ls:"
+
+// Indexes of the parts of state keys. Both prefixes have the same length, and are followed by the
+// app ID, big-endian. Local state keys then have the account.
+const (
+	stateAppIndex          = len(globalStatePrefix)
+	globalStateKeyIndex    = stateAppIndex + 8
+	localStateAccountIndex = stateAppIndex + 8
+	localStateKeyIndex     = localStateAccountIndex + address.BytesSize
+)
+
+// MakeGlobalStateKey creates the key that the global state entry `key` of app `appIdx` should use
+// in a key-value store. Like box keys, the keys of the global state of an app share a prefix, so
+// they can be iterated over together.
+func MakeGlobalStateKey(appIdx uint64, key string) string {
+	stateKey := make([]byte, globalStateKeyIndex+len(key))
+	copy(stateKey, globalStatePrefix)
+	binary.BigEndian.PutUint64(stateKey[stateAppIndex:], appIdx)
+	copy(stateKey[globalStateKeyIndex:], key)
+	return string(stateKey)
+}
+
+// SplitGlobalStateKey extracts an appid and global state key from a string that was created by
+// MakeGlobalStateKey()
+func SplitGlobalStateKey(stateKey string) (uint64, string, error) {
+	if len(stateKey) < globalStateKeyIndex {
+		return 0, "", fmt.Errorf("SplitGlobalStateKey() cannot extract AppIndex as key (%s) too short (length=%d)", stateKey, len(stateKey))
+	}
+	if stateKey[:stateAppIndex] != globalStatePrefix {
+		return 0, "", fmt.Errorf("SplitGlobalStateKey() illegal global state prefix in key (%s). Expected prefix '%s'", stateKey, globalStatePrefix)
+	}
+	app := binary.BigEndian.Uint64([]byte(stateKey[stateAppIndex:globalStateKeyIndex]))
+	return app, stateKey[globalStateKeyIndex:], nil
+}
+
+// MakeLocalStateKey creates the key that the local state entry `key` of account `account` in app
+// `appIdx` should use in a key-value store. The app comes before the account, so the keys of the
+// local state of all the accounts opted in to an app share a prefix, and so do the keys of the
+// local state of an account in an app.
+func MakeLocalStateKey(appIdx uint64, account address.Address, key string) string {
+	stateKey := make([]byte, localStateKeyIndex+len(key))
+	copy(stateKey, localStatePrefix)
+	binary.BigEndian.PutUint64(stateKey[stateAppIndex:], appIdx)
+	copy(stateKey[localStateAccountIndex:], account[:])
+	copy(stateKey[localStateKeyIndex:], key)
+	return string(stateKey)
+}
+
+// SplitLocalStateKey extracts an appid, account, and local state key from a string that was
+// created by MakeLocalStateKey()
+func SplitLocalStateKey(stateKey string) (uint64, address.Address, string, error) {
+	if len(stateKey) < localStateKeyIndex {
+		return 0, address.Address{}, "", fmt.Errorf("SplitLocalStateKey() cannot extract AppIndex and account as key (%s) too short (length=%d)", stateKey, len(stateKey))
+	}
+	if stateKey[:stateAppIndex] != localStatePrefix {
+		return 0, address.Address{}, "", fmt.Errorf("SplitLocalStateKey() illegal local state prefix in key (%s). Expected prefix '%s'", stateKey, localStatePrefix)
+	}
+	app := binary.BigEndian.Uint64([]byte(stateKey[stateAppIndex:localStateAccountIndex]))
+	var account address.Address
+	copy(account[:], stateKey[localStateAccountIndex:localStateKeyIndex])
+	return app, account, stateKey[localStateKeyIndex:], nil
+}
@@ -0,0 +1,75 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/algorand/avm-abi/address"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalStateKey(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		app      uint64
+		key      string
+		stateKey string
+	}{
+		{app: 0, key: "counter", stateKey: "gs:\x00\x00\x00\x00\x00\x00\x00\x00counter"},
+		{app: 131231, key: "", stateKey: "gs:\x00\x00\x00\x00\x00\x02\x00\x9f"},
+		{app: 42, key: "\xff\x00", stateKey: "gs:\x00\x00\x00\x00\x00\x00\x00*\xff\x00"},
+	}
+	for _, testCase := range testCases {
+		stateKey := MakeGlobalStateKey(testCase.app, testCase.key)
+		require.Equal(t, testCase.stateKey, stateKey)
+		app, key, err := SplitGlobalStateKey(stateKey)
+		require.NoError(t, err)
+		require.Equal(t, testCase.app, app)
+		require.Equal(t, testCase.key, key)
+	}
+
+	_, _, err := SplitGlobalStateKey("gs:short")
+	require.EqualError(t, err, "SplitGlobalStateKey() cannot extract AppIndex as key (gs:short) too short (length=8)")
+	_, _, err = SplitGlobalStateKey(MakeBoxKey(1, "name"))
+	require.ErrorContains(t, err, "SplitGlobalStateKey() illegal global state prefix in key")
+	_, _, err = SplitGlobalStateKey(MakeLocalStateKey(1, address.Address{}, "name"))
+	require.ErrorContains(t, err, "Expected prefix 'gs:'")
+}
+
+func TestLocalStateKey(t *testing.T) {
+	t.Parallel()
+
+	account := address.ForApplication(1)
+	testCases := []struct {
+		app     uint64
+		account address.Address
+		key     string
+	}{
+		{app: 0, account: address.ZeroAddress, key: "counter"},
+		{app: 131231, account: account, key: ""},
+		{app: 42, account: account, key: "\xff\x00"},
+	}
+	for _, testCase := range testCases {
+		stateKey := MakeLocalStateKey(testCase.app, testCase.account, testCase.key)
+		require.Equal(t, MakeGlobalStateKey(testCase.app, "")[3:], stateKey[3:11])
+		require.Equal(t, "ls:", stateKey[:3])
+		require.Equal(t, string(testCase.account[:]), stateKey[11:43])
+		require.Equal(t, testCase.key, stateKey[43:])
+
+		app, stateAccount, key, err := SplitLocalStateKey(stateKey)
+		require.NoError(t, err)
+		require.Equal(t, testCase.app, app)
+		require.Equal(t, testCase.account, stateAccount)
+		require.Equal(t, testCase.key, key)
+	}
+
+	_, _, _, err := SplitLocalStateKey("ls:\x00\x00\x00\x00\x00\x00\x00\x01")
+	require.EqualError(t, err, "SplitLocalStateKey() cannot extract AppIndex and account as key (ls:\x00\x00\x00\x00\x00\x00\x00\x01) too short (length=11)")
+	_, _, _, err = SplitLocalStateKey(MakeGlobalStateKey(1, string(account[:])))
+	require.ErrorContains(t, err, "SplitLocalStateKey() illegal local state prefix in key")
+
+	// the local state keys of an app share a prefix
+	start, end := MakeGlobalStateKey(7, "")[3:], MakeGlobalStateKey(8, "")[3:]
+	stateKey := MakeLocalStateKey(7, account, "key")[3:]
+	require.True(t, stateKey >= start && stateKey < end)
+}
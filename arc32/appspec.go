@@ -0,0 +1,179 @@
+/*
+Package arc32 provides parsing of ARC-32 application specifications, the application.json files
+which describe an application's ARC-4 contract together with its state, source, and per-method call
+hints.
+
+See https://arc.algorand.foundation/ARCs/arc-0032 for the corresponding specification.
+*/
+package arc32
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/algorand/avm-abi/abi"
+)
+
+// CallConfigValue describes when an application may be called with an OnCompletion action.
+type CallConfigValue string
+
+const (
+	// CallConfigNever means the action is not allowed.
+	CallConfigNever CallConfigValue = "NEVER"
+	// CallConfigCall means the action is allowed when calling an existing application.
+	CallConfigCall CallConfigValue = "CALL"
+	// CallConfigCreate means the action is allowed when creating the application.
+	CallConfigCreate CallConfigValue = "CREATE"
+	// CallConfigAll means the action is allowed both when creating and when calling the application.
+	CallConfigAll CallConfigValue = "ALL"
+)
+
+// CallConfig describes which OnCompletion actions may be used to call a method, or to make a bare
+// call. Actions which are not set are not allowed.
+type CallConfig struct {
+	NoOp              CallConfigValue `json:"no_op,omitempty"`
+	OptIn             CallConfigValue `json:"opt_in,omitempty"`
+	CloseOut          CallConfigValue `json:"close_out,omitempty"`
+	UpdateApplication CallConfigValue `json:"update_application,omitempty"`
+	DeleteApplication CallConfigValue `json:"delete_application,omitempty"`
+}
+
+// StructElement is a field of a struct hint, as a [name, type] pair.
+type StructElement [2]string
+
+// Struct is a hint giving the name and fields of a tuple argument or return value.
+type Struct struct {
+	Name     string          `json:"name"`
+	Elements []StructElement `json:"elements"`
+}
+
+// DefaultArgument is a hint describing where a client can find the value of an argument which was
+// not given.
+type DefaultArgument struct {
+	// Source is one of "constant", "global-state", "local-state", or "abi-method".
+	Source string `json:"source"`
+	// Data is the constant value, the state key, or the method to call, depending on Source.
+	Data json.RawMessage `json:"data"`
+}
+
+// Hint holds the ARC-32 hints for a single method.
+type Hint struct {
+	CallConfig       CallConfig                 `json:"call_config"`
+	ReadOnly         bool                       `json:"read_only,omitempty"`
+	Structs          map[string]Struct          `json:"structs,omitempty"`
+	DefaultArguments map[string]DefaultArgument `json:"default_arguments,omitempty"`
+}
+
+// Source holds the base64 encoded TEAL source of the application programs.
+type Source struct {
+	Approval string `json:"approval"`
+	Clear    string `json:"clear"`
+}
+
+// ApprovalProgram returns the decoded TEAL source of the approval program.
+func (s Source) ApprovalProgram() (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(s.Approval)
+	if err != nil {
+		return "", fmt.Errorf("cannot decode approval program source: %w", err)
+	}
+	return string(decoded), nil
+}
+
+// ClearProgram returns the decoded TEAL source of the clear state program.
+func (s Source) ClearProgram() (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(s.Clear)
+	if err != nil {
+		return "", fmt.Errorf("cannot decode clear program source: %w", err)
+	}
+	return string(decoded), nil
+}
+
+// StateSchema is the number of uint and byte slice values an application stores in global or local
+// state.
+type StateSchema struct {
+	NumUints      uint64 `json:"num_uints"`
+	NumByteSlices uint64 `json:"num_byte_slices"`
+}
+
+// State holds the global and local state schemas of the application.
+type State struct {
+	Global StateSchema `json:"global"`
+	Local  StateSchema `json:"local"`
+}
+
+// DeclaredValue describes a state value declared by the application.
+type DeclaredValue struct {
+	// Type is "uint64" or "bytes".
+	Type  string `json:"type"`
+	Key   string `json:"key"`
+	Descr string `json:"descr,omitempty"`
+}
+
+// ReservedValue describes a range of state values reserved by the application.
+type ReservedValue struct {
+	// Type is "uint64" or "bytes".
+	Type    string `json:"type"`
+	MaxKeys uint64 `json:"max_keys"`
+	Descr   string `json:"descr,omitempty"`
+}
+
+// StateValues describes the declared and reserved values of global or local state.
+type StateValues struct {
+	Declared map[string]DeclaredValue `json:"declared"`
+	Reserved map[string]ReservedValue `json:"reserved"`
+}
+
+// Schema describes the values the application stores in global and local state.
+type Schema struct {
+	Global StateValues `json:"global"`
+	Local  StateValues `json:"local"`
+}
+
+// AppSpec is an ARC-32 application specification.
+type AppSpec struct {
+	// Hints maps method signatures to the hints for that method.
+	Hints  map[string]Hint `json:"hints"`
+	Source Source          `json:"source"`
+	State  State           `json:"state"`
+	Schema Schema          `json:"schema"`
+	// Contract is the ARC-4 contract implemented by the application.
+	Contract abi.Contract `json:"contract"`
+	// BareCallConfig describes which OnCompletion actions may be used for calls without a method
+	// selector.
+	BareCallConfig CallConfig `json:"bare_call_config"`
+}
+
+// UnmarshalJSON parses an ARC-32 application specification, and verifies that every call config
+// value is valid. The ARC-22 read-only hint of each method is copied to the ReadOnly flag of the
+// corresponding method of Contract.
+func (s *AppSpec) UnmarshalJSON(data []byte) error {
+	// appSpecJSON has the same fields as AppSpec, without its UnmarshalJSON method
+	type appSpecJSON AppSpec
+	var parsed appSpecJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+	spec := AppSpec(parsed)
+	if err := spec.BareCallConfig.verify(); err != nil {
+		return fmt.Errorf("bare call config: %w", err)
+	}
+	for signature, hint := range spec.Hints {
+		if err := hint.CallConfig.verify(); err != nil {
+			return fmt.Errorf("call config of method %s: %w", signature, err)
+		}
+	}
+	for i, method := range spec.Contract.Methods {
+		if hint, ok := spec.MethodHint(method); ok && hint.ReadOnly {
+			spec.Contract.Methods[i].ReadOnly = true
+		}
+	}
+	*s = spec
+	return nil
+}
+
+// MethodHint returns the hints for method, if there are any. Hints are keyed by method signature.
+func (s AppSpec) MethodHint(method abi.Method) (Hint, bool) {
+	hint, ok := s.Hints[method.GetSignature()]
+	return hint, ok
+}
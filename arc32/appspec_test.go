@@ -0,0 +1,117 @@
+package arc32
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const exampleAppSpec = `{
+	"hints": {
+		"add(uint64,uint64)uint64": {
+			"call_config": {"no_op": "CALL"},
+			"read_only": true,
+			"default_arguments": {
+				"b": {"source": "constant", "data": 1}
+			}
+		},
+		"set_point((uint64,uint64))void": {
+			"call_config": {"no_op": "CALL", "opt_in": "ALL"},
+			"structs": {
+				"point": {"name": "Point", "elements": [["x", "uint64"], ["y", "uint64"]]}
+			}
+		}
+	},
+	"source": {
+		"approval": "I3ByYWdtYSB2ZXJzaW9uIDgKaW50IDE=",
+		"clear": "I3ByYWdtYSB2ZXJzaW9uIDgKaW50IDE="
+	},
+	"state": {
+		"global": {"num_byte_slices": 1, "num_uints": 2},
+		"local": {"num_byte_slices": 0, "num_uints": 1}
+	},
+	"schema": {
+		"global": {
+			"declared": {
+				"counter": {"type": "uint64", "key": "counter", "descr": "A counter"}
+			},
+			"reserved": {
+				"data": {"type": "bytes", "max_keys": 1}
+			}
+		},
+		"local": {"declared": {}, "reserved": {}}
+	},
+	"contract": {
+		"name": "Example",
+		"methods": [
+			{"name": "add", "args": [{"type": "uint64", "name": "a"}, {"type": "uint64", "name": "b"}], "returns": {"type": "uint64"}},
+			{"name": "set_point", "args": [{"type": "(uint64,uint64)", "name": "point"}], "returns": {"type": "void"}},
+			{"name": "hello", "args": [], "returns": {"type": "string"}}
+		],
+		"networks": {}
+	},
+	"bare_call_config": {"no_op": "CREATE", "delete_application": "CALL"}
+}`
+
+func TestAppSpecUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var spec AppSpec
+	require.NoError(t, json.Unmarshal([]byte(exampleAppSpec), &spec))
+
+	require.Equal(t, "Example", spec.Contract.Name)
+	require.Len(t, spec.Contract.Methods, 3)
+	require.Equal(t, State{Global: StateSchema{NumUints: 2, NumByteSlices: 1}, Local: StateSchema{NumUints: 1}}, spec.State)
+	require.Equal(t, DeclaredValue{Type: "uint64", Key: "counter", Descr: "A counter"}, spec.Schema.Global.Declared["counter"])
+	require.Equal(t, ReservedValue{Type: "bytes", MaxKeys: 1}, spec.Schema.Global.Reserved["data"])
+	require.Equal(t, CallConfig{NoOp: CallConfigCreate, DeleteApplication: CallConfigCall}, spec.BareCallConfig)
+
+	approval, err := spec.Source.ApprovalProgram()
+	require.NoError(t, err)
+	require.Equal(t, "#pragma version 8\nint 1", approval)
+	clearProgram, err := spec.Source.ClearProgram()
+	require.NoError(t, err)
+	require.Equal(t, "#pragma version 8\nint 1", clearProgram)
+
+	add, err := spec.Contract.MethodByName("add")
+	require.NoError(t, err)
+	hint, ok := spec.MethodHint(add)
+	require.True(t, ok)
+	require.True(t, hint.ReadOnly)
+	require.True(t, add.ReadOnly)
+	require.Equal(t, CallConfig{NoOp: CallConfigCall}, hint.CallConfig)
+	require.Equal(t, "constant", hint.DefaultArguments["b"].Source)
+	require.JSONEq(t, "1", string(hint.DefaultArguments["b"].Data))
+
+	setPoint, err := spec.Contract.MethodByName("set_point")
+	require.NoError(t, err)
+	hint, ok = spec.MethodHint(setPoint)
+	require.True(t, ok)
+	require.Equal(t, CallConfig{NoOp: CallConfigCall, OptIn: CallConfigAll}, hint.CallConfig)
+	require.Equal(t, Struct{Name: "Point", Elements: []StructElement{{"x", "uint64"}, {"y", "uint64"}}}, hint.Structs["point"])
+
+	hello, err := spec.Contract.MethodByName("hello")
+	require.NoError(t, err)
+	_, ok = spec.MethodHint(hello)
+	require.False(t, ok)
+	require.False(t, hello.ReadOnly)
+}
+
+func TestAppSpecErrors(t *testing.T) {
+	t.Parallel()
+
+	var spec AppSpec
+	err := json.Unmarshal([]byte(`{"contract": {"name": "c", "methods": [{"name": "m", "args": [{"type": "uint7"}], "returns": {"type": "void"}}]}}`), &spec)
+	require.ErrorContains(t, err, "Error parsing argument type at index 0 of method m")
+
+	err = json.Unmarshal([]byte(`{"bare_call_config": {"opt_in": "SOMETIMES"}}`), &spec)
+	require.EqualError(t, err, `bare call config: invalid call config value "SOMETIMES" for OptIn`)
+	err = json.Unmarshal([]byte(`{"hints": {"m()void": {"call_config": {"no_op": "call"}}}}`), &spec)
+	require.EqualError(t, err, `call config of method m()void: invalid call config value "call" for NoOp`)
+
+	_, err = Source{Approval: "not base64!"}.ApprovalProgram()
+	require.ErrorContains(t, err, "cannot decode approval program source")
+	_, err = Source{Clear: "not base64!"}.ClearProgram()
+	require.ErrorContains(t, err, "cannot decode clear program source")
+}
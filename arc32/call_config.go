@@ -0,0 +1,162 @@
+package arc32
+
+import (
+	"fmt"
+
+	"github.com/algorand/avm-abi/abi"
+)
+
+// OnCompletion is the action an application call performs after the approval program runs.
+type OnCompletion uint64
+
+const (
+	// NoOpOC only runs the approval program.
+	NoOpOC OnCompletion = 0
+	// OptInOC allocates local state for the sender.
+	OptInOC OnCompletion = 1
+	// CloseOutOC clears the local state of the sender.
+	CloseOutOC OnCompletion = 2
+	// ClearStateOC runs the clear state program and clears the local state of the sender.
+	ClearStateOC OnCompletion = 3
+	// UpdateApplicationOC replaces the programs of the application.
+	UpdateApplicationOC OnCompletion = 4
+	// DeleteApplicationOC deletes the application.
+	DeleteApplicationOC OnCompletion = 5
+)
+
+// callConfigActions are the OnCompletion actions described by a CallConfig, in order.
+var callConfigActions = []OnCompletion{NoOpOC, OptInOC, CloseOutOC, UpdateApplicationOC, DeleteApplicationOC}
+
+// String returns the name of the action, such as NoOp.
+func (oc OnCompletion) String() string {
+	switch oc {
+	case NoOpOC:
+		return "NoOp"
+	case OptInOC:
+		return "OptIn"
+	case CloseOutOC:
+		return "CloseOut"
+	case ClearStateOC:
+		return "ClearState"
+	case UpdateApplicationOC:
+		return "UpdateApplication"
+	case DeleteApplicationOC:
+		return "DeleteApplication"
+	default:
+		return fmt.Sprintf("OnCompletion(%d)", uint64(oc))
+	}
+}
+
+// Allows reports whether the value allows an action when creating the application, if create is
+// true, or when calling an existing application otherwise.
+func (v CallConfigValue) Allows(create bool) bool {
+	switch v {
+	case CallConfigAll:
+		return true
+	case CallConfigCreate:
+		return create
+	case CallConfigCall:
+		return !create
+	default:
+		return false
+	}
+}
+
+// isValid reports whether the value is unset or one of the defined values.
+func (v CallConfigValue) isValid() bool {
+	switch v {
+	case "", CallConfigNever, CallConfigCall, CallConfigCreate, CallConfigAll:
+		return true
+	default:
+		return false
+	}
+}
+
+// Value returns the call config value of an action, which is CallConfigNever if it is not set.
+// ClearState calls run the clear state program instead of the approval program, so they are not
+// described by call configs and their value is always CallConfigNever.
+func (c CallConfig) Value(oc OnCompletion) CallConfigValue {
+	var value CallConfigValue
+	switch oc {
+	case NoOpOC:
+		value = c.NoOp
+	case OptInOC:
+		value = c.OptIn
+	case CloseOutOC:
+		value = c.CloseOut
+	case UpdateApplicationOC:
+		value = c.UpdateApplication
+	case DeleteApplicationOC:
+		value = c.DeleteApplication
+	}
+	if value == "" {
+		return CallConfigNever
+	}
+	return value
+}
+
+// Allows reports whether the config allows an action when creating the application, if create is
+// true, or when calling an existing application otherwise.
+func (c CallConfig) Allows(oc OnCompletion, create bool) bool {
+	return c.Value(oc).Allows(create)
+}
+
+// Actions returns the actions the config allows when creating the application, if create is true,
+// or when calling an existing application otherwise.
+func (c CallConfig) Actions(create bool) []OnCompletion {
+	var actions []OnCompletion
+	for _, oc := range callConfigActions {
+		if c.Allows(oc, create) {
+			actions = append(actions, oc)
+		}
+	}
+	return actions
+}
+
+// verify checks that every value of the config is valid.
+func (c CallConfig) verify() error {
+	for _, oc := range callConfigActions {
+		if value := c.Value(oc); !value.isValid() {
+			return fmt.Errorf(`invalid call config value "%s" for %s`, value, oc)
+		}
+	}
+	return nil
+}
+
+// MethodCallConfig returns the call config of method, which is empty, allowing no actions, if the
+// method has no hints.
+func (s AppSpec) MethodCallConfig(method abi.Method) CallConfig {
+	hint, _ := s.MethodHint(method)
+	return hint.CallConfig
+}
+
+// SupportsBareCalls reports whether the application allows any action for calls without a method
+// selector.
+func (s AppSpec) SupportsBareCalls() bool {
+	return len(s.BareCallConfig.Actions(true)) > 0 || len(s.BareCallConfig.Actions(false)) > 0
+}
+
+// ValidateMethodCall checks that the call config of method allows an action when creating the
+// application, if create is true, or when calling an existing application otherwise.
+func (s AppSpec) ValidateMethodCall(method abi.Method, oc OnCompletion, create bool) error {
+	if !s.MethodCallConfig(method).Allows(oc, create) {
+		return fmt.Errorf("method %s does not allow %s", method.GetSignature(), describeCall(oc, create))
+	}
+	return nil
+}
+
+// ValidateBareCall checks that the bare call config allows an action when creating the
+// application, if create is true, or when calling an existing application otherwise.
+func (s AppSpec) ValidateBareCall(oc OnCompletion, create bool) error {
+	if !s.BareCallConfig.Allows(oc, create) {
+		return fmt.Errorf("bare calls do not allow %s", describeCall(oc, create))
+	}
+	return nil
+}
+
+func describeCall(oc OnCompletion, create bool) string {
+	if create {
+		return oc.String() + " when creating the application"
+	}
+	return oc.String() + " when calling the application"
+}
@@ -0,0 +1,59 @@
+package arc32
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallConfig(t *testing.T) {
+	t.Parallel()
+
+	config := CallConfig{NoOp: CallConfigCall, OptIn: CallConfigAll, CloseOut: CallConfigNever, UpdateApplication: CallConfigCreate}
+	require.Equal(t, CallConfigCall, config.Value(NoOpOC))
+	require.Equal(t, CallConfigNever, config.Value(DeleteApplicationOC))
+	require.Equal(t, CallConfigNever, config.Value(ClearStateOC))
+
+	require.True(t, config.Allows(NoOpOC, false))
+	require.False(t, config.Allows(NoOpOC, true))
+	require.True(t, config.Allows(OptInOC, true))
+	require.True(t, config.Allows(OptInOC, false))
+	require.False(t, config.Allows(CloseOutOC, false))
+	require.True(t, config.Allows(UpdateApplicationOC, true))
+	require.False(t, config.Allows(UpdateApplicationOC, false))
+	require.False(t, config.Allows(ClearStateOC, false))
+
+	require.Equal(t, []OnCompletion{OptInOC, UpdateApplicationOC}, config.Actions(true))
+	require.Equal(t, []OnCompletion{NoOpOC, OptInOC}, config.Actions(false))
+	require.Nil(t, CallConfig{}.Actions(false))
+
+	require.Equal(t, "DeleteApplication", DeleteApplicationOC.String())
+	require.Equal(t, "OnCompletion(9)", OnCompletion(9).String())
+}
+
+func TestAppSpecValidateCall(t *testing.T) {
+	t.Parallel()
+
+	var spec AppSpec
+	require.NoError(t, json.Unmarshal([]byte(exampleAppSpec), &spec))
+	require.True(t, spec.SupportsBareCalls())
+
+	setPoint, err := spec.Contract.MethodByName("set_point")
+	require.NoError(t, err)
+	require.Equal(t, CallConfig{NoOp: CallConfigCall, OptIn: CallConfigAll}, spec.MethodCallConfig(setPoint))
+	require.NoError(t, spec.ValidateMethodCall(setPoint, OptInOC, true))
+	require.NoError(t, spec.ValidateMethodCall(setPoint, NoOpOC, false))
+	require.EqualError(t, spec.ValidateMethodCall(setPoint, NoOpOC, true), "method set_point((uint64,uint64))void does not allow NoOp when creating the application")
+
+	hello, err := spec.Contract.MethodByName("hello")
+	require.NoError(t, err)
+	require.Equal(t, CallConfig{}, spec.MethodCallConfig(hello))
+	require.EqualError(t, spec.ValidateMethodCall(hello, NoOpOC, false), "method hello()string does not allow NoOp when calling the application")
+
+	require.NoError(t, spec.ValidateBareCall(NoOpOC, true))
+	require.NoError(t, spec.ValidateBareCall(DeleteApplicationOC, false))
+	require.EqualError(t, spec.ValidateBareCall(OptInOC, false), "bare calls do not allow OptIn when calling the application")
+
+	require.False(t, AppSpec{}.SupportsBareCalls())
+}
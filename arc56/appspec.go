@@ -0,0 +1,443 @@
+/*
+Package arc56 provides parsing of ARC-56 application specifications, which describe an application's
+methods, named structs, events, state, template variables, default arguments, and source information.
+
+See https://arc.algorand.foundation/ARCs/arc-0056 for the corresponding specification.
+*/
+package arc56
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/algorand/avm-abi/abi"
+)
+
+// StructField is a field of a struct definition. Its type is either a type string, which is an ABI
+// type or the name of another struct, or an anonymous nested struct given by Fields.
+type StructField struct {
+	Name string
+	// Type is the ABI type or struct name of the field, if Fields is nil.
+	Type string
+	// Fields are the fields of an anonymous nested struct.
+	Fields []StructField
+}
+
+// structFieldJSON is the JSON form of StructField, whose type is a string or an array of fields.
+type structFieldJSON struct {
+	Name string          `json:"name"`
+	Type json.RawMessage `json:"type"`
+}
+
+// UnmarshalJSON parses a struct field whose type is a string or an array of nested fields.
+func (f *StructField) UnmarshalJSON(data []byte) error {
+	var parsed structFieldJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+	field := StructField{Name: parsed.Name}
+	if err := json.Unmarshal(parsed.Type, &field.Type); err != nil {
+		if err := json.Unmarshal(parsed.Type, &field.Fields); err != nil {
+			return fmt.Errorf(`type of struct field "%s" must be a string or an array of fields`, parsed.Name)
+		}
+		if field.Fields == nil {
+			field.Fields = []StructField{}
+		}
+	}
+	*f = field
+	return nil
+}
+
+// MarshalJSON renders a struct field whose type is a string or an array of nested fields.
+func (f StructField) MarshalJSON() ([]byte, error) {
+	var fieldType interface{} = f.Type
+	if f.Fields != nil {
+		fieldType = f.Fields
+	}
+	return json.Marshal(struct {
+		Name string      `json:"name"`
+		Type interface{} `json:"type"`
+	}{f.Name, fieldType})
+}
+
+// DefaultValue is the default value of a method argument, used by clients when the argument is
+// not given.
+type DefaultValue struct {
+	// Data is the default value, or the key or method used to look it up, depending on Source.
+	Data string `json:"data"`
+	// Type is the type of Data, if it differs from the argument type.
+	Type string `json:"type,omitempty"`
+	// Source is one of "box", "global", "local", "literal", or "method".
+	Source string `json:"source"`
+}
+
+// MethodArg is an argument of a method.
+type MethodArg struct {
+	Type string `json:"type"`
+	// Struct is the name of the struct the argument value is, if any.
+	Struct       string        `json:"struct,omitempty"`
+	Name         string        `json:"name,omitempty"`
+	Desc         string        `json:"desc,omitempty"`
+	DefaultValue *DefaultValue `json:"defaultValue,omitempty"`
+}
+
+// MethodReturn is the return value of a method.
+type MethodReturn struct {
+	Type string `json:"type"`
+	// Struct is the name of the struct the return value is, if any.
+	Struct string `json:"struct,omitempty"`
+	Desc   string `json:"desc,omitempty"`
+}
+
+// Actions lists the OnCompletion actions, such as "NoOp" or "OptIn", allowed when creating and
+// when calling the application.
+type Actions struct {
+	Create []string `json:"create"`
+	Call   []string `json:"call"`
+}
+
+// EventArg is an argument of an event.
+type EventArg struct {
+	Type string `json:"type"`
+	// Struct is the name of the struct the argument value is, if any.
+	Struct string `json:"struct,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Desc   string `json:"desc,omitempty"`
+}
+
+// Event is an ARC-28 event emitted by the application.
+type Event struct {
+	Name string     `json:"name"`
+	Desc string     `json:"desc,omitempty"`
+	Args []EventArg `json:"args"`
+}
+
+// Method is a method of the application.
+type Method struct {
+	Name            string          `json:"name"`
+	Desc            string          `json:"desc,omitempty"`
+	Args            []MethodArg     `json:"args"`
+	Returns         MethodReturn    `json:"returns"`
+	Actions         Actions         `json:"actions"`
+	ReadOnly        bool            `json:"readonly,omitempty"`
+	Events          []Event         `json:"events,omitempty"`
+	Recommendations json.RawMessage `json:"recommendations,omitempty"`
+}
+
+// StateSchema is the number of uint and byte slice values stored in global or local state.
+type StateSchema struct {
+	Ints  uint64 `json:"ints"`
+	Bytes uint64 `json:"bytes"`
+}
+
+// StorageKey describes a single value stored under a fixed key.
+type StorageKey struct {
+	Desc      string `json:"desc,omitempty"`
+	KeyType   string `json:"keyType"`
+	ValueType string `json:"valueType"`
+	// Key is the base64 encoded key.
+	Key string `json:"key"`
+}
+
+// StorageMap describes a collection of values stored under keys with a common prefix.
+type StorageMap struct {
+	Desc      string `json:"desc,omitempty"`
+	KeyType   string `json:"keyType"`
+	ValueType string `json:"valueType"`
+	// Prefix is the base64 encoded prefix of the keys.
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// State describes the state of the application.
+type State struct {
+	Schema struct {
+		Global StateSchema `json:"global"`
+		Local  StateSchema `json:"local"`
+	} `json:"schema"`
+	Keys struct {
+		Global map[string]StorageKey `json:"global"`
+		Local  map[string]StorageKey `json:"local"`
+		Box    map[string]StorageKey `json:"box"`
+	} `json:"keys"`
+	Maps struct {
+		Global map[string]StorageMap `json:"global"`
+		Local  map[string]StorageMap `json:"local"`
+		Box    map[string]StorageMap `json:"box"`
+	} `json:"maps"`
+}
+
+// SourceInfo maps program counters to the TEAL source they were compiled from, and optionally to
+// an error message for failures at those program counters.
+type SourceInfo struct {
+	PC           []uint64 `json:"pc"`
+	ErrorMessage string   `json:"errorMessage,omitempty"`
+	Teal         uint64   `json:"teal,omitempty"`
+	Source       string   `json:"source,omitempty"`
+}
+
+// ProgramSourceInfo holds the source information of a program.
+type ProgramSourceInfo struct {
+	SourceInfo []SourceInfo `json:"sourceInfo"`
+	// PCOffsetMethod is "none", or "cblocks" if program counters must be offset by the size of the
+	// constant blocks, which depend on template variable values.
+	PCOffsetMethod string `json:"pcOffsetMethod"`
+}
+
+// ProgramSourceInfos holds the source information of the approval and clear state programs.
+type ProgramSourceInfos struct {
+	Approval ProgramSourceInfo `json:"approval"`
+	Clear    ProgramSourceInfo `json:"clear"`
+}
+
+// Programs holds a base64 encoded representation, such as TEAL source or bytecode, of the
+// approval and clear state programs.
+type Programs struct {
+	Approval string `json:"approval"`
+	Clear    string `json:"clear"`
+}
+
+// TemplateVariable is a variable substituted into the program before it is compiled.
+type TemplateVariable struct {
+	// Type is an ABI type, "AVMBytes", "AVMString", "AVMUint64", or the name of a struct.
+	Type string `json:"type"`
+	// Value is the base64 encoded value of the variable, if it is fixed.
+	Value string `json:"value,omitempty"`
+}
+
+// ScratchVariable is a value the program stores in a scratch slot.
+type ScratchVariable struct {
+	Slot uint64 `json:"slot"`
+	Type string `json:"type"`
+}
+
+// AppSpec is an ARC-56 application specification.
+type AppSpec struct {
+	Arcs []uint64 `json:"arcs"`
+	Name string   `json:"name"`
+	Desc string   `json:"desc,omitempty"`
+	// Networks maps the base64 encoded genesis hash of each network the application is deployed on
+	// to information about the deployment.
+	Networks map[string]abi.ContractNetworkInfo `json:"networks,omitempty"`
+	// Structs maps struct names to their fields.
+	Structs           map[string][]StructField    `json:"structs"`
+	Methods           []Method                    `json:"methods"`
+	State             State                       `json:"state"`
+	BareActions       Actions                     `json:"bareActions"`
+	SourceInfo        *ProgramSourceInfos         `json:"sourceInfo,omitempty"`
+	Source            *Programs                   `json:"source,omitempty"`
+	ByteCode          *Programs                   `json:"byteCode,omitempty"`
+	CompilerInfo      json.RawMessage             `json:"compilerInfo,omitempty"`
+	Events            []Event                     `json:"events,omitempty"`
+	TemplateVariables map[string]TemplateVariable `json:"templateVariables,omitempty"`
+	ScratchVariables  map[string]ScratchVariable  `json:"scratchVariables,omitempty"`
+}
+
+// UnmarshalJSON parses an ARC-56 application specification, and verifies that every struct
+// definition can be mapped onto a named tuple type and that every struct referenced by a method
+// or event exists and matches the type it annotates.
+func (s *AppSpec) UnmarshalJSON(data []byte) error {
+	// appSpecJSON has the same fields as AppSpec, without its UnmarshalJSON method
+	type appSpecJSON AppSpec
+	var parsed appSpecJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+	spec := AppSpec(parsed)
+	for name := range spec.Structs {
+		if _, err := spec.StructType(name); err != nil {
+			return err
+		}
+	}
+	checkStruct := func(typeStr, structName, context string) error {
+		if structName == "" {
+			return nil
+		}
+		if _, ok := spec.Structs[structName]; !ok {
+			return fmt.Errorf(`%s refers to unknown struct "%s"`, context, structName)
+		}
+		if _, err := spec.resolveType(typeStr, structName); err != nil {
+			return fmt.Errorf("%s: %w", context, err)
+		}
+		return nil
+	}
+	for _, method := range spec.Methods {
+		for i, arg := range method.Args {
+			if err := checkStruct(arg.Type, arg.Struct, fmt.Sprintf("argument %d of method %s", i, method.Name)); err != nil {
+				return err
+			}
+		}
+		if err := checkStruct(method.Returns.Type, method.Returns.Struct, fmt.Sprintf("return value of method %s", method.Name)); err != nil {
+			return err
+		}
+	}
+	for _, event := range spec.Events {
+		for i, arg := range event.Args {
+			if err := checkStruct(arg.Type, arg.Struct, fmt.Sprintf("argument %d of event %s", i, event.Name)); err != nil {
+				return err
+			}
+		}
+	}
+	*s = spec
+	return nil
+}
+
+// StructType returns the named tuple type of the struct with the given name, whose field names are
+// the struct's field names. Fields which refer to other structs, or which are anonymous nested
+// structs, are themselves named tuples.
+func (s AppSpec) StructType(name string) (abi.Type, error) {
+	return s.structType(name, map[string]bool{})
+}
+
+func (s AppSpec) structType(name string, resolving map[string]bool) (abi.Type, error) {
+	fields, ok := s.Structs[name]
+	if !ok {
+		return abi.Type{}, fmt.Errorf(`unknown struct "%s"`, name)
+	}
+	if resolving[name] {
+		return abi.Type{}, fmt.Errorf(`struct "%s" contains itself`, name)
+	}
+	resolving[name] = true
+	defer delete(resolving, name)
+
+	structType, err := s.fieldsType(fields, resolving)
+	if err != nil {
+		return abi.Type{}, fmt.Errorf(`cannot resolve struct "%s": %w`, name, err)
+	}
+	return structType, nil
+}
+
+func (s AppSpec) fieldsType(fields []StructField, resolving map[string]bool) (abi.Type, error) {
+	fieldTypes := make([]abi.Type, len(fields))
+	fieldNames := make([]string, len(fields))
+	for i, field := range fields {
+		fieldNames[i] = field.Name
+		var err error
+		switch {
+		case field.Fields != nil:
+			fieldTypes[i], err = s.fieldsType(field.Fields, resolving)
+		case s.Structs[field.Type] != nil:
+			fieldTypes[i], err = s.structType(field.Type, resolving)
+		default:
+			fieldTypes[i], err = abi.TypeOf(field.Type)
+		}
+		if err != nil {
+			return abi.Type{}, fmt.Errorf(`field "%s": %w`, field.Name, err)
+		}
+	}
+	return abi.MakeNamedTupleType(fieldTypes, fieldNames)
+}
+
+// StructTypes returns the named tuple types of all structs of the application, by struct name.
+func (s AppSpec) StructTypes() (map[string]abi.Type, error) {
+	types := make(map[string]abi.Type, len(s.Structs))
+	for name := range s.Structs {
+		structType, err := s.StructType(name)
+		if err != nil {
+			return nil, err
+		}
+		types[name] = structType
+	}
+	return types, nil
+}
+
+// ArgType returns the ABI type of a value argument of a method. If the argument is a struct, the
+// type is the struct's named tuple type, so decoded values are rendered with field names by
+// `abi.Type.MarshalToJSON`.
+func (s AppSpec) ArgType(arg MethodArg) (abi.Type, error) {
+	return s.resolveType(arg.Type, arg.Struct)
+}
+
+// ReturnType returns the ABI type of a non-void return value of a method. If the return value is a
+// struct, the type is the struct's named tuple type.
+func (s AppSpec) ReturnType(ret MethodReturn) (abi.Type, error) {
+	return s.resolveType(ret.Type, ret.Struct)
+}
+
+// EventArgType returns the ABI type of an argument of an event. If the argument is a struct, the
+// type is the struct's named tuple type.
+func (s AppSpec) EventArgType(arg EventArg) (abi.Type, error) {
+	return s.resolveType(arg.Type, arg.Struct)
+}
+
+// resolveType returns the type of typeStr, or the named tuple type of the struct called
+// structName if it is not empty, which must have the same positional type as typeStr.
+func (s AppSpec) resolveType(typeStr, structName string) (abi.Type, error) {
+	abiType, err := abi.TypeOf(typeStr)
+	if err != nil {
+		return abi.Type{}, err
+	}
+	if structName == "" {
+		return abiType, nil
+	}
+	structType, err := s.StructType(structName)
+	if err != nil {
+		return abi.Type{}, err
+	}
+	if structType.String() != abiType.String() {
+		return abi.Type{}, fmt.Errorf(`struct "%s" has type %s, which does not match type %s`, structName, structType, abiType)
+	}
+	return structType, nil
+}
+
+// Contract returns the ARC-4 contract implemented by the application. Arguments, return values,
+// and event arguments which are structs carry the struct's named tuple type as their StructType,
+// so their values are converted to and from JSON objects keyed by field name. Structs are not part
+// of ARC-4 descriptions, so the named tuple types are lost if the contract is encoded in JSON.
+// Structs which cannot be resolved, which UnmarshalJSON rejects, are left out.
+func (s AppSpec) Contract() abi.Contract {
+	methods := make([]abi.Method, len(s.Methods))
+	for i, method := range s.Methods {
+		args := make([]abi.MethodArg, len(method.Args))
+		for j, arg := range method.Args {
+			args[j] = abi.MethodArg{Name: arg.Name, Type: arg.Type, Desc: arg.Desc, StructType: s.structTypeOf(arg.Type, arg.Struct)}
+		}
+		methods[i] = abi.Method{
+			Name: method.Name,
+			Desc: method.Desc,
+			Args: args,
+			Returns: abi.MethodReturn{
+				Type:       method.Returns.Type,
+				Desc:       method.Returns.Desc,
+				StructType: s.structTypeOf(method.Returns.Type, method.Returns.Struct),
+			},
+			ReadOnly: method.ReadOnly,
+			Events:   s.abiEvents(method.Events),
+		}
+	}
+	return abi.Contract{
+		Name:     s.Name,
+		Desc:     s.Desc,
+		Networks: s.Networks,
+		Methods:  methods,
+		Events:   s.abiEvents(s.Events),
+	}
+}
+
+// structTypeOf returns the named tuple type of the struct called structName annotating type
+// typeStr, or nil if structName is empty or the struct cannot be resolved.
+func (s AppSpec) structTypeOf(typeStr, structName string) *abi.Type {
+	if structName == "" {
+		return nil
+	}
+	structType, err := s.resolveType(typeStr, structName)
+	if err != nil {
+		return nil
+	}
+	return &structType
+}
+
+// abiEvents converts events to their ARC-28 form, or returns nil if there are none.
+func (s AppSpec) abiEvents(events []Event) []abi.Event {
+	if len(events) == 0 {
+		return nil
+	}
+	converted := make([]abi.Event, len(events))
+	for i, event := range events {
+		args := make([]abi.EventArg, len(event.Args))
+		for j, arg := range event.Args {
+			args[j] = abi.EventArg{Name: arg.Name, Type: arg.Type, Desc: arg.Desc, StructType: s.structTypeOf(arg.Type, arg.Struct)}
+		}
+		converted[i] = abi.Event{Name: event.Name, Desc: event.Desc, Args: args}
+	}
+	return converted
+}
@@ -0,0 +1,278 @@
+package arc56
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/algorand/avm-abi/abi"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleAppSpec = `{
+	"arcs": [4, 56],
+	"name": "Shapes",
+	"desc": "Stores shapes",
+	"networks": {
+		"wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8=": {"appID": 1234}
+	},
+	"structs": {
+		"Point": [
+			{"name": "x", "type": "uint64"},
+			{"name": "y", "type": "uint64"}
+		],
+		"Line": [
+			{"name": "start", "type": "Point"},
+			{"name": "end", "type": "Point"},
+			{"name": "style", "type": [
+				{"name": "color", "type": "byte[3]"},
+				{"name": "dashed", "type": "bool"}
+			]}
+		]
+	},
+	"methods": [
+		{
+			"name": "add_line",
+			"desc": "Adds a line",
+			"args": [
+				{"type": "((uint64,uint64),(uint64,uint64),(byte[3],bool))", "struct": "Line", "name": "line"},
+				{"type": "uint64", "name": "width", "defaultValue": {"data": "AAAAAAAAAAE=", "type": "uint64", "source": "literal"}}
+			],
+			"returns": {"type": "uint64", "desc": "The line ID"},
+			"actions": {"create": [], "call": ["NoOp"]},
+			"readonly": false,
+			"events": [
+				{"name": "LineAdded", "args": [{"type": "uint64", "name": "id"}]}
+			]
+		},
+		{
+			"name": "get_start",
+			"args": [{"type": "uint64", "name": "id"}],
+			"returns": {"type": "(uint64,uint64)", "struct": "Point"},
+			"actions": {"create": [], "call": ["NoOp"]},
+			"readonly": true
+		}
+	],
+	"state": {
+		"schema": {"global": {"ints": 1, "bytes": 0}, "local": {"ints": 0, "bytes": 0}},
+		"keys": {
+			"global": {"count": {"keyType": "AVMString", "valueType": "AVMUint64", "key": "Y291bnQ="}},
+			"local": {},
+			"box": {}
+		},
+		"maps": {
+			"global": {},
+			"local": {},
+			"box": {"lines": {"keyType": "uint64", "valueType": "Line", "prefix": "bA=="}}
+		}
+	},
+	"bareActions": {"create": ["NoOp"], "call": []},
+	"sourceInfo": {
+		"approval": {"sourceInfo": [{"pc": [12, 13], "errorMessage": "line not found"}], "pcOffsetMethod": "none"},
+		"clear": {"sourceInfo": [], "pcOffsetMethod": "none"}
+	},
+	"source": {"approval": "I3ByYWdtYSB2ZXJzaW9uIDEw", "clear": "I3ByYWdtYSB2ZXJzaW9uIDEw"},
+	"events": [
+		{"name": "LineAdded", "args": [{"type": "uint64", "name": "id"}]}
+	],
+	"templateVariables": {
+		"MAX_LINES": {"type": "uint64", "value": "AAAAAAAAAGQ="}
+	},
+	"scratchVariables": {
+		"last": {"slot": 1, "type": "uint64"}
+	}
+}`
+
+func TestAppSpecUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var spec AppSpec
+	require.NoError(t, json.Unmarshal([]byte(exampleAppSpec), &spec))
+
+	require.Equal(t, []uint64{4, 56}, spec.Arcs)
+	require.Equal(t, "Shapes", spec.Name)
+	require.Len(t, spec.Methods, 2)
+	require.Equal(t, &DefaultValue{Data: "AAAAAAAAAAE=", Type: "uint64", Source: "literal"}, spec.Methods[0].Args[1].DefaultValue)
+	require.Equal(t, []string{"NoOp"}, spec.Methods[0].Actions.Call)
+	require.Equal(t, "LineAdded", spec.Methods[0].Events[0].Name)
+	require.True(t, spec.Methods[1].ReadOnly)
+	require.Equal(t, "Point", spec.Methods[1].Returns.Struct)
+	require.Equal(t, uint64(1), spec.State.Schema.Global.Ints)
+	require.Equal(t, "Y291bnQ=", spec.State.Keys.Global["count"].Key)
+	require.Equal(t, "Line", spec.State.Maps.Box["lines"].ValueType)
+	require.Equal(t, []string{"NoOp"}, spec.BareActions.Create)
+	require.Equal(t, "line not found", spec.SourceInfo.Approval.SourceInfo[0].ErrorMessage)
+	require.Equal(t, "I3ByYWdtYSB2ZXJzaW9uIDEw", spec.Source.Approval)
+	require.Nil(t, spec.ByteCode)
+	require.Equal(t, TemplateVariable{Type: "uint64", Value: "AAAAAAAAAGQ="}, spec.TemplateVariables["MAX_LINES"])
+	require.Equal(t, ScratchVariable{Slot: 1, Type: "uint64"}, spec.ScratchVariables["last"])
+	require.Equal(t, []StructField{
+		{Name: "start", Type: "Point"},
+		{Name: "end", Type: "Point"},
+		{Name: "style", Fields: []StructField{{Name: "color", Type: "byte[3]"}, {Name: "dashed", Type: "bool"}}},
+	}, spec.Structs["Line"])
+
+	// struct fields round-trip through JSON
+	encoded, err := json.Marshal(spec.Structs["Line"])
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"name": "start", "type": "Point"},
+		{"name": "end", "type": "Point"},
+		{"name": "style", "type": [{"name": "color", "type": "byte[3]"}, {"name": "dashed", "type": "bool"}]}
+	]`, string(encoded))
+}
+
+func TestAppSpecStructType(t *testing.T) {
+	t.Parallel()
+
+	var spec AppSpec
+	require.NoError(t, json.Unmarshal([]byte(exampleAppSpec), &spec))
+
+	lineType, err := spec.StructType("Line")
+	require.NoError(t, err)
+	require.Equal(t, "((uint64,uint64),(uint64,uint64),(byte[3],bool))", lineType.String())
+	require.Equal(t, []string{"start", "end", "style"}, lineType.FieldNames())
+
+	encoded, err := lineType.Encode([]interface{}{
+		[]interface{}{uint64(1), uint64(2)},
+		[]interface{}{uint64(3), uint64(4)},
+		[]interface{}{[]byte{255, 0, 0}, true},
+	})
+	require.NoError(t, err)
+	value, err := lineType.Decode(encoded)
+	require.NoError(t, err)
+	rendered, err := lineType.MarshalToJSON(value)
+	require.NoError(t, err)
+	require.Equal(t, `{"start":{"x":1,"y":2},"end":{"x":3,"y":4},"style":{"color":"/wAA","dashed":true}}`, string(rendered))
+
+	_, err = spec.StructType("Circle")
+	require.EqualError(t, err, `unknown struct "Circle"`)
+}
+
+func TestAppSpecStructTypes(t *testing.T) {
+	t.Parallel()
+
+	var spec AppSpec
+	require.NoError(t, json.Unmarshal([]byte(exampleAppSpec), &spec))
+
+	structTypes, err := spec.StructTypes()
+	require.NoError(t, err)
+	require.Len(t, structTypes, 2)
+	require.Equal(t, []string{"x", "y"}, structTypes["Point"].FieldNames())
+	require.Equal(t, []string{"start", "end", "style"}, structTypes["Line"].FieldNames())
+
+	lineType, err := spec.ArgType(spec.Methods[0].Args[0])
+	require.NoError(t, err)
+	require.Equal(t, []string{"start", "end", "style"}, lineType.FieldNames())
+
+	widthType, err := spec.ArgType(spec.Methods[0].Args[1])
+	require.NoError(t, err)
+	require.Equal(t, "uint64", widthType.String())
+
+	pointType, err := spec.ReturnType(spec.Methods[1].Returns)
+	require.NoError(t, err)
+	encoded, err := pointType.Encode([]interface{}{uint64(5), uint64(6)})
+	require.NoError(t, err)
+	value, err := pointType.Decode(encoded)
+	require.NoError(t, err)
+	rendered, err := pointType.MarshalToJSON(value)
+	require.NoError(t, err)
+	require.Equal(t, `{"x":5,"y":6}`, string(rendered))
+
+	idType, err := spec.EventArgType(spec.Events[0].Args[0])
+	require.NoError(t, err)
+	require.Nil(t, idType.FieldNames())
+
+	_, err = spec.ArgType(MethodArg{Type: "(uint64,uint32)", Struct: "Point"})
+	require.EqualError(t, err, `struct "Point" has type (uint64,uint64), which does not match type (uint64,uint32)`)
+	_, err = spec.ReturnType(MethodReturn{Type: "void"})
+	require.Error(t, err)
+}
+
+func TestAppSpecContract(t *testing.T) {
+	t.Parallel()
+
+	var spec AppSpec
+	require.NoError(t, json.Unmarshal([]byte(exampleAppSpec), &spec))
+
+	contract := spec.Contract()
+	require.Equal(t, "Shapes", contract.Name)
+	require.Equal(t, map[string]abi.ContractNetworkInfo{"wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8=": {AppID: 1234}}, contract.Networks)
+	getStart := contract.Methods[1]
+	require.Equal(t, "get_start(uint64)(uint64,uint64)", getStart.GetSignature())
+	require.True(t, getStart.ReadOnly)
+	require.Nil(t, getStart.Args[0].StructType)
+	returnType, err := getStart.Returns.Parse()
+	require.NoError(t, err)
+	require.Equal(t, []string{"x", "y"}, returnType.FieldNames())
+
+	addLine := contract.Methods[0]
+	require.Equal(t, "add_line(((uint64,uint64),(uint64,uint64),(byte[3],bool)),uint64)uint64", addLine.GetSignature())
+	require.Nil(t, addLine.Returns.StructType)
+	lineArg, err := addLine.Args[0].Parse()
+	require.NoError(t, err)
+	lineType, ok := lineArg.Type()
+	require.True(t, ok)
+	require.Equal(t, []string{"start", "end", "style"}, lineType.FieldNames())
+	encoded, err := lineType.MarshalToJSON([]interface{}{[]interface{}{1, 2}, []interface{}{3, 4}, []interface{}{[]byte{5, 6, 7}, true}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"start": {"x": 1, "y": 2}, "end": {"x": 3, "y": 4}, "style": {"color": "BQYH", "dashed": true}}`, string(encoded))
+
+	// Struct types are not part of the ARC-4 description.
+	marshaled, err := json.Marshal(addLine)
+	require.NoError(t, err)
+	var unmarshaled abi.Method
+	require.NoError(t, json.Unmarshal(marshaled, &unmarshaled))
+	require.Nil(t, unmarshaled.Args[0].StructType)
+
+	lineAdded := abi.Event{Name: "LineAdded", Args: []abi.EventArg{{Name: "id", Type: "uint64"}}}
+	require.Equal(t, []abi.Event{lineAdded}, contract.Methods[0].Events)
+	require.Equal(t, []abi.Event{lineAdded}, contract.Events)
+}
+
+func TestAppSpecErrors(t *testing.T) {
+	t.Parallel()
+
+	errorCases := []struct {
+		input string
+		err   string
+	}{
+		{
+			input: `{"structs": {"A": [{"name": "b", "type": "B"}], "B": [{"name": "a", "type": "A"}]}}`,
+			err:   `contains itself`,
+		},
+		{
+			input: `{"structs": {"A": [{"name": "x", "type": "uint7"}]}}`,
+			err:   `cannot resolve struct "A": field "x"`,
+		},
+		{
+			input: `{"structs": {"A": [{"name": "x", "type": "uint8"}, {"name": "x", "type": "uint8"}]}}`,
+			err:   `named tuple field name "x" is duplicated`,
+		},
+		{
+			input: `{"structs": {"A": [{"name": "x", "type": 5}]}}`,
+			err:   `type of struct field "x" must be a string or an array of fields`,
+		},
+		{
+			input: `{"methods": [{"name": "m", "args": [{"type": "(uint8)", "struct": "S"}], "returns": {"type": "void"}}]}`,
+			err:   `argument 0 of method m refers to unknown struct "S"`,
+		},
+		{
+			input: `{"methods": [{"name": "m", "args": [], "returns": {"type": "(uint8)", "struct": "S"}}]}`,
+			err:   `return value of method m refers to unknown struct "S"`,
+		},
+		{
+			input: `{"structs": {"S": [{"name": "a", "type": "uint8"}]}, "methods": [{"name": "m", "args": [{"type": "(uint16)", "struct": "S"}], "returns": {"type": "void"}}]}`,
+			err:   `argument 0 of method m: struct "S" has type (uint8), which does not match type (uint16)`,
+		},
+		{
+			input: `{"events": [{"name": "e", "args": [{"type": "(uint8)", "struct": "S"}]}]}`,
+			err:   `argument 0 of event e refers to unknown struct "S"`,
+		},
+	}
+
+	for _, errorCase := range errorCases {
+		var spec AppSpec
+		err := json.Unmarshal([]byte(errorCase.input), &spec)
+		require.ErrorContains(t, err, errorCase.err)
+	}
+}
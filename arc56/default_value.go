@@ -0,0 +1,67 @@
+package arc56
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/algorand/avm-abi/abi"
+)
+
+// AVM types which values, such as literal default values, may have instead of an ABI type.
+const (
+	AVMBytes  = "AVMBytes"
+	AVMString = "AVMString"
+	AVMUint64 = "AVMUint64"
+)
+
+// DefaultValue returns the default value of the argument at argIndex, decoded into a Go value of
+// the argument type as returned by `abi.Type.Decode`. Only literal defaults can be resolved
+// without reading the application's state or calling a method, so it is an error for the argument
+// to have no default or a default from another source.
+//
+// Literal defaults are base64 encoded values of the default's type, or of the argument type if
+// the default has no type. A default of type "AVMBytes" is returned as a []byte, "AVMString" as a
+// string, and "AVMUint64" as a uint64.
+func (m Method) DefaultValue(argIndex int) (interface{}, error) {
+	if argIndex < 0 || argIndex >= len(m.Args) {
+		return nil, fmt.Errorf("method %s has no argument at index %d", m.Name, argIndex)
+	}
+	defaultValue := m.Args[argIndex].DefaultValue
+	if defaultValue == nil {
+		return nil, fmt.Errorf("argument %d of method %s has no default value", argIndex, m.Name)
+	}
+	if defaultValue.Source != "literal" {
+		return nil, fmt.Errorf(`default value of argument %d of method %s has source "%s", only literal defaults can be resolved`, argIndex, m.Name, defaultValue.Source)
+	}
+
+	value, err := decodeLiteral(defaultValue.Data, defaultValue.Type, m.Args[argIndex].Type)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode default value of argument %d of method %s: %w", argIndex, m.Name, err)
+	}
+	return value, nil
+}
+
+// decodeLiteral decodes the base64 encoded value data of type valueType, or of argType if
+// valueType is empty.
+func decodeLiteral(data, valueType, argType string) (interface{}, error) {
+	encoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, err
+	}
+	if valueType == "" {
+		valueType = argType
+	}
+	switch valueType {
+	case AVMBytes:
+		return encoded, nil
+	case AVMString:
+		return string(encoded), nil
+	case AVMUint64:
+		valueType = "uint64"
+	}
+	abiType, err := abi.TypeOf(valueType)
+	if err != nil {
+		return nil, err
+	}
+	return abiType.Decode(encoded)
+}
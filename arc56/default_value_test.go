@@ -0,0 +1,59 @@
+package arc56
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodDefaultValue(t *testing.T) {
+	t.Parallel()
+
+	var spec AppSpec
+	require.NoError(t, json.Unmarshal([]byte(exampleAppSpec), &spec))
+
+	value, err := spec.Methods[0].DefaultValue(1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), value)
+
+	method := Method{
+		Name: "m",
+		Args: []MethodArg{
+			{Type: "(uint16,bool)", DefaultValue: &DefaultValue{Data: "AAeA", Source: "literal"}},
+			{Type: "string", DefaultValue: &DefaultValue{Data: "aGk=", Type: AVMString, Source: "literal"}},
+			{Type: "byte[]", DefaultValue: &DefaultValue{Data: "AQI=", Type: AVMBytes, Source: "literal"}},
+			{Type: "uint64", DefaultValue: &DefaultValue{Data: "AAAAAAAAAAc=", Type: AVMUint64, Source: "literal"}},
+			{Type: "uint64", DefaultValue: &DefaultValue{Data: "Y291bnQ=", Type: AVMString, Source: "global"}},
+			{Type: "uint64"},
+			{Type: "uint64", DefaultValue: &DefaultValue{Data: "AAE=", Source: "literal"}},
+			{Type: "uint64", DefaultValue: &DefaultValue{Data: "!", Source: "literal"}},
+		},
+	}
+
+	testCases := []struct {
+		index int
+		value interface{}
+		err   string
+	}{
+		{index: 0, value: []interface{}{uint16(7), true}},
+		{index: 1, value: "hi"},
+		{index: 2, value: []byte{1, 2}},
+		{index: 3, value: uint64(7)},
+		{index: 4, err: `default value of argument 4 of method m has source "global", only literal defaults can be resolved`},
+		{index: 5, err: "argument 5 of method m has no default value"},
+		{index: 6, err: "cannot decode default value of argument 6 of method m"},
+		{index: 7, err: "cannot decode default value of argument 7 of method m"},
+		{index: 8, err: "method m has no argument at index 8"},
+		{index: -1, err: "method m has no argument at index -1"},
+	}
+	for _, testCase := range testCases {
+		value, err := method.DefaultValue(testCase.index)
+		if testCase.err != "" {
+			require.ErrorContains(t, err, testCase.err, "index %d", testCase.index)
+			continue
+		}
+		require.NoError(t, err, "index %d", testCase.index)
+		require.Equal(t, testCase.value, value, "index %d", testCase.index)
+	}
+}
@@ -0,0 +1,104 @@
+package arc56
+
+import (
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// TEAL opcodes of the constant blocks which may start a program.
+const (
+	intcblockOpcode  = 0x20
+	bytecblockOpcode = 0x26
+)
+
+// ErrorMessage returns the error message declared for failures at the program counter pc, and
+// whether one is declared. Program counters are relative to the program without the offset given
+// by PCOffsetMethod, see `ConstantBlocksSize`.
+func (p ProgramSourceInfo) ErrorMessage(pc uint64) (string, bool) {
+	for _, info := range p.SourceInfo {
+		if info.ErrorMessage == "" {
+			continue
+		}
+		for _, infoPC := range info.PC {
+			if infoPC == pc {
+				return info.ErrorMessage, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ConstantBlocksSize returns the number of bytes of the intcblock and bytecblock instructions at
+// the start of a compiled program, after its version. Their size depends on the values of template
+// variables, so the program counters of source information whose PCOffsetMethod is "cblocks" do
+// not include it.
+func ConstantBlocksSize(program []byte) (uint64, error) {
+	_, versionSize := binary.Uvarint(program)
+	if versionSize <= 0 {
+		return 0, fmt.Errorf("cannot read program version")
+	}
+	offset := versionSize
+	readUvarint := func() (uint64, error) {
+		value, size := binary.Uvarint(program[offset:])
+		if size <= 0 {
+			return 0, fmt.Errorf("cannot read constant block at byte %d", offset)
+		}
+		offset += size
+		return value, nil
+	}
+	for offset < len(program) && (program[offset] == intcblockOpcode || program[offset] == bytecblockOpcode) {
+		opcode := program[offset]
+		offset++
+		count, err := readUvarint()
+		if err != nil {
+			return 0, err
+		}
+		for i := uint64(0); i < count; i++ {
+			value, err := readUvarint()
+			if err != nil {
+				return 0, err
+			}
+			if opcode == bytecblockOpcode {
+				if value > uint64(len(program)-offset) {
+					return 0, fmt.Errorf("constant block at byte %d is truncated", offset)
+				}
+				offset += int(value)
+			}
+		}
+	}
+	return uint64(offset - versionSize), nil
+}
+
+// logicErrorPC matches the program counter in the error of a failed application call, such as
+// "logic eval error: assert failed pc=12. Details: ...".
+var logicErrorPC = regexp.MustCompile(`pc=(\d+)`)
+
+// MapLogicError returns the error message declared in the approval program source information for
+// the failure reported by errorMessage, the error of a failed application call, and whether one is
+// declared. If the program counters of the approval program are offset by its constant blocks,
+// approvalProgram must be the compiled approval program of the application, with template
+// variables substituted; otherwise it is not used and may be nil.
+func (s AppSpec) MapLogicError(errorMessage string, approvalProgram []byte) (string, bool) {
+	if s.SourceInfo == nil {
+		return "", false
+	}
+	match := logicErrorPC.FindStringSubmatch(errorMessage)
+	if match == nil {
+		return "", false
+	}
+	pc, err := strconv.ParseUint(match[1], 10, 64)
+	if err != nil {
+		return "", false
+	}
+	sourceInfo := s.SourceInfo.Approval
+	if sourceInfo.PCOffsetMethod == "cblocks" {
+		offset, err := ConstantBlocksSize(approvalProgram)
+		if err != nil || offset > pc {
+			return "", false
+		}
+		pc -= offset
+	}
+	return sourceInfo.ErrorMessage(pc)
+}
@@ -0,0 +1,87 @@
+package arc56
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgramSourceInfoErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	info := ProgramSourceInfo{
+		SourceInfo: []SourceInfo{
+			{PC: []uint64{1, 2}, Teal: 3},
+			{PC: []uint64{12, 13}, ErrorMessage: "line not found"},
+		},
+		PCOffsetMethod: "none",
+	}
+	message, ok := info.ErrorMessage(13)
+	require.True(t, ok)
+	require.Equal(t, "line not found", message)
+	_, ok = info.ErrorMessage(1)
+	require.False(t, ok)
+	_, ok = info.ErrorMessage(14)
+	require.False(t, ok)
+}
+
+func TestConstantBlocksSize(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		program []byte
+		size    uint64
+		err     string
+	}{
+		// intcblock 1 300; bytecblock "ab"; intc_0
+		{program: []byte{0x0a, 0x20, 0x02, 0x01, 0xac, 0x02, 0x26, 0x01, 0x02, 'a', 'b', 0x22}, size: 10},
+		// bytecblock "ab"
+		{program: []byte{0x0a, 0x26, 0x01, 0x02, 'a', 'b'}, size: 5},
+		// intc_0
+		{program: []byte{0x0a, 0x22}, size: 0},
+		{program: []byte{0x0a}, size: 0},
+		{program: nil, err: "cannot read program version"},
+		{program: []byte{0x0a, 0x20, 0x02, 0x01}, err: "cannot read constant block at byte 4"},
+		{program: []byte{0x0a, 0x26, 0x01, 0x03, 'a', 'b'}, err: "constant block at byte 4 is truncated"},
+	}
+	for _, testCase := range testCases {
+		size, err := ConstantBlocksSize(testCase.program)
+		if testCase.err != "" {
+			require.EqualError(t, err, testCase.err, "program %x", testCase.program)
+			continue
+		}
+		require.NoError(t, err, "program %x", testCase.program)
+		require.Equal(t, testCase.size, size, "program %x", testCase.program)
+	}
+}
+
+func TestAppSpecMapLogicError(t *testing.T) {
+	t.Parallel()
+
+	var spec AppSpec
+	require.NoError(t, json.Unmarshal([]byte(exampleAppSpec), &spec))
+
+	const failure = "logic eval error: assert failed pc=13. Details: app=1234, pc=13, opcodes=frame_dig -1; assert"
+	message, ok := spec.MapLogicError(failure, nil)
+	require.True(t, ok)
+	require.Equal(t, "line not found", message)
+
+	_, ok = spec.MapLogicError("logic eval error: assert failed pc=14", nil)
+	require.False(t, ok)
+	_, ok = spec.MapLogicError("overspend", nil)
+	require.False(t, ok)
+	_, ok = AppSpec{}.MapLogicError(failure, nil)
+	require.False(t, ok)
+
+	// with constant block offsets, the program counter of the failure includes the constant blocks
+	spec.SourceInfo.Approval.PCOffsetMethod = "cblocks"
+	program := []byte{0x0a, 0x26, 0x01, 0x02, 'a', 'b'}
+	message, ok = spec.MapLogicError("logic eval error: assert failed pc=18", program)
+	require.True(t, ok)
+	require.Equal(t, "line not found", message)
+	_, ok = spec.MapLogicError(failure, program)
+	require.False(t, ok)
+	_, ok = spec.MapLogicError(failure, nil)
+	require.False(t, ok)
+}
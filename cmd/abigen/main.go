@@ -0,0 +1,90 @@
+/*
+Command abigen generates a typed Go client for an ARC-4 contract, using the codegen package.
+
+Usage:
+
+	abigen -spec contract.json -pkg name [-type Name] [-format arc4|arc32|arc56] [-out file.go]
+
+The spec file is an ARC-4 contract description by default, or an ARC-32 or ARC-56 application
+specification with the -format flag. The generated code is written to the -out file, or to standard
+output if -out is not given. abigen is intended to be run from a go:generate directive:
+
+	//go:generate go run github.com/algorand/avm-abi/cmd/abigen -spec calculator.json -pkg calculator -out calculator.go
+*/
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/algorand/avm-abi/abi"
+	"github.com/algorand/avm-abi/arc32"
+	"github.com/algorand/avm-abi/arc56"
+	"github.com/algorand/avm-abi/codegen"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "path of the contract specification file")
+	format := flag.String("format", "arc4", "format of the specification file: arc4, arc32, or arc56")
+	pkg := flag.String("pkg", "", "package name of the generated code")
+	typeName := flag.String("type", "", "name of the generated client type (default: the contract name)")
+	out := flag.String("out", "", "path of the generated file (default: standard output)")
+	flag.Parse()
+
+	if err := run(*specPath, *format, *pkg, *typeName, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "abigen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, format, pkg, typeName, out string) error {
+	if specPath == "" || pkg == "" {
+		return fmt.Errorf("the -spec and -pkg flags are required")
+	}
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return err
+	}
+	opts := codegen.Options{Package: pkg, TypeName: typeName}
+	var source []byte
+	if format == "arc56" {
+		// ARC-56 clients embed the app spec, to keep the named tuple types of its structs.
+		var spec arc56.AppSpec
+		if err := json.Unmarshal(data, &spec); err != nil {
+			return fmt.Errorf("cannot parse %s: %w", specPath, err)
+		}
+		source, err = codegen.GenerateARC56(spec, opts)
+	} else {
+		var contract abi.Contract
+		contract, err = parseContract(data, format)
+		if err != nil {
+			return fmt.Errorf("cannot parse %s: %w", specPath, err)
+		}
+		source, err = codegen.Generate(contract, opts)
+	}
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+	return os.WriteFile(out, source, 0o644)
+}
+
+func parseContract(data []byte, format string) (abi.Contract, error) {
+	switch format {
+	case "arc4":
+		var contract abi.Contract
+		err := json.Unmarshal(data, &contract)
+		return contract, err
+	case "arc32":
+		var spec arc32.AppSpec
+		err := json.Unmarshal(data, &spec)
+		return spec.Contract, err
+	default:
+		return abi.Contract{}, fmt.Errorf("unknown format %q", format)
+	}
+}
@@ -0,0 +1,438 @@
+/*
+Package codegen generates typed Go clients for ARC-4 contracts.
+
+The generated code defines one client type per contract, with a selector variable, an argument
+encoding method, and, for methods which return a value, a return decoding method for each method of
+the contract. It only depends on the abi package of this module, and on the arc56 package for clients
+generated from ARC-56 app specs by `GenerateARC56`.
+
+Clients are usually generated with the abigen command, for example from a go:generate directive:
+
+	//go:generate go run github.com/algorand/avm-abi/cmd/abigen -spec calculator.json -pkg calculator -out calculator.go
+*/
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/algorand/avm-abi/abi"
+	"github.com/algorand/avm-abi/arc56"
+)
+
+// Options configures the code generated by `Generate` and `GenerateARC56`.
+type Options struct {
+	// Package is the name of the package of the generated code.
+	Package string
+	// TypeName is the name of the generated client type. If it is empty, the contract name converted
+	// to an exported Go identifier is used.
+	TypeName string
+}
+
+type methodData struct {
+	Index        int
+	GoName       string
+	Signature    string
+	Desc         []string
+	Selector     string
+	Params       []paramData
+	CallArgs     string
+	TxnArgs      int
+	ReturnType   string
+	ReturnAssert bool
+}
+
+type paramData struct {
+	Name   string
+	GoType string
+}
+
+type fileData struct {
+	Package string
+	Type    string
+	Name    string
+	Desc    []string
+	Spec    string
+	ARC56   bool
+	UsesBig bool
+	Methods []methodData
+}
+
+// Generate returns the gofmt-formatted source of a Go file defining a client for the contract. The
+// contract must be valid, as reported by `abi.Contract.Validate`.
+//
+// Method names are converted to exported Go identifiers; overloaded methods are suffixed with their
+// number of arguments. Arguments of unsigned integer, bool, byte, string, and address types are
+// mapped to the Go types produced by `abi.Type.Decode`, reference arguments to a [32]byte account or
+// uint64 ID, and all other types to interface{}. Transaction arguments are omitted, since they are
+// passed as the preceding transactions of the call.
+func Generate(contract abi.Contract, opts Options) ([]byte, error) {
+	spec, err := json.MarshalIndent(contract, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return generate(contract, spec, false, opts)
+}
+
+// GenerateARC56 returns the gofmt-formatted source of a Go file defining a client for the contract
+// of an ARC-56 app spec, as returned by `arc56.AppSpec.Contract`. The client embeds the app spec,
+// so the arguments and return values of its methods which are ARC-56 structs keep their named
+// tuple types. Otherwise it is the same as the client returned by `Generate`.
+func GenerateARC56(spec arc56.AppSpec, opts Options) ([]byte, error) {
+	encoded, err := json.MarshalIndent(spec, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return generate(spec.Contract(), encoded, true, opts)
+}
+
+// generate returns the source of a client for the contract, embedding spec, which is the ARC-56 app
+// spec of the contract if isARC56 is true, and its ARC-4 description otherwise.
+func generate(contract abi.Contract, spec []byte, isARC56 bool, opts Options) ([]byte, error) {
+	if !token.IsIdentifier(opts.Package) {
+		return nil, fmt.Errorf("invalid package name: %q", opts.Package)
+	}
+	if err := contract.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid contract: %w", err)
+	}
+	typeName := opts.TypeName
+	if typeName == "" {
+		typeName = exportedName(contract.Name)
+	}
+	if !token.IsIdentifier(typeName) || !token.IsExported(typeName) {
+		return nil, fmt.Errorf("invalid type name: %q", typeName)
+	}
+
+	data := fileData{
+		Package: opts.Package,
+		Type:    typeName,
+		Name:    contract.Name,
+		Desc:    commentLines(contract.Desc),
+		Spec:    goStringLiteral(string(spec)),
+		ARC56:   isARC56,
+	}
+
+	goNames, err := methodNames(contract.Methods)
+	if err != nil {
+		return nil, err
+	}
+	for i, method := range contract.Methods {
+		m, usesBig, err := newMethodData(i, goNames[i], method)
+		if err != nil {
+			return nil, err
+		}
+		data.UsesBig = data.UsesBig || usesBig
+		data.Methods = append(data.Methods, m)
+	}
+
+	var source bytes.Buffer
+	if err := fileTemplate.Execute(&source, data); err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("cannot format generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+func newMethodData(index int, goName string, method abi.Method) (methodData, bool, error) {
+	selector := method.GetSelector()
+	selectorBytes := make([]string, len(selector))
+	for i, b := range selector {
+		selectorBytes[i] = fmt.Sprintf("0x%02x", b)
+	}
+	m := methodData{
+		Index:     index,
+		GoName:    goName,
+		Signature: method.GetSignature(),
+		Desc:      commentLines(method.Desc),
+		Selector:  strings.Join(selectorBytes, ", "),
+		TxnArgs:   method.TxnArgCount(),
+	}
+
+	parsedArgs, err := method.ParsedArgs()
+	if err != nil {
+		return methodData{}, false, err
+	}
+	usesBig := false
+	taken := map[string]bool{"c": true, "sender": true, "appID": true}
+	callArgs := make([]string, len(method.Args))
+	for i, name := range method.ArgNames() {
+		arg := parsedArgs[i]
+		if !arg.IsEncoded() {
+			callArgs[i] = "nil"
+			continue
+		}
+		goType, big, err := argGoType(arg)
+		if err != nil {
+			return methodData{}, false, err
+		}
+		usesBig = usesBig || big
+		paramName := unexportedName(name)
+		for taken[paramName] {
+			paramName += "_"
+		}
+		taken[paramName] = true
+		m.Params = append(m.Params, paramData{Name: paramName, GoType: goType})
+		callArgs[i] = paramName
+	}
+	m.CallArgs = strings.Join(callArgs, ", ")
+
+	if method.Returns.Type != abi.VoidReturnType {
+		returnType, err := method.Returns.Parse()
+		if err != nil {
+			return methodData{}, false, err
+		}
+		goType, big, err := valueGoType(returnType)
+		if err != nil {
+			return methodData{}, false, err
+		}
+		usesBig = usesBig || big
+		m.ReturnType = goType
+		m.ReturnAssert = goType != "interface{}"
+	}
+	return m, usesBig, nil
+}
+
+// methodNames returns the Go method name of each method, suffixing overloaded methods with their
+// number of arguments.
+func methodNames(methods []abi.Method) ([]string, error) {
+	counts := make(map[string]int, len(methods))
+	for _, method := range methods {
+		counts[exportedName(method.Name)]++
+	}
+	names := make([]string, len(methods))
+	taken := map[string]string{"Contract": "the Contract accessor"}
+	for i, method := range methods {
+		name := exportedName(method.Name)
+		if counts[name] > 1 {
+			name += strconv.Itoa(len(method.Args))
+		}
+		generated := []string{name}
+		if method.Returns.Type != abi.VoidReturnType {
+			generated = append(generated, "Decode"+name+"Return")
+		}
+		for _, identifier := range generated {
+			if other, ok := taken[identifier]; ok {
+				return nil, fmt.Errorf("method %s and %s both generate the Go method %s", method.GetSignature(), other, identifier)
+			}
+			taken[identifier] = method.GetSignature()
+		}
+		names[i] = name
+	}
+	return names, nil
+}
+
+var (
+	uintTypePattern   = regexp.MustCompile(`^uint(\d+)$`)
+	ufixedTypePattern = regexp.MustCompile(`^ufixed(\d+)x\d+$`)
+)
+
+// argGoType returns the Go type of an argument type, and whether it is *big.Int.
+func argGoType(arg abi.Arg) (string, bool, error) {
+	if abiType, ok := arg.Type(); ok {
+		return valueGoType(abiType)
+	}
+	if arg.String() == abi.AccountReferenceType {
+		return "[32]byte", false, nil
+	}
+	return "uint64", false, nil
+}
+
+// valueGoType returns the Go type of an ABI type, and whether it is *big.Int.
+func valueGoType(abiType abi.Type) (string, bool, error) {
+	canonical := abiType.String()
+	switch canonical {
+	case "bool", "byte", "string":
+		return canonical, false, nil
+	case "address":
+		return "[]byte", false, nil
+	}
+
+	match := uintTypePattern.FindStringSubmatch(canonical)
+	if match == nil {
+		match = ufixedTypePattern.FindStringSubmatch(canonical)
+	}
+	if match == nil {
+		return "interface{}", false, nil
+	}
+	bitSize, err := strconv.Atoi(match[1])
+	if err != nil {
+		return "", false, err
+	}
+	switch {
+	case bitSize <= 8:
+		return "uint8", false, nil
+	case bitSize <= 16:
+		return "uint16", false, nil
+	case bitSize <= 32:
+		return "uint32", false, nil
+	case bitSize <= 64:
+		return "uint64", false, nil
+	default:
+		return "*big.Int", true, nil
+	}
+}
+
+// identifierParts splits a name into its alphanumeric parts.
+func identifierParts(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// exportedName converts a name, such as "get_balance", to an exported Go identifier, such as
+// "GetBalance".
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, part := range identifierParts(name) {
+		runes := []rune(part)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	identifier := b.String()
+	if identifier == "" || !token.IsExported(identifier) {
+		identifier = "X" + identifier
+	}
+	return identifier
+}
+
+// unexportedName converts a name, such as "asset_id", to an unexported Go identifier, such as
+// "assetId".
+func unexportedName(name string) string {
+	var b strings.Builder
+	for i, part := range identifierParts(name) {
+		runes := []rune(part)
+		if i == 0 {
+			b.WriteRune(unicode.ToLower(runes[0]))
+		} else {
+			b.WriteRune(unicode.ToUpper(runes[0]))
+		}
+		b.WriteString(string(runes[1:]))
+	}
+	identifier := b.String()
+	// keywords, such as "type", are not identifiers
+	if !token.IsIdentifier(identifier) {
+		runes := []rune(identifier)
+		if len(runes) > 0 {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		identifier = "arg" + string(runes)
+	}
+	return identifier
+}
+
+// commentLines splits a description into lines for a doc comment.
+func commentLines(desc string) []string {
+	desc = strings.TrimSpace(desc)
+	if desc == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(desc, "\r\n", "\n"), "\n")
+}
+
+// goStringLiteral returns a Go string literal for text, using a raw string literal when possible.
+func goStringLiteral(text string) string {
+	if strings.ContainsAny(text, "`\r") {
+		return strconv.Quote(text)
+	}
+	return "`" + text + "`"
+}
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by abigen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"encoding/json"
+{{- if .UsesBig}}
+	"math/big"
+{{- end}}
+
+	"github.com/algorand/avm-abi/abi"
+{{- if .ARC56}}
+	"github.com/algorand/avm-abi/arc56"
+{{- end}}
+)
+
+{{if .ARC56 -}}
+// {{.Type}}Spec is the ARC-56 app spec of the {{.Name}} contract.
+{{- else -}}
+// {{.Type}}Spec is the ARC-4 description of the {{.Name}} contract.
+{{- end}}
+const {{.Type}}Spec = {{.Spec}}
+{{range .Methods}}
+// {{$.Type}}{{.GoName}}Selector is the selector of the {{.Signature}} method.
+var {{$.Type}}{{.GoName}}Selector = abi.Selector{ {{- .Selector -}} }
+{{end}}
+// {{.Type}} is a client for the {{.Name}} contract.
+{{- if .Desc}}
+//
+{{- range .Desc}}
+// {{.}}
+{{- end}}
+{{- end}}
+type {{.Type}} struct {
+	contract abi.Contract
+}
+
+// New{{.Type}} returns a client for the {{.Name}} contract.
+func New{{.Type}}() (*{{.Type}}, error) {
+{{- if .ARC56}}
+	var spec arc56.AppSpec
+	if err := json.Unmarshal([]byte({{.Type}}Spec), &spec); err != nil {
+		return nil, err
+	}
+	return &{{.Type}}{contract: spec.Contract()}, nil
+{{- else}}
+	var contract abi.Contract
+	if err := json.Unmarshal([]byte({{.Type}}Spec), &contract); err != nil {
+		return nil, err
+	}
+	return &{{.Type}}{contract: contract}, nil
+{{- end}}
+}
+
+// Contract returns the ARC-4 description of the contract.
+func (c *{{.Type}}) Contract() abi.Contract {
+	return c.contract
+}
+{{range .Methods}}
+// {{.GoName}} encodes the arguments of a call to the {{.Signature}} method, made by sender to the application appID.
+{{- if .TxnArgs}}
+// The transaction arguments of the method must precede the call in the group, in order.
+{{- end}}
+{{- if .Desc}}
+//
+{{- range .Desc}}
+// {{.}}
+{{- end}}
+{{- end}}
+func (c *{{$.Type}}) {{.GoName}}(sender [32]byte, appID uint64{{range .Params}}, {{.Name}} {{.GoType}}{{end}}) (*abi.MethodCallArgs, error) {
+	return abi.BuildMethodCallArgs(c.contract.Methods[{{.Index}}], sender, appID, []interface{}{ {{- .CallArgs -}} })
+}
+{{- if .ReturnType}}
+
+// Decode{{.GoName}}Return decodes the return value of the {{.Signature}} method from the logs of a call.
+func (c *{{$.Type}}) Decode{{.GoName}}Return(logs [][]byte) (result {{.ReturnType}}, err error) {
+	value, err := c.contract.Methods[{{.Index}}].DecodeReturn(logs)
+	if err != nil {
+		return result, err
+	}
+{{- if .ReturnAssert}}
+	return value.({{.ReturnType}}), nil
+{{- else}}
+	return value, nil
+{{- end}}
+}
+{{- end}}
+{{end}}`))
@@ -0,0 +1,147 @@
+package codegen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/algorand/avm-abi/abi"
+	"github.com/algorand/avm-abi/arc56"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateExample(t *testing.T) {
+	t.Parallel()
+
+	// the generated example must be up to date with the generator
+	spec, err := os.ReadFile(filepath.Join("internal", "example", "calculator.json"))
+	require.NoError(t, err)
+	expected, err := os.ReadFile(filepath.Join("internal", "example", "calculator.go"))
+	require.NoError(t, err)
+
+	var contract abi.Contract
+	require.NoError(t, json.Unmarshal(spec, &contract))
+	source, err := Generate(contract, Options{Package: "example"})
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(source))
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	contract := abi.Contract{
+		Name: "token-v2",
+		Methods: []abi.Method{
+			{Name: "transfer", Args: []abi.MethodArg{{Type: "axfer"}, {Type: "address", Name: "to"}, {Type: "ufixed64x2", Name: "c"}}, Returns: abi.MethodReturn{Type: "void"}},
+			{Name: "balance", Args: []abi.MethodArg{{Type: "asset", Name: "sender"}}, Returns: abi.MethodReturn{Type: "uint256"}},
+		},
+	}
+	source, err := Generate(contract, Options{Package: "token"})
+	require.NoError(t, err)
+	require.Contains(t, string(source), "type TokenV2 struct")
+	require.Contains(t, string(source), "func (c *TokenV2) Transfer(sender [32]byte, appID uint64, to []byte, c_ uint64) (*abi.MethodCallArgs, error) {")
+	require.Contains(t, string(source), "[]interface{}{nil, to, c_}")
+	require.Contains(t, string(source), "func (c *TokenV2) Balance(sender [32]byte, appID uint64, sender_ uint64) (*abi.MethodCallArgs, error) {")
+	require.Contains(t, string(source), "func (c *TokenV2) DecodeBalanceReturn(logs [][]byte) (result *big.Int, err error) {")
+	require.Contains(t, string(source), "// The transaction arguments of the method must precede the call in the group, in order.")
+
+	source, err = Generate(contract, Options{Package: "token", TypeName: "Client"})
+	require.NoError(t, err)
+	require.Contains(t, string(source), "type Client struct")
+
+	// types are mapped in their canonical form
+	contract.Methods = []abi.Method{{Name: "set", Args: []abi.MethodArg{{Type: "uint064", Name: "v"}, {Type: "account", Name: "a"}}, Returns: abi.MethodReturn{Type: "byte"}}}
+	source, err = Generate(contract, Options{Package: "token"})
+	require.NoError(t, err)
+	require.Contains(t, string(source), "func (c *TokenV2) Set(sender [32]byte, appID uint64, v uint64, a [32]byte) (*abi.MethodCallArgs, error) {")
+	require.Contains(t, string(source), "func (c *TokenV2) DecodeSetReturn(logs [][]byte) (result byte, err error) {")
+}
+
+func TestGenerateARC56(t *testing.T) {
+	t.Parallel()
+
+	var spec arc56.AppSpec
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"arcs": [4, 56],
+		"name": "Shapes",
+		"structs": {"Point": [{"name": "x", "type": "uint64"}, {"name": "y", "type": "uint64"}]},
+		"methods": [
+			{"name": "get_start", "args": [{"type": "uint64", "name": "id"}], "returns": {"type": "(uint64,uint64)", "struct": "Point"}}
+		]
+	}`), &spec))
+	source, err := GenerateARC56(spec, Options{Package: "shapes"})
+	require.NoError(t, err)
+	require.Contains(t, string(source), "\"github.com/algorand/avm-abi/arc56\"")
+	require.Contains(t, string(source), "// ShapesSpec is the ARC-56 app spec of the Shapes contract.")
+	require.Contains(t, string(source), "return &Shapes{contract: spec.Contract()}, nil")
+	require.Contains(t, string(source), "func (c *Shapes) DecodeGetStartReturn(logs [][]byte) (result interface{}, err error) {")
+	require.Contains(t, string(source), `"struct": "Point"`)
+
+	// the embedded app spec must decode to the same contract
+	var embedded arc56.AppSpec
+	start := strings.Index(string(source), "const ShapesSpec = `") + len("const ShapesSpec = `")
+	end := strings.Index(string(source)[start:], "`") + start
+	require.NoError(t, json.Unmarshal(source[start:end], &embedded))
+	require.Equal(t, spec.Contract(), embedded.Contract())
+
+	source, err = Generate(spec.Contract(), Options{Package: "shapes"})
+	require.NoError(t, err)
+	require.NotContains(t, string(source), "arc56")
+}
+
+func TestGenerateErrors(t *testing.T) {
+	t.Parallel()
+
+	valid := abi.Contract{Name: "Valid", Methods: []abi.Method{{Name: "noop", Returns: abi.MethodReturn{Type: "void"}}}}
+
+	_, err := Generate(valid, Options{Package: "not a package"})
+	require.EqualError(t, err, `invalid package name: "not a package"`)
+
+	_, err = Generate(valid, Options{Package: "p", TypeName: "unexported"})
+	require.EqualError(t, err, `invalid type name: "unexported"`)
+
+	_, err = Generate(abi.Contract{}, Options{Package: "p"})
+	require.ErrorContains(t, err, "invalid contract: contract has no name")
+
+	_, err = Generate(abi.Contract{
+		Name: "Collision",
+		Methods: []abi.Method{
+			{Name: "get_value", Returns: abi.MethodReturn{Type: "void"}},
+			{Name: "getValue", Returns: abi.MethodReturn{Type: "void"}},
+		},
+	}, Options{Package: "p"})
+	require.EqualError(t, err, "method getValue()void and get_value()void both generate the Go method GetValue0")
+
+	_, err = Generate(abi.Contract{
+		Name:    "Collision",
+		Methods: []abi.Method{{Name: "contract", Returns: abi.MethodReturn{Type: "void"}}},
+	}, Options{Package: "p"})
+	require.EqualError(t, err, "method contract()void and the Contract accessor both generate the Go method Contract")
+}
+
+func TestIdentifierNames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		exported   string
+		unexported string
+	}{
+		{name: "add", exported: "Add", unexported: "add"},
+		{name: "get_balance", exported: "GetBalance", unexported: "getBalance"},
+		{name: "assetID", exported: "AssetID", unexported: "assetID"},
+		{name: "2fa", exported: "X2fa", unexported: "arg2fa"},
+		{name: "type", exported: "Type", unexported: "argType"},
+		{name: "", exported: "X", unexported: "arg"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, test.exported, exportedName(test.name))
+			require.Equal(t, test.unexported, unexportedName(test.name))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package example
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/algorand/avm-abi/abi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculator(t *testing.T) {
+	t.Parallel()
+
+	calculator, err := NewCalculator()
+	require.NoError(t, err)
+	contract := calculator.Contract()
+	require.Equal(t, "Calculator", contract.Name)
+	require.Equal(t, contract.Methods[0].GetSelector(), CalculatorAdd2Selector)
+	require.Equal(t, contract.Methods[3].GetSelector(), CalculatorGetTotalSelector)
+
+	sender := [32]byte{1}
+	owner := [32]byte{2}
+	call, err := calculator.Deposit(sender, 10, owner, "memo")
+	require.NoError(t, err)
+	require.Equal(t, [][32]byte{owner}, call.Accounts)
+	decoded, err := contract.DecodeCall(call.ApplicationArgs)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		abi.DecodedTransaction{Type: "pay", GroupOffset: -1},
+		abi.DecodedReference{Type: "account", Index: 1},
+		"memo",
+	}, decoded.Args)
+
+	call, err = calculator.Add1(sender, 10, []uint8{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, CalculatorAdd1Selector[:], call.ApplicationArgs[0])
+
+	returnLog := append(abi.MethodReturnPrefix(), make([]byte, 16)...)
+	returnLog[len(returnLog)-1] = 5
+	sum, err := calculator.DecodeAdd2Return([][]byte{returnLog})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(5), sum)
+
+	_, err = calculator.DecodeAdd1Return(nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,4 @@
+// Package example holds a client generated from calculator.json, used to test the generated code.
+package example
+
+//go:generate go run ../../../cmd/abigen -spec calculator.json -pkg example -out calculator.go
@@ -0,0 +1,148 @@
+/*
+Package uri provides parsing and building of ARC-26 URIs, the algorand:// deep links which wallets
+open to prefill payment and asset transfer transactions.
+
+See https://arc.algorand.foundation/ARCs/arc-0026 for the corresponding specification.
+*/
+package uri
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/algorand/avm-abi/address"
+)
+
+// Scheme is the scheme of ARC-26 URIs.
+const Scheme = "algorand"
+
+const schemePrefix = Scheme + "://"
+
+// Query parameters of ARC-26 URIs.
+const (
+	labelParam  = "label"
+	amountParam = "amount"
+	assetParam  = "asset"
+	noteParam   = "note"
+	xnoteParam  = "xnote"
+)
+
+// Payment is an ARC-26 URI, which requests a payment of Algos or, if AssetID is set, an asset
+// transfer to an address.
+type Payment struct {
+	// Address is the receiver of the payment.
+	Address address.Address
+	// Label is an optional label of the receiver, for display.
+	Label string
+	// Amount is the optional amount of the payment, in microAlgos or in base units of the asset.
+	// Nil means the amount is not specified, so an amount of zero, as used for asset opt-ins, can
+	// be requested.
+	Amount *uint64
+	// AssetID is the optional ID of the asset to transfer. Zero means the payment is in Algos.
+	AssetID uint64
+	// Note is an optional note for the transaction, which the user may modify.
+	Note string
+	// XNote is an optional note for the transaction, which the user must not modify. At most one of
+	// Note and XNote may be set.
+	XNote string
+}
+
+// ParsePayment parses an ARC-26 URI, such as algorand://<address>?amount=150500000&note=Lunch.
+// The address is verified as by address.FromString, and unknown query parameters are ignored, as
+// ARC-26 requires.
+func ParsePayment(uri string) (Payment, error) {
+	if len(uri) < len(schemePrefix) || !strings.EqualFold(uri[:len(schemePrefix)], schemePrefix) {
+		return Payment{}, fmt.Errorf("URI %q does not start with %s", uri, schemePrefix)
+	}
+	addressString, rawQuery, _ := strings.Cut(uri[len(schemePrefix):], "?")
+	addressBytes, err := address.FromString(addressString)
+	if err != nil {
+		return Payment{}, fmt.Errorf("URI %q has an invalid address: %w", uri, err)
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return Payment{}, fmt.Errorf("URI %q has an invalid query: %w", uri, err)
+	}
+
+	payment := Payment{Address: addressBytes}
+	for param, values := range query {
+		if len(values) > 1 {
+			return Payment{}, fmt.Errorf("URI %q has %d %s parameters", uri, len(values), param)
+		}
+		value := values[0]
+		switch param {
+		case labelParam:
+			payment.Label = value
+		case amountParam:
+			var amount uint64
+			amount, err = parseUint(param, value)
+			payment.Amount = &amount
+		case assetParam:
+			payment.AssetID, err = parseUint(param, value)
+		case noteParam:
+			payment.Note = value
+		case xnoteParam:
+			payment.XNote = value
+		}
+		if err != nil {
+			return Payment{}, fmt.Errorf("URI %q has an invalid %w", uri, err)
+		}
+	}
+	if err := payment.Validate(); err != nil {
+		return Payment{}, fmt.Errorf("URI %q is invalid: %w", uri, err)
+	}
+	return payment, nil
+}
+
+// parseUint parses the value of a numeric query parameter, which must be made of decimal digits.
+func parseUint(param, value string) (uint64, error) {
+	if value == "" || strings.TrimLeft(value, "0123456789") != "" {
+		return 0, fmt.Errorf("%s parameter %q, which should be a decimal integer", param, value)
+	}
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s parameter %q, which does not fit in a uint64", param, value)
+	}
+	return parsed, nil
+}
+
+// Validate checks that the payment can be encoded as an ARC-26 URI.
+func (p Payment) Validate() error {
+	if p.Note != "" && p.XNote != "" {
+		return errors.New("only one of note and xnote can be set")
+	}
+	return nil
+}
+
+// String returns the ARC-26 URI of the payment, with the parameters which are set in the order
+// label, amount, asset, note, and xnote. Parameter values are percent-encoded. Use Validate to
+// check that the URI is valid.
+func (p Payment) String() string {
+	var b strings.Builder
+	b.WriteString(schemePrefix)
+	b.WriteString(p.Address.String())
+	separator := byte('?')
+	writeParam := func(param, value string) {
+		if value == "" {
+			return
+		}
+		b.WriteByte(separator)
+		b.WriteString(param)
+		b.WriteByte('=')
+		b.WriteString(strings.ReplaceAll(url.QueryEscape(value), "+", "%20"))
+		separator = '&'
+	}
+	writeParam(labelParam, p.Label)
+	if p.Amount != nil {
+		writeParam(amountParam, strconv.FormatUint(*p.Amount, 10))
+	}
+	if p.AssetID != 0 {
+		writeParam(assetParam, strconv.FormatUint(p.AssetID, 10))
+	}
+	writeParam(noteParam, p.Note)
+	writeParam(xnoteParam, p.XNote)
+	return b.String()
+}
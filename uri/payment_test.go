@@ -0,0 +1,128 @@
+package uri
+
+import (
+	"testing"
+
+	"github.com/algorand/avm-abi/address"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleAddress = "TMTAD6N22HCS2LKH7677L2KFLT3PAQWY6M4JFQFXQS32ECBFC23F57RYX4"
+
+func TestParsePayment(t *testing.T) {
+	t.Parallel()
+
+	addr, err := address.FromString(exampleAddress)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		uri      string
+		expected Payment
+	}{
+		{
+			uri:      "algorand://" + exampleAddress,
+			expected: Payment{Address: addr},
+		},
+		{
+			uri:      "algorand://" + exampleAddress + "?label=Silvio",
+			expected: Payment{Address: addr, Label: "Silvio"},
+		},
+		{
+			uri:      "algorand://" + exampleAddress + "?amount=150500000",
+			expected: Payment{Address: addr, Amount: uint64Ptr(150500000)},
+		},
+		{
+			uri:      "algorand://" + exampleAddress + "?amount=150&asset=45",
+			expected: Payment{Address: addr, Amount: uint64Ptr(150), AssetID: 45},
+		},
+		{
+			uri:      "algorand://" + exampleAddress + "?label=Silvio&amount=150500000&note=Lunch%20%26%20coffee",
+			expected: Payment{Address: addr, Label: "Silvio", Amount: uint64Ptr(150500000), Note: "Lunch & coffee"},
+		},
+		{
+			uri:      "algorand://" + exampleAddress + "?amount=0&asset=45",
+			expected: Payment{Address: addr, Amount: uint64Ptr(0), AssetID: 45},
+		},
+		{
+			uri:      "ALGORAND://" + exampleAddress + "?xnote=Invoice+42&other=ignored",
+			expected: Payment{Address: addr, XNote: "Invoice 42"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.uri, func(t *testing.T) {
+			t.Parallel()
+			payment, err := ParsePayment(testCase.uri)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, payment)
+		})
+	}
+
+	errorCases := []struct {
+		uri string
+		err string
+	}{
+		{uri: "", err: `URI "" does not start with algorand://`},
+		{uri: "https://" + exampleAddress, err: "does not start with algorand://"},
+		{uri: "algorand:" + exampleAddress, err: "does not start with algorand://"},
+		{uri: "algorand://", err: "has an invalid address"},
+		{uri: "algorand://UMTAD6N22HCS2LKH7677L2KFLT3PAQWY6M4JFQFXQS32ECBFC23F57RYX4", err: "has an invalid address"},
+		{uri: "algorand://" + exampleAddress + "?amount=1.5", err: `has an invalid amount parameter "1.5", which should be a decimal integer`},
+		{uri: "algorand://" + exampleAddress + "?amount=-1", err: `has an invalid amount parameter "-1", which should be a decimal integer`},
+		{uri: "algorand://" + exampleAddress + "?amount=", err: `has an invalid amount parameter "", which should be a decimal integer`},
+		{uri: "algorand://" + exampleAddress + "?asset=18446744073709551616", err: `has an invalid asset parameter "18446744073709551616", which does not fit in a uint64`},
+		{uri: "algorand://" + exampleAddress + "?amount=1&amount=2", err: "has 2 amount parameters"},
+		{uri: "algorand://" + exampleAddress + "?note=a&xnote=b", err: "is invalid: only one of note and xnote can be set"},
+		{uri: "algorand://" + exampleAddress + "?note=%zz", err: "has an invalid query"},
+	}
+	for _, errorCase := range errorCases {
+		_, err := ParsePayment(errorCase.uri)
+		require.ErrorContains(t, err, errorCase.err, errorCase.uri)
+	}
+
+	_, err = ParsePayment("algorand://" + exampleAddress[1:])
+	require.ErrorIs(t, err, address.ErrWrongLength)
+}
+
+func TestPaymentString(t *testing.T) {
+	t.Parallel()
+
+	addr, err := address.FromString(exampleAddress)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		payment  Payment
+		expected string
+	}{
+		{
+			payment:  Payment{Address: addr},
+			expected: "algorand://" + exampleAddress,
+		},
+		{
+			payment:  Payment{Address: addr, Label: "Silvio", Amount: uint64Ptr(150500000), Note: "Lunch & coffee"},
+			expected: "algorand://" + exampleAddress + "?label=Silvio&amount=150500000&note=Lunch%20%26%20coffee",
+		},
+		{
+			payment:  Payment{Address: addr, Amount: uint64Ptr(150), AssetID: 45, XNote: "a+b=c?"},
+			expected: "algorand://" + exampleAddress + "?amount=150&asset=45&xnote=a%2Bb%3Dc%3F",
+		},
+		{
+			// an amount of zero is kept, as in asset opt-in requests
+			payment:  Payment{Address: addr, Amount: uint64Ptr(0), AssetID: 45},
+			expected: "algorand://" + exampleAddress + "?amount=0&asset=45",
+		},
+	}
+	for _, testCase := range testCases {
+		require.NoError(t, testCase.payment.Validate())
+		require.Equal(t, testCase.expected, testCase.payment.String())
+
+		parsed, err := ParsePayment(testCase.payment.String())
+		require.NoError(t, err)
+		require.Equal(t, testCase.payment, parsed)
+	}
+
+	require.EqualError(t, Payment{Address: addr, Note: "a", XNote: "b"}.Validate(), "only one of note and xnote can be set")
+}
+
+func uint64Ptr(value uint64) *uint64 {
+	return &value
+}